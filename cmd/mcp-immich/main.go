@@ -51,6 +51,14 @@ func main() {
 		transportMode = "http"
 	}
 
+	// The stdio transport owns stdout for the protocol stream; make sure
+	// logging stays on stderr regardless of the logging format
+	if transportMode == "stdio" {
+		if cfg.LogJSON {
+			log.Logger = log.Logger.Output(os.Stderr)
+		}
+	}
+
 	server, err := mcpserver.New(cfg)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to initialise MCP server")
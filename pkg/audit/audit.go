@@ -0,0 +1,120 @@
+// Package audit persists a JSONL log of mutating tool invocations: who
+// called what, with which arguments, touching which assets and albums, and
+// how it ended. Recording is best-effort — a log that cannot be written
+// never fails the call it describes.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Entry records one mutating tool invocation.
+type Entry struct {
+	At          time.Time              `json:"at"`
+	Tool        string                 `json:"tool"`
+	Caller      string                 `json:"caller"`
+	Arguments   map[string]interface{} `json:"arguments,omitempty"`
+	AffectedIDs []string               `json:"affectedIds,omitempty"`
+	Success     bool                   `json:"success"`
+	Error       string                 `json:"error,omitempty"`
+	DurationMs  int64                  `json:"durationMs"`
+}
+
+// Log appends entries to a JSONL file.
+type Log struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+// Open opens (or creates) the audit log at path for appending.
+func Open(path string) (*Log, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &Log{path: path, file: file}, nil
+}
+
+// Record appends one entry. A nil Log discards it.
+func (l *Log) Record(entry Entry) error {
+	if l == nil {
+		return nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// Tail returns up to limit entries, newest first, optionally filtered to
+// one tool. Torn trailing lines from interrupted writes are skipped.
+func (l *Log) Tail(limit int, tool string) ([]Entry, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.Open(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		if tool != "" && entry.Tool != tool {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan audit log: %w", err)
+	}
+
+	// Newest first, bounded
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// Close closes the underlying file.
+func (l *Log) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
@@ -23,6 +23,27 @@ type Provider interface {
 	Authenticate(r *http.Request) (context.Context, error)
 }
 
+// CallerIdentity returns a loggable identity for the authenticated caller:
+// a masked API key, "oauth" for bearer-token callers, or "anonymous" when
+// the request carried no credentials (no-op provider or stdio transport).
+func CallerIdentity(ctx context.Context) string {
+	if key, ok := ctx.Value(contextKeyAPIKey).(string); ok && key != "" {
+		return "api-key:" + maskKey(key)
+	}
+	if token, ok := ctx.Value(contextKeyOAuthToken).(string); ok && token != "" {
+		return "oauth"
+	}
+	return "anonymous"
+}
+
+// maskKey keeps only the last four characters of a credential.
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
+
 // NoOpProvider provides no authentication
 type NoOpProvider struct{}
 
@@ -154,4 +175,4 @@ func (p *MultiProvider) Authenticate(r *http.Request) (context.Context, error) {
 	}
 
 	return nil, fmt.Errorf("no auth providers configured")
-}
\ No newline at end of file
+}
@@ -0,0 +1,85 @@
+package bulkops
+
+import (
+	"sync"
+	"time"
+)
+
+// Bounds and tuning for adaptive chunk sizing.
+const (
+	chunkSizeMin     = 25
+	chunkSizeMax     = 2000
+	chunkSizeStep    = 50               // additive increase per fast call
+	chunkSlowLatency = 10 * time.Second // calls slower than this shrink the chunk
+	chunkFastLatency = 2 * time.Second  // calls faster than this grow the chunk
+)
+
+// ChunkSizer adapts per-endpoint chunk sizes AIMD-style: successful fast
+// calls grow the size additively, while errors and slow calls halve it.
+// This lets the server self-tune for both beefy and tiny Immich deployments.
+type ChunkSizer struct {
+	mu    sync.Mutex
+	sizes map[string]int
+}
+
+// NewChunkSizer returns an empty sizer; endpoints start at the initial size
+// passed to the first Size call.
+func NewChunkSizer() *ChunkSizer {
+	return &ChunkSizer{sizes: map[string]int{}}
+}
+
+// Size returns the current chunk size for the endpoint, seeding it with
+// initial on first use.
+func (cs *ChunkSizer) Size(endpoint string, initial int) int {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	size, found := cs.sizes[endpoint]
+	if !found {
+		size = clampChunkSize(initial)
+		cs.sizes[endpoint] = size
+	}
+	return size
+}
+
+// Observe records one call's outcome and adjusts the endpoint's chunk size.
+func (cs *ChunkSizer) Observe(endpoint string, latency time.Duration, err error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	size, found := cs.sizes[endpoint]
+	if !found {
+		return
+	}
+
+	switch {
+	case err != nil || latency > chunkSlowLatency:
+		// Multiplicative decrease on errors or congestion
+		size /= 2
+	case latency < chunkFastLatency:
+		// Additive increase while the server keeps up
+		size += chunkSizeStep
+	}
+
+	cs.sizes[endpoint] = clampChunkSize(size)
+}
+
+func clampChunkSize(size int) int {
+	if size < chunkSizeMin {
+		return chunkSizeMin
+	}
+	if size > chunkSizeMax {
+		return chunkSizeMax
+	}
+	return size
+}
+
+// defaultChunkSizer tunes the shared engine's batch and page sizes across
+// calls for the lifetime of the process.
+var defaultChunkSizer = NewChunkSizer()
+
+// Endpoint keys tracked by the default sizer.
+const (
+	chunkEndpointAddAssets = "albums/assets"
+	chunkEndpointGetAssets = "assets"
+)
@@ -0,0 +1,259 @@
+// Package bulkops provides the shared engine behind the "find matching
+// assets, find or create an album, add the assets, report" pattern that
+// several MCP tools implement. Tools supply a Matcher and a Target; the
+// engine handles dry runs, batching, partial failures, optional source
+// cleanup and cache invalidation.
+package bulkops
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/journal"
+	"github.com/yourusername/mcp-immich/pkg/oplog"
+	"github.com/yourusername/mcp-immich/pkg/undo"
+)
+
+// defaultBatchSize is how many assets are added to an album per API call
+// when the caller doesn't specify a batch size.
+const defaultBatchSize = 500
+
+// Matcher produces the set of assets an operation should act on.
+type Matcher func(ctx context.Context) ([]immich.Asset, error)
+
+// Target describes the destination album for a move.
+type Target struct {
+	AlbumName        string
+	AlbumDescription string // used when the album has to be created
+	CreateAlbum      bool
+}
+
+// Options tune how the move runs.
+type Options struct {
+	DryRun            bool
+	BatchSize         int    // assets per AddAssetsToAlbum call (default 500)
+	RemoveFromAlbumID string // prune successfully moved assets from this album
+	InvalidateCache   func() // called once after assets were actually moved
+
+	// Guard, when set, can veto the move after the target album is
+	// resolved (e.g. the album-lock registry); a veto aborts before any
+	// assets are touched
+	Guard func(albumID, albumName string) error
+
+	// Journal, when set together with RemoveFromAlbumID, records the
+	// add-then-remove sequence as intent -> applied -> confirmed so an
+	// interruption between the two steps can be recovered on next start
+	Journal   *journal.Journal
+	Operation string // originating tool name, recorded in the journal and log
+
+	// Log receives machine-readable start/progress/end records for the run;
+	// a nil Writer discards them, and logging never fails the move
+	Log *oplog.Writer
+
+	// Undo, when set, records the completed album mutations so they can be
+	// rolled back later; recording is best-effort and never fails the move
+	Undo *undo.Journal
+}
+
+// Result reports what the engine matched and did. On a dry run only
+// Matched is populated.
+type Result struct {
+	Matched           []immich.Asset
+	AlbumID           string
+	AlbumCreated      bool
+	Moved             int
+	Failed            int
+	RemovedFromSource int
+	RemoveError       string
+	Errors            []string
+}
+
+// MoveMatchingAssets runs the matcher and moves everything it returns into
+// the target album. Batch-level failures are collected in Result.Errors
+// rather than aborting the whole operation.
+func MoveMatchingAssets(ctx context.Context, client *immich.Client, matcher Matcher, target Target, opts Options) (*Result, error) {
+	matched, err := matcher(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Matched: matched}
+
+	opts.Log.Start(map[string]interface{}{
+		"targetAlbum": target.AlbumName,
+		"matched":     len(matched),
+		"dryRun":      opts.DryRun,
+	})
+
+	if opts.DryRun || len(matched) == 0 {
+		opts.Log.End(map[string]interface{}{"matched": len(matched), "dryRun": opts.DryRun})
+		return result, nil
+	}
+
+	// Find or create the target album
+	albums, err := client.ListAlbums(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list albums: %w", err)
+	}
+	for _, album := range albums {
+		if album.AlbumName == target.AlbumName {
+			result.AlbumID = album.ID
+			break
+		}
+	}
+	if result.AlbumID == "" {
+		if !target.CreateAlbum {
+			return nil, fmt.Errorf("album '%s' not found and createAlbum is false", target.AlbumName)
+		}
+		newAlbum, err := client.CreateAlbum(ctx, immich.CreateAlbumParams{
+			Name:        target.AlbumName,
+			Description: target.AlbumDescription,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create album: %w", err)
+		}
+		result.AlbumID = newAlbum.ID
+		result.AlbumCreated = true
+	}
+
+	if opts.Guard != nil {
+		if err := opts.Guard(result.AlbumID, target.AlbumName); err != nil {
+			return nil, err
+		}
+		if opts.RemoveFromAlbumID != "" {
+			if err := opts.Guard(opts.RemoveFromAlbumID, ""); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	assetIDs := make([]string, len(matched))
+	for i, asset := range matched {
+		assetIDs[i] = asset.ID
+	}
+
+	// Journal the intent before touching anything; only two-step moves
+	// (add then remove) need crash recovery
+	var journalEntry *journal.Entry
+	if opts.Journal != nil && opts.RemoveFromAlbumID != "" {
+		journalEntry, err = opts.Journal.Begin(opts.Operation, assetIDs, result.AlbumID, opts.RemoveFromAlbumID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to journal operation: %w", err)
+		}
+	}
+
+	// Add assets in batches, treating batch failures as partial failures.
+	// Without an explicit batch size the adaptive sizer picks one per chunk.
+	var movedIDs []string
+	for start := 0; start < len(assetIDs); {
+		// Check for context cancellation
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+		default:
+		}
+
+		batchSize := opts.BatchSize
+		if batchSize <= 0 {
+			batchSize = defaultChunkSizer.Size(chunkEndpointAddAssets, defaultBatchSize)
+		}
+
+		end := start + batchSize
+		if end > len(assetIDs) {
+			end = len(assetIDs)
+		}
+		batch := assetIDs[start:end]
+
+		began := time.Now()
+		bulkResult, err := client.AddAssetsToAlbum(ctx, result.AlbumID, batch)
+		if opts.BatchSize <= 0 {
+			defaultChunkSizer.Observe(chunkEndpointAddAssets, time.Since(began), err)
+		}
+		if err != nil {
+			result.Failed += len(batch)
+			if len(result.Errors) < 10 {
+				result.Errors = append(result.Errors, fmt.Sprintf("batch %d-%d: %v", start, end, err))
+			}
+			opts.Log.Progress(map[string]interface{}{
+				"batch": fmt.Sprintf("%d-%d", start, end),
+				"error": err.Error(),
+			})
+			start = end
+			continue
+		}
+		result.Moved += len(bulkResult.Success)
+		result.Failed += len(bulkResult.Error)
+		movedIDs = append(movedIDs, bulkResult.Success...)
+		opts.Log.Progress(map[string]interface{}{
+			"batch":  fmt.Sprintf("%d-%d", start, end),
+			"moved":  result.Moved,
+			"failed": result.Failed,
+		})
+		start = end
+	}
+
+	// The additive step is done: narrow the journal entry to the assets
+	// actually added, so recovery only ever removes those
+	if journalEntry != nil {
+		if len(movedIDs) == 0 {
+			_ = opts.Journal.Confirm(journalEntry.ID)
+			journalEntry = nil
+		} else if err := opts.Journal.MarkApplied(journalEntry.ID, movedIDs); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("journal update failed: %v", err))
+		}
+	}
+
+	// Record the completed add for later rollback
+	if opts.Undo != nil && len(movedIDs) > 0 {
+		_, _ = opts.Undo.Record(undo.Operation{
+			Tool:     opts.Operation,
+			Kind:     undo.KindAlbumAdd,
+			AlbumID:  result.AlbumID,
+			AssetIDs: movedIDs,
+		})
+	}
+
+	// Prune the source album if requested
+	if opts.RemoveFromAlbumID != "" && len(movedIDs) > 0 {
+		removeResult, err := client.RemoveAssetsFromAlbum(ctx, opts.RemoveFromAlbumID, movedIDs)
+		if err != nil {
+			result.RemoveError = fmt.Sprintf("failed to remove from source: %v", err)
+		} else {
+			result.RemovedFromSource = len(removeResult.Success)
+			// Both steps completed; the journal entry has served its purpose
+			if journalEntry != nil {
+				_ = opts.Journal.Confirm(journalEntry.ID)
+			}
+			if opts.Undo != nil && len(removeResult.Success) > 0 {
+				_, _ = opts.Undo.Record(undo.Operation{
+					Tool:     opts.Operation,
+					Kind:     undo.KindAlbumRemove,
+					AlbumID:  opts.RemoveFromAlbumID,
+					AssetIDs: removeResult.Success,
+				})
+			}
+		}
+	}
+
+	if result.Moved > 0 && opts.InvalidateCache != nil {
+		opts.InvalidateCache()
+	}
+
+	summary := map[string]interface{}{
+		"albumId":      result.AlbumID,
+		"albumCreated": result.AlbumCreated,
+		"moved":        result.Moved,
+		"failed":       result.Failed,
+	}
+	if result.RemovedFromSource > 0 {
+		summary["removedFromSource"] = result.RemovedFromSource
+	}
+	if result.RemoveError != "" {
+		summary["removeError"] = result.RemoveError
+	}
+	opts.Log.End(summary)
+
+	return result, nil
+}
@@ -0,0 +1,43 @@
+package bulkops
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/journal"
+)
+
+// RecoverJournal resolves operations that were interrupted between steps.
+// Entries still at intent never modified anything and are rolled back by
+// dropping them; entries at applied already added their assets to the
+// target album, so the outstanding removal from the source is completed.
+// Returns how many operations were completed and rolled back, plus any
+// errors encountered.
+func RecoverJournal(ctx context.Context, client *immich.Client, j *journal.Journal) (completed, rolledBack int, errs []string) {
+	for _, entry := range j.Pending() {
+		switch entry.State {
+		case journal.StateIntent:
+			// Nothing was applied; forgetting the intent is the rollback
+			if err := j.Confirm(entry.ID); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", entry.ID, err))
+				continue
+			}
+			rolledBack++
+
+		case journal.StateApplied:
+			if entry.SourceAlbumID != "" && len(entry.AssetIDs) > 0 {
+				if _, err := client.RemoveAssetsFromAlbum(ctx, entry.SourceAlbumID, entry.AssetIDs); err != nil {
+					errs = append(errs, fmt.Sprintf("%s: failed to complete removal: %v", entry.ID, err))
+					continue
+				}
+			}
+			if err := j.Confirm(entry.ID); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", entry.ID, err))
+				continue
+			}
+			completed++
+		}
+	}
+	return completed, rolledBack, errs
+}
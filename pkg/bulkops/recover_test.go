@@ -0,0 +1,119 @@
+package bulkops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/journal"
+)
+
+func TestRecoverJournal(t *testing.T) {
+	var removals []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			IDs []string `json:"ids"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode removal body: %v", err)
+		}
+		removals = append(removals, r.URL.Path)
+		removals = append(removals, body.IDs...)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	client := immich.NewClient(server.URL, "test-key", time.Second)
+
+	path := filepath.Join(t.TempDir(), "operations.json")
+	j, err := journal.New(path)
+	if err != nil {
+		t.Fatalf("journal.New failed: %v", err)
+	}
+
+	// Interrupted before any step ran: rollback is dropping the intent
+	if _, err := j.Begin("movePhotosBySearch", []string{"x"}, "target-album", "src-album"); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+
+	// Interrupted after the additive step: recovery completes the removal
+	applied, err := j.Begin("movePhotosBySearch", []string{"a", "b", "c"}, "target-album", "src-album")
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if err := j.MarkApplied(applied.ID, []string{"a", "b"}); err != nil {
+		t.Fatalf("MarkApplied failed: %v", err)
+	}
+
+	// Applied with no source album: nothing left to remove
+	noSource, err := j.Begin("moveAssetsToAlbum", []string{"d"}, "target-album", "")
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if err := j.MarkApplied(noSource.ID, []string{"d"}); err != nil {
+		t.Fatalf("MarkApplied failed: %v", err)
+	}
+
+	completed, rolledBack, errs := RecoverJournal(context.Background(), client, j)
+	if len(errs) > 0 {
+		t.Fatalf("RecoverJournal reported errors: %v", errs)
+	}
+	if completed != 2 || rolledBack != 1 {
+		t.Errorf("RecoverJournal = %d completed, %d rolled back; want 2, 1", completed, rolledBack)
+	}
+	if pending := j.Pending(); len(pending) != 0 {
+		t.Errorf("journal still holds %d entries after recovery", len(pending))
+	}
+
+	// Only the assets MarkApplied narrowed to are removed, from the source
+	want := []string{"/api/albums/src-album/assets", "a", "b"}
+	if len(removals) != len(want) {
+		t.Fatalf("removal calls = %v, want %v", removals, want)
+	}
+	for i := range want {
+		if removals[i] != want[i] {
+			t.Errorf("removal calls = %v, want %v", removals, want)
+			break
+		}
+	}
+}
+
+func TestRecoverJournalKeepsEntryOnFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	client := immich.NewClient(server.URL, "test-key", time.Second)
+
+	path := filepath.Join(t.TempDir(), "operations.json")
+	j, err := journal.New(path)
+	if err != nil {
+		t.Fatalf("journal.New failed: %v", err)
+	}
+
+	applied, err := j.Begin("movePhotosBySearch", []string{"a"}, "target-album", "src-album")
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if err := j.MarkApplied(applied.ID, []string{"a"}); err != nil {
+		t.Fatalf("MarkApplied failed: %v", err)
+	}
+
+	completed, rolledBack, errs := RecoverJournal(context.Background(), client, j)
+	if completed != 0 || rolledBack != 0 || len(errs) != 1 {
+		t.Errorf("RecoverJournal = %d completed, %d rolled back, errs %v; want 0, 0, one error", completed, rolledBack, errs)
+	}
+
+	// The entry must survive so the next start can retry the removal
+	pending := j.Pending()
+	if len(pending) != 1 || pending[0].ID != applied.ID {
+		t.Errorf("pending entries after failed recovery = %+v, want the applied entry", pending)
+	}
+}
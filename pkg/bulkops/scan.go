@@ -0,0 +1,83 @@
+package bulkops
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// ScanOptions control a paged scan over the whole library.
+type ScanOptions struct {
+	StartPage int // first page to fetch (default 1)
+	PageSize  int // assets per page (default 1000)
+	Max       int // stop after this many matches (0 for unlimited)
+
+	// Filters are pushed down to the server so pages only contain assets
+	// that can possibly match; see immich.MetadataSearchFilters for which
+	// predicates must remain client-side
+	Filters immich.MetadataSearchFilters
+}
+
+// ScanResult carries the matches plus the pagination stats tools report.
+type ScanResult struct {
+	Assets         []immich.Asset
+	TotalProcessed int
+	LastPage       int
+}
+
+// ScanAssets pages through every asset and collects those the predicate
+// accepts, stopping once opts.Max matches are found.
+func ScanAssets(ctx context.Context, client *immich.Client, opts ScanOptions, match func(immich.Asset) bool) (*ScanResult, error) {
+	page := opts.StartPage
+	if page <= 0 {
+		page = 1
+	}
+	// Page size must stay constant within one scan so page offsets line up;
+	// the adaptive sizer tunes the size used by the next scan instead
+	adaptive := opts.PageSize <= 0
+	pageSize := opts.PageSize
+	if adaptive {
+		pageSize = defaultChunkSizer.Size(chunkEndpointGetAssets, 1000)
+	}
+
+	result := &ScanResult{}
+
+	for opts.Max == 0 || len(result.Assets) < opts.Max {
+		// Check for context cancellation
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+		default:
+		}
+
+		began := time.Now()
+		assetPage, err := client.GetAssetsFiltered(ctx, page, pageSize, opts.Filters)
+		if adaptive {
+			defaultChunkSizer.Observe(chunkEndpointGetAssets, time.Since(began), err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get assets page %d: %w", page, err)
+		}
+
+		result.TotalProcessed += len(assetPage.Assets)
+
+		for _, asset := range assetPage.Assets {
+			if match(asset) {
+				result.Assets = append(result.Assets, asset)
+				if opts.Max > 0 && len(result.Assets) >= opts.Max {
+					break
+				}
+			}
+		}
+
+		if !assetPage.HasNextPage {
+			break
+		}
+		page++
+	}
+
+	result.LastPage = page
+	return result, nil
+}
@@ -0,0 +1,140 @@
+package classify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Label is a single classification result for an asset
+type Label struct {
+	Name       string  `json:"label"`
+	Confidence float64 `json:"confidence"`
+}
+
+// Result holds the cached classification for one asset
+type Result struct {
+	AssetID      string    `json:"assetId"`
+	Labels       []Label   `json:"labels"`
+	ClassifiedAt time.Time `json:"classifiedAt"`
+}
+
+// Classifier calls an external HTTP classification service with asset
+// thumbnails and caches the returned labels locally.
+type Classifier struct {
+	url        string
+	apiKey     string
+	httpClient *http.Client
+
+	mu      sync.RWMutex
+	results map[string]Result // assetID -> result
+}
+
+// New creates a classifier for the given external service endpoint
+func New(url, apiKey string, timeout time.Duration) *Classifier {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &Classifier{
+		url:        url,
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: timeout},
+		results:    make(map[string]Result),
+	}
+}
+
+// Classify sends a thumbnail to the external classifier and caches the labels.
+// Cached results are returned without re-calling the service.
+func (c *Classifier) Classify(ctx context.Context, assetID string, thumbnail []byte) (Result, error) {
+	c.mu.RLock()
+	cached, found := c.results[assetID]
+	c.mu.RUnlock()
+	if found {
+		return cached, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(thumbnail))
+	if err != nil {
+		return Result{}, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return Result{}, fmt.Errorf("classifier request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return Result{}, fmt.Errorf("classifier error: status=%d body=%s", resp.StatusCode, string(bodyBytes))
+	}
+
+	// Accept either a bare label array or an object with a labels field
+	var labels []Label
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to read classifier response: %w", err)
+	}
+	if err := json.Unmarshal(bodyBytes, &labels); err != nil {
+		var wrapped struct {
+			Labels []Label `json:"labels"`
+		}
+		if err := json.Unmarshal(bodyBytes, &wrapped); err != nil {
+			return Result{}, fmt.Errorf("failed to decode classifier response: %w", err)
+		}
+		labels = wrapped.Labels
+	}
+
+	result := Result{
+		AssetID:      assetID,
+		Labels:       labels,
+		ClassifiedAt: time.Now(),
+	}
+
+	c.mu.Lock()
+	c.results[assetID] = result
+	c.mu.Unlock()
+
+	return result, nil
+}
+
+// Get returns the cached classification for an asset, if any
+func (c *Classifier) Get(assetID string) (Result, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	result, found := c.results[assetID]
+	return result, found
+}
+
+// Search returns cached results containing the given label at or above minConfidence
+func (c *Classifier) Search(label string, minConfidence float64) []Result {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var matches []Result
+	for _, result := range c.results {
+		for _, l := range result.Labels {
+			if l.Name == label && l.Confidence >= minConfidence {
+				matches = append(matches, result)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// Size returns the number of cached classification results
+func (c *Classifier) Size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.results)
+}
@@ -10,8 +10,10 @@ import (
 // Config holds all application configuration
 type Config struct {
 	// Server settings
-	ListenAddr    string `mapstructure:"listen_addr"`
-	TransportMode string `mapstructure:"transport_mode"` // "http" or "stdio"
+	ListenAddr       string `mapstructure:"listen_addr"`
+	ListenSocket     string `mapstructure:"listen_socket"`      // Unix socket path; replaces the TCP listener when set
+	ListenSocketMode string `mapstructure:"listen_socket_mode"` // octal file mode for the socket (default 0660)
+	TransportMode    string `mapstructure:"transport_mode"`     // "http" or "stdio"
 
 	// Immich connection
 	ImmichURL    string `mapstructure:"immich_url"`
@@ -34,6 +36,105 @@ type Config struct {
 	RequestTimeout time.Duration `mapstructure:"request_timeout"`
 	ImmichTimeout  time.Duration `mapstructure:"immich_timeout"`
 
+	// Transport keepalive: heartbeat pings keep long StreamableHTTP sessions
+	// alive through reverse proxies (0 disables them), and the idle timeout
+	// closes connections with no traffic at all
+	HeartbeatInterval  time.Duration `mapstructure:"heartbeat_interval"`
+	SessionIdleTimeout time.Duration `mapstructure:"session_idle_timeout"`
+
+	// Per-category Immich timeouts (fall back to immich_timeout when unset)
+	ImmichQueryTimeout    time.Duration `mapstructure:"immich_query_timeout"`
+	ImmichMutationTimeout time.Duration `mapstructure:"immich_mutation_timeout"`
+	ImmichScanTimeout     time.Duration `mapstructure:"immich_scan_timeout"`
+	ImmichDownloadTimeout time.Duration `mapstructure:"immich_download_timeout"`
+
+	// Downloads and exports
+	DownloadBandwidthLimit int64  `mapstructure:"download_bandwidth_limit"` // bytes/sec, 0 for unlimited
+	ExportWindowStart      string `mapstructure:"export_window_start"`      // "HH:MM", empty for no window
+	ExportWindowEnd        string `mapstructure:"export_window_end"`
+	ExportEmbedMetadata    bool   `mapstructure:"export_embed_metadata"` // write XMP sidecars with exported originals
+	ExportDir              string `mapstructure:"export_dir"`            // where archive exports are written
+
+	// Quiet hours during which background automation never runs
+	QuietHoursStart string `mapstructure:"quiet_hours_start"` // "HH:MM", empty for none
+	QuietHoursEnd   string `mapstructure:"quiet_hours_end"`
+
+	// Smart albums; the refresh interval is the scheduler's global default
+	// between background refreshes (0 disables scheduled refreshes unless a
+	// definition sets its own interval)
+	SmartAlbumStorePath       string        `mapstructure:"smart_album_store_path"`
+	SmartAlbumRefreshInterval time.Duration `mapstructure:"smart_album_refresh_interval"`
+
+	// Smart album store backend: "json" (default) rewrites the store file on
+	// each save; "sqlite" keeps it in a database next to the store path (the
+	// .json extension swapped for .db) and migrates an existing JSON store in
+	// on first start
+	SmartAlbumStoreBackend string `mapstructure:"smart_album_store"`
+
+	// Saved searches
+	SavedSearchStorePath string `mapstructure:"saved_search_store_path"`
+
+	// Named parameter presets applied as tool call defaults; clients select
+	// one via the X-MCP-Profile header, or DefaultProfile applies
+	ParameterProfiles map[string]map[string]interface{} `mapstructure:"parameter_profiles"`
+	DefaultProfile    string                            `mapstructure:"default_profile"`
+
+	// Journal for multi-step operations, recovered on startup
+	OperationJournalPath string `mapstructure:"operation_journal_path"`
+
+	// Recycle snapshots taken before destructive operations
+	RecycleStorePath     string `mapstructure:"recycle_store_path"`
+	RecycleRetentionDays int    `mapstructure:"recycle_retention_days"`
+
+	// Album lock registry protecting curated albums from mutation
+	AlbumLockStorePath string `mapstructure:"album_lock_store_path"`
+
+	// Tool call history (disabled when the path is empty)
+	ToolHistoryPath string `mapstructure:"tool_history_path"`
+	ToolHistorySize int    `mapstructure:"tool_history_size"`
+
+	// Structured operation logs for bulk runs (disabled when the directory
+	// is empty); retention is the number of log files kept
+	OperationLogDir       string `mapstructure:"operation_log_dir"`
+	OperationLogRetention int    `mapstructure:"operation_log_retention"`
+
+	// Background job state for async bulk runs
+	JobStatePath string `mapstructure:"job_state_path"`
+
+	// Audit log of mutating tool calls (disabled when the path is empty)
+	AuditLogPath string `mapstructure:"audit_log_path"`
+
+	// Undo journal of reversible mutations
+	UndoJournalPath string `mapstructure:"undo_journal_path"`
+
+	// Home Assistant bridge (optional)
+	HomeAssistant *HomeAssistantConfig `mapstructure:"home_assistant"`
+
+	// External classifier (optional)
+	ClassifierURL     string        `mapstructure:"classifier_url"`
+	ClassifierAPIKey  string        `mapstructure:"classifier_api_key"`
+	ClassifierTimeout time.Duration `mapstructure:"classifier_timeout"`
+
+	// Write mode: "live" sends mutations to Immich, "record-only" captures
+	// them in a local journal with synthetic success for risk-free rehearsals
+	WriteMode string `mapstructure:"write_mode"`
+
+	// Read-only mode rejects destructive tools entirely, for exposing the
+	// server to untrusted agents
+	ReadOnly bool `mapstructure:"read_only"`
+
+	// Forced dry-run mode rewrites every destructive call into its dry-run
+	// form so an agent's plan can be audited before real changes are allowed
+	ForceDryRun bool `mapstructure:"force_dry_run"`
+
+	// Tool results
+	ResultVerbosity string `mapstructure:"result_verbosity"` // "minimal", "standard" or "full"
+	SampleFormat    string `mapstructure:"sample_format"`    // "json" or "cards"
+
+	// Strict tool registration: planned-but-unimplemented tools are hidden
+	// when true, or advertised with a not-implemented error when false
+	StrictTools bool `mapstructure:"strict_tools"`
+
 	// Logging
 	LogLevel string `mapstructure:"log_level"`
 	LogJSON  bool   `mapstructure:"log_json"`
@@ -43,6 +144,17 @@ type Config struct {
 	MetricsPort   string `mapstructure:"metrics_port"`
 }
 
+// HomeAssistantConfig holds the Home Assistant bridge settings. Automation
+// events go to the webhook (base_url + webhook_id); library stats are
+// pushed as sensor states via the HA REST API, which needs a long-lived
+// access token.
+type HomeAssistantConfig struct {
+	BaseURL       string        `mapstructure:"base_url"`
+	Token         string        `mapstructure:"token"`
+	WebhookID     string        `mapstructure:"webhook_id"`
+	StatsInterval time.Duration `mapstructure:"stats_interval"`
+}
+
 // OAuthConfig holds OAuth configuration
 type OAuthConfig struct {
 	ClientID     string   `mapstructure:"client_id"`
@@ -94,6 +206,8 @@ func Load(configFile string) (*Config, error) {
 func setDefaults(v *viper.Viper) {
 	// Server defaults
 	v.SetDefault("listen_addr", ":8080")
+	v.SetDefault("listen_socket", "")
+	v.SetDefault("listen_socket_mode", "0660")
 	v.SetDefault("transport_mode", "http")
 
 	// Auth defaults
@@ -111,6 +225,68 @@ func setDefaults(v *viper.Viper) {
 	// Timeout defaults
 	v.SetDefault("request_timeout", 30*time.Second)
 	v.SetDefault("immich_timeout", 30*time.Second)
+	v.SetDefault("immich_scan_timeout", 2*time.Minute)
+	v.SetDefault("immich_download_timeout", 5*time.Minute)
+	v.SetDefault("heartbeat_interval", 30*time.Second)
+	v.SetDefault("session_idle_timeout", 5*time.Minute)
+
+	// Download and export defaults
+	v.SetDefault("download_bandwidth_limit", 0)
+	v.SetDefault("export_window_start", "")
+	v.SetDefault("export_window_end", "")
+	v.SetDefault("export_embed_metadata", false)
+	v.SetDefault("export_dir", "exports")
+
+	// Smart album defaults
+	v.SetDefault("smart_album_store_path", "smart_albums.json")
+	v.SetDefault("smart_album_store", "json")
+	v.SetDefault("smart_album_refresh_interval", time.Duration(0))
+
+	// Saved search defaults
+	v.SetDefault("saved_search_store_path", "saved_searches.json")
+
+	// Parameter profile defaults (none configured)
+	v.SetDefault("default_profile", "")
+
+	// Operation journal defaults
+	v.SetDefault("operation_journal_path", "operation_journal.json")
+
+	// Recycle snapshot defaults
+	v.SetDefault("recycle_store_path", "recycle_snapshots.json")
+	v.SetDefault("recycle_retention_days", 30)
+	v.SetDefault("album_lock_store_path", "album_locks.json")
+
+	// Tool history defaults (opt-in)
+	v.SetDefault("tool_history_path", "")
+	v.SetDefault("tool_history_size", 200)
+
+	// Operation log defaults (opt-in)
+	v.SetDefault("operation_log_dir", "")
+	v.SetDefault("operation_log_retention", 50)
+
+	// Background job defaults
+	v.SetDefault("job_state_path", "jobs.json")
+
+	// Audit log defaults (opt-in)
+	v.SetDefault("audit_log_path", "")
+
+	// Undo journal defaults
+	v.SetDefault("undo_journal_path", "undo_journal.json")
+
+	// Classifier defaults
+	v.SetDefault("classifier_url", "")
+	v.SetDefault("classifier_api_key", "")
+	v.SetDefault("classifier_timeout", 30*time.Second)
+
+	// Write mode default
+	v.SetDefault("write_mode", "live")
+	v.SetDefault("read_only", false)
+	v.SetDefault("force_dry_run", false)
+
+	// Tool result defaults
+	v.SetDefault("result_verbosity", "standard")
+	v.SetDefault("sample_format", "json")
+	v.SetDefault("strict_tools", true)
 
 	// Logging defaults
 	v.SetDefault("log_level", "info")
@@ -171,6 +347,13 @@ func applyDerivedDefaults(cfg *Config, v *viper.Viper) {
 		}
 	}
 
+	if cfg.SessionIdleTimeout <= 0 {
+		cfg.SessionIdleTimeout = v.GetDuration("session_idle_timeout")
+		if cfg.SessionIdleTimeout <= 0 {
+			cfg.SessionIdleTimeout = 5 * time.Minute
+		}
+	}
+
 	if cfg.ImmichTimeout <= 0 {
 		cfg.ImmichTimeout = v.GetDuration("immich_timeout")
 		if cfg.ImmichTimeout <= 0 {
@@ -178,6 +361,51 @@ func applyDerivedDefaults(cfg *Config, v *viper.Viper) {
 		}
 	}
 
+	// Quick calls default to immich_timeout; long operations get wider bounds
+	if cfg.ImmichQueryTimeout <= 0 {
+		cfg.ImmichQueryTimeout = cfg.ImmichTimeout
+	}
+	if cfg.ImmichMutationTimeout <= 0 {
+		cfg.ImmichMutationTimeout = cfg.ImmichTimeout
+	}
+	if cfg.ImmichScanTimeout <= 0 {
+		cfg.ImmichScanTimeout = v.GetDuration("immich_scan_timeout")
+		if cfg.ImmichScanTimeout <= 0 {
+			cfg.ImmichScanTimeout = 2 * time.Minute
+		}
+	}
+	if cfg.ImmichDownloadTimeout <= 0 {
+		cfg.ImmichDownloadTimeout = v.GetDuration("immich_download_timeout")
+		if cfg.ImmichDownloadTimeout <= 0 {
+			cfg.ImmichDownloadTimeout = 5 * time.Minute
+		}
+	}
+
+	if cfg.RecycleRetentionDays <= 0 {
+		cfg.RecycleRetentionDays = v.GetInt("recycle_retention_days")
+		if cfg.RecycleRetentionDays <= 0 {
+			cfg.RecycleRetentionDays = 30
+		}
+	}
+
+	if cfg.ToolHistorySize <= 0 {
+		cfg.ToolHistorySize = v.GetInt("tool_history_size")
+		if cfg.ToolHistorySize <= 0 {
+			cfg.ToolHistorySize = 200
+		}
+	}
+
+	if cfg.OperationLogRetention <= 0 {
+		cfg.OperationLogRetention = v.GetInt("operation_log_retention")
+		if cfg.OperationLogRetention <= 0 {
+			cfg.OperationLogRetention = 50
+		}
+	}
+
+	if cfg.HomeAssistant != nil && cfg.HomeAssistant.StatsInterval <= 0 {
+		cfg.HomeAssistant.StatsInterval = 15 * time.Minute
+	}
+
 	if cfg.MetricsPort == "" {
 		cfg.MetricsPort = v.GetString("metrics_port")
 		if cfg.MetricsPort == "" {
@@ -185,6 +413,27 @@ func applyDerivedDefaults(cfg *Config, v *viper.Viper) {
 		}
 	}
 
+	if cfg.WriteMode == "" {
+		cfg.WriteMode = v.GetString("write_mode")
+		if cfg.WriteMode == "" {
+			cfg.WriteMode = "live"
+		}
+	}
+
+	if cfg.ResultVerbosity == "" {
+		cfg.ResultVerbosity = v.GetString("result_verbosity")
+		if cfg.ResultVerbosity == "" {
+			cfg.ResultVerbosity = "standard"
+		}
+	}
+
+	if cfg.SampleFormat == "" {
+		cfg.SampleFormat = v.GetString("sample_format")
+		if cfg.SampleFormat == "" {
+			cfg.SampleFormat = "json"
+		}
+	}
+
 	// Ensure auth mode is set even if empty string was provided
 	if cfg.AuthMode == "" {
 		cfg.AuthMode = v.GetString("auth_mode")
@@ -221,6 +470,58 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid transport_mode: %s", c.TransportMode)
 	}
 
+	if (c.ExportWindowStart == "") != (c.ExportWindowEnd == "") {
+		return fmt.Errorf("export_window_start and export_window_end must be set together")
+	}
+
+	if (c.QuietHoursStart == "") != (c.QuietHoursEnd == "") {
+		return fmt.Errorf("quiet_hours_start and quiet_hours_end must be set together")
+	}
+
+	if c.DefaultProfile != "" {
+		if _, found := c.ParameterProfiles[c.DefaultProfile]; !found {
+			return fmt.Errorf("default_profile '%s' is not defined in parameter_profiles", c.DefaultProfile)
+		}
+	}
+
+	if c.SmartAlbumRefreshInterval < 0 {
+		return fmt.Errorf("smart_album_refresh_interval must not be negative")
+	}
+	switch c.SmartAlbumStoreBackend {
+	case "", "json", "sqlite":
+	default:
+		return fmt.Errorf("invalid smart_album_store: %s (must be json or sqlite)", c.SmartAlbumStoreBackend)
+	}
+
+	if c.HeartbeatInterval < 0 {
+		return fmt.Errorf("heartbeat_interval must not be negative")
+	}
+	if c.HeartbeatInterval > 0 && c.SessionIdleTimeout > 0 && c.HeartbeatInterval >= c.SessionIdleTimeout {
+		return fmt.Errorf("heartbeat_interval (%s) must be shorter than session_idle_timeout (%s)", c.HeartbeatInterval, c.SessionIdleTimeout)
+	}
+
+	switch c.WriteMode {
+	case "", "live", "record-only":
+	default:
+		return fmt.Errorf("invalid write_mode: %s (must be live or record-only)", c.WriteMode)
+	}
+
+	switch c.ResultVerbosity {
+	case "", "minimal", "standard", "full":
+	default:
+		return fmt.Errorf("invalid result_verbosity: %s", c.ResultVerbosity)
+	}
+
+	switch c.SampleFormat {
+	case "", "json", "cards":
+	default:
+		return fmt.Errorf("invalid sample_format: %s", c.SampleFormat)
+	}
+
+	if c.HomeAssistant != nil && c.HomeAssistant.BaseURL == "" {
+		return fmt.Errorf("home_assistant.base_url is required when the home_assistant block is set")
+	}
+
 	// If auth mode requires API keys, ensure they exist
 	if (c.AuthMode == "api_key" || c.AuthMode == "both") && len(c.APIKeys) == 0 {
 		return fmt.Errorf("api_keys required when auth_mode is %s", c.AuthMode)
@@ -0,0 +1,75 @@
+// Package events is an in-process publish/subscribe bus for automation
+// events — album refreshes, asset moves, finished tool calls, errors. The
+// SSE /events endpoint streams it to dashboards and home automation; slow
+// subscribers drop events rather than stalling publishers.
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// subscriberBuffer is how many events a subscriber can lag behind before
+// new events are dropped for it
+const subscriberBuffer = 64
+
+// Event is one structured automation event
+type Event struct {
+	Type      string                 `json:"type"`
+	Timestamp time.Time              `json:"timestamp"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+}
+
+// Bus fans events out to subscribers
+type Bus struct {
+	mu          sync.Mutex
+	subscribers map[int]chan Event
+	nextID      int
+}
+
+// NewBus creates an empty event bus
+func NewBus() *Bus {
+	return &Bus{subscribers: make(map[int]chan Event)}
+}
+
+// Publish sends an event to every subscriber, dropping it for subscribers
+// whose buffers are full
+func (b *Bus) Publish(eventType string, data map[string]interface{}) {
+	event := Event{Type: eventType, Timestamp: time.Now(), Data: data}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe registers a new subscriber; the returned cancel function must be
+// called when the subscriber goes away
+func (b *Bus) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = ch
+	b.mu.Unlock()
+
+	cancel := func() {
+		b.mu.Lock()
+		delete(b.subscribers, id)
+		b.mu.Unlock()
+	}
+	return ch, cancel
+}
+
+// SubscriberCount reports how many subscribers are attached, for health
+// reporting
+func (b *Bus) SubscriberCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers)
+}
@@ -0,0 +1,103 @@
+// Package exportmeta renders Immich-side curation metadata — description,
+// recognized people as keywords, and rating — into XMP so it travels with
+// originals exported out of Immich. The export pipeline writes the XMP as a
+// sidecar next to each exported file, which photo tools (Lightroom,
+// digiKam, darktable) pick up without touching the original's bytes.
+package exportmeta
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// Metadata is the curation metadata written back on export
+type Metadata struct {
+	Description string
+	People      []string // embedded as dc:subject keywords
+	Rating      int      // xmp:Rating, 0 when unrated
+}
+
+// FromAsset extracts the writable metadata from an asset record
+func FromAsset(asset *immich.Asset) Metadata {
+	m := Metadata{}
+	if asset.ExifInfo != nil {
+		m.Description = asset.ExifInfo.Description
+		if asset.ExifInfo.Rating > 0 {
+			m.Rating = asset.ExifInfo.Rating
+		}
+	}
+	for _, person := range asset.People {
+		if person.Name == "" || person.IsHidden {
+			continue
+		}
+		m.People = append(m.People, person.Name)
+	}
+	return m
+}
+
+// IsEmpty reports whether there is any metadata worth writing
+func (m Metadata) IsEmpty() bool {
+	return m.Description == "" && len(m.People) == 0 && m.Rating == 0
+}
+
+// XMP renders the metadata as an XMP packet
+func (m Metadata) XMP() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xpacket begin="" id="W5M0MpCehiHzreSzNTczkc9d"?>` + "\n")
+	buf.WriteString(`<x:xmpmeta xmlns:x="adobe:ns:meta/">` + "\n")
+	buf.WriteString(` <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">` + "\n")
+	buf.WriteString(`  <rdf:Description rdf:about=""` + "\n")
+	buf.WriteString(`    xmlns:dc="http://purl.org/dc/elements/1.1/"` + "\n")
+	buf.WriteString(`    xmlns:xmp="http://ns.adobe.com/xap/1.0/"`)
+	if m.Rating > 0 {
+		fmt.Fprintf(&buf, "\n    xmp:Rating=\"%d\"", m.Rating)
+	}
+	buf.WriteString(">\n")
+
+	if m.Description != "" {
+		buf.WriteString("   <dc:description>\n    <rdf:Alt>\n     <rdf:li xml:lang=\"x-default\">")
+		buf.WriteString(escapeXML(m.Description))
+		buf.WriteString("</rdf:li>\n    </rdf:Alt>\n   </dc:description>\n")
+	}
+
+	if len(m.People) > 0 {
+		buf.WriteString("   <dc:subject>\n    <rdf:Bag>\n")
+		for _, person := range m.People {
+			buf.WriteString("     <rdf:li>")
+			buf.WriteString(escapeXML(person))
+			buf.WriteString("</rdf:li>\n")
+		}
+		buf.WriteString("    </rdf:Bag>\n   </dc:subject>\n")
+	}
+
+	buf.WriteString("  </rdf:Description>\n")
+	buf.WriteString(" </rdf:RDF>\n")
+	buf.WriteString("</x:xmpmeta>\n")
+	buf.WriteString(`<?xpacket end="w"?>` + "\n")
+	return buf.Bytes()
+}
+
+// WriteSidecar writes the metadata as an XMP sidecar next to the exported
+// file, returning the sidecar path. Empty metadata writes nothing and
+// returns "".
+func WriteSidecar(exportedPath string, m Metadata) (string, error) {
+	if m.IsEmpty() {
+		return "", nil
+	}
+	sidecar := exportedPath + ".xmp"
+	if err := os.WriteFile(sidecar, m.XMP(), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write XMP sidecar: %w", err)
+	}
+	return sidecar, nil
+}
+
+// escapeXML escapes a string for use in XMP element content
+func escapeXML(value string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(value))
+	return buf.String()
+}
@@ -0,0 +1,103 @@
+// Package history persists a bounded log of recent tool calls and their
+// summarized results, so a new agent session can recover context without
+// re-querying the whole library.
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// defaultMaxEntries bounds the history when no limit is configured.
+const defaultMaxEntries = 200
+
+// Entry records one tool call.
+type Entry struct {
+	Tool       string    `json:"tool"`
+	CalledAt   time.Time `json:"calledAt"`
+	DurationMs int64     `json:"durationMs"`
+	Success    bool      `json:"success"`
+	Summary    string    `json:"summary,omitempty"`
+}
+
+// Store is a JSON-file-backed ring of recent tool calls.
+type Store struct {
+	path    string
+	max     int
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// NewStore opens (or creates) a history store at path, keeping at most max
+// entries (0 for the default).
+func NewStore(path string, max int) (*Store, error) {
+	if max <= 0 {
+		max = defaultMaxEntries
+	}
+	s := &Store{path: path, max: max}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read history store: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &s.entries); err != nil {
+			return nil, fmt.Errorf("failed to parse history store: %w", err)
+		}
+	}
+	if len(s.entries) > s.max {
+		s.entries = s.entries[len(s.entries)-s.max:]
+	}
+
+	return s, nil
+}
+
+// Record appends an entry, evicting the oldest when over the limit.
+func (s *Store) Record(entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = append(s.entries, entry)
+	if len(s.entries) > s.max {
+		s.entries = s.entries[len(s.entries)-s.max:]
+	}
+	return s.save()
+}
+
+// List returns the recorded entries, oldest first.
+func (s *Store) List() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]Entry, len(s.entries))
+	copy(entries, s.entries)
+	return entries
+}
+
+// save writes the history atomically via a temp file rename. Caller must
+// hold the mutex.
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal history: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to replace history file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+// Package homeassistant bridges the server into Home Assistant. Automation
+// events are forwarded to an HA webhook for use in automations, and library
+// stats are pushed as sensor states via the HA REST API. The inverse
+// direction — HA automations triggering tools like refreshSmartAlbum — goes
+// through the server's /ha/refresh-smart-album endpoint.
+package homeassistant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/mcp-immich/pkg/config"
+	"github.com/yourusername/mcp-immich/pkg/events"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// sensorPrefix namespaces the pushed HA entities
+const sensorPrefix = "sensor.mcp_immich_"
+
+// Publisher pushes events and sensor states into a Home Assistant instance
+type Publisher struct {
+	baseURL       string
+	token         string
+	webhookID     string
+	statsInterval time.Duration
+	httpClient    *http.Client
+}
+
+// New creates a publisher from the home_assistant config block
+func New(cfg *config.HomeAssistantConfig) *Publisher {
+	return &Publisher{
+		baseURL:       cfg.BaseURL,
+		token:         cfg.Token,
+		webhookID:     cfg.WebhookID,
+		statsInterval: cfg.StatsInterval,
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// post sends a JSON payload, attaching the bearer token when one is set
+func (p *Publisher) post(ctx context.Context, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("home assistant returned status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+	return nil
+}
+
+// PublishEvent forwards one automation event to the configured HA webhook;
+// a no-op when no webhook is configured
+func (p *Publisher) PublishEvent(ctx context.Context, event events.Event) error {
+	if p.webhookID == "" {
+		return nil
+	}
+	url := fmt.Sprintf("%s/api/webhook/%s", p.baseURL, p.webhookID)
+	return p.post(ctx, url, event)
+}
+
+// PushSensor updates one HA sensor entity's state and attributes; a no-op
+// when no access token is configured
+func (p *Publisher) PushSensor(ctx context.Context, name string, state interface{}, attributes map[string]interface{}) error {
+	if p.token == "" {
+		return nil
+	}
+	url := fmt.Sprintf("%s/api/states/%s%s", p.baseURL, sensorPrefix, name)
+	return p.post(ctx, url, map[string]interface{}{
+		"state":      state,
+		"attributes": attributes,
+	})
+}
+
+// ForwardEvents relays bus events to the HA webhook until the context ends
+func (p *Publisher) ForwardEvents(ctx context.Context, bus *events.Bus) {
+	ch, cancel := bus.Subscribe()
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-ch:
+			if err := p.PublishEvent(ctx, event); err != nil {
+				log.Warn().Err(err).Str("event", event.Type).Msg("Failed to forward event to Home Assistant")
+			}
+		}
+	}
+}
+
+// RunStatsLoop periodically pushes library totals as HA sensors until the
+// context ends; the first push happens immediately
+func (p *Publisher) RunStatsLoop(ctx context.Context, client *immich.Client) {
+	if p.token == "" {
+		return
+	}
+
+	ticker := time.NewTicker(p.statsInterval)
+	defer ticker.Stop()
+
+	for {
+		p.pushStats(ctx, client)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pushStats pushes one round of library sensors
+func (p *Publisher) pushStats(ctx context.Context, client *immich.Client) {
+	stats, err := client.GetServerStatistics(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to fetch Immich statistics for Home Assistant sensors")
+		return
+	}
+
+	sensors := []struct {
+		name       string
+		state      interface{}
+		attributes map[string]interface{}
+	}{
+		{"photos", stats.Photos, map[string]interface{}{"friendly_name": "Immich Photos"}},
+		{"videos", stats.Videos, map[string]interface{}{"friendly_name": "Immich Videos"}},
+		{"usage_bytes", stats.Usage, map[string]interface{}{
+			"friendly_name":       "Immich Storage Usage",
+			"unit_of_measurement": "B",
+		}},
+	}
+	for _, sensor := range sensors {
+		if err := p.PushSensor(ctx, sensor.name, sensor.state, sensor.attributes); err != nil {
+			log.Warn().Err(err).Str("sensor", sensor.name).Msg("Failed to push Home Assistant sensor")
+		}
+	}
+}
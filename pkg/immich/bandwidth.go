@@ -0,0 +1,56 @@
+package immich
+
+import (
+	"context"
+	"io"
+
+	"golang.org/x/time/rate"
+)
+
+// downloadChunkSize is how many bytes are claimed from the bandwidth bucket
+// per read.
+const downloadChunkSize = 32 * 1024
+
+// SetDownloadBandwidthLimit caps download streams to bytesPerSec via a
+// token bucket. A limit of 0 removes the cap.
+func (c *Client) SetDownloadBandwidthLimit(bytesPerSec int64) {
+	if bytesPerSec <= 0 {
+		c.downloadLimiter = nil
+		return
+	}
+	burst := int(bytesPerSec)
+	if burst < downloadChunkSize {
+		burst = downloadChunkSize
+	}
+	c.downloadLimiter = rate.NewLimiter(rate.Limit(bytesPerSec), burst)
+}
+
+// limitDownload wraps a download stream with the bandwidth token bucket
+// when one is configured.
+func (c *Client) limitDownload(ctx context.Context, r io.Reader) io.Reader {
+	if c.downloadLimiter == nil {
+		return r
+	}
+	return &limitedReader{ctx: ctx, r: r, limiter: c.downloadLimiter}
+}
+
+// limitedReader claims tokens for every chunk read from the underlying
+// stream, blocking until the bucket allows it.
+type limitedReader struct {
+	ctx     context.Context
+	r       io.Reader
+	limiter *rate.Limiter
+}
+
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	if len(p) > downloadChunkSize {
+		p = p[:downloadChunkSize]
+	}
+	n, err := lr.r.Read(p)
+	if n > 0 {
+		if waitErr := lr.limiter.WaitN(lr.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
@@ -3,11 +3,14 @@ package immich
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -21,8 +24,42 @@ type Client struct {
 	apiKey      string
 	httpClient  *http.Client
 	rateLimiter *rate.Limiter
+	timeouts    CategoryTimeouts
+
+	// Optional token bucket capping download stream bandwidth
+	downloadLimiter *rate.Limiter
+
+	// When set, mutating requests are captured here instead of being sent
+	// (record-only write mode)
+	writeRecorder *WriteRecorder
+
+	// Cached tag hierarchy for tag-path resolution
+	tagTreeMu sync.Mutex
+	tagTree   *TagTree
+	tagTreeAt time.Time
+
+	// Conditional-request validators for the albums listing; when Immich
+	// sends neither an ETag nor Last-Modified we hash the body ourselves so
+	// unchanged payloads are still detected
+	albumsCondMu       sync.Mutex
+	albumsETag         string
+	albumsLastModified string
+	albumsBodyHash     string
+
+	// Observed throughput, used for bulk operation ETA estimation and
+	// latency reporting; recentDurations is a bounded ring of the latest
+	// request durations for percentile estimates
+	statsMu         sync.Mutex
+	requestCount    int64
+	requestTotals   time.Duration
+	recentDurations []time.Duration
+	recentIndex     int
 }
 
+// recentDurationWindow is how many request durations are retained for
+// latency percentile estimates
+const recentDurationWindow = 128
+
 // NewClient creates a new Immich client
 func NewClient(baseURL, apiKey string, timeout time.Duration) *Client {
 	return &Client{
@@ -65,6 +102,24 @@ func (c *Client) Ping(ctx context.Context) error {
 	return nil
 }
 
+// ServerStats reports library-wide totals from the Immich server
+type ServerStats struct {
+	Photos int   `json:"photos"`
+	Videos int   `json:"videos"`
+	Usage  int64 `json:"usage"`
+}
+
+// GetServerStatistics fetches library-wide asset and storage totals
+func (c *Client) GetServerStatistics(ctx context.Context) (*ServerStats, error) {
+	endpoint := fmt.Sprintf("%s/api/server-info/statistics", c.baseURL)
+
+	var stats ServerStats
+	if err := c.get(ctx, endpoint, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
 // QueryPhotos searches for photos with filters
 func (c *Client) QueryPhotos(ctx context.Context, params QueryPhotosParams) (*PhotoResults, error) {
 	endpoint := fmt.Sprintf("%s/api/search", c.baseURL)
@@ -158,6 +213,105 @@ func (c *Client) GetAssetMetadata(ctx context.Context, assetID string) (*Asset,
 	return &asset, nil
 }
 
+// GetAssetFaces gets the detected face regions on an asset, including the
+// person each face is assigned to (nil for unassigned faces)
+func (c *Client) GetAssetFaces(ctx context.Context, assetID string) ([]AssetFace, error) {
+	endpoint := fmt.Sprintf("%s/api/faces?id=%s", c.baseURL, url.QueryEscape(assetID))
+
+	var faces []AssetFace
+	if err := c.get(ctx, endpoint, &faces); err != nil {
+		return nil, fmt.Errorf("failed to get faces for asset %s: %w", assetID, err)
+	}
+
+	return faces, nil
+}
+
+// ListPeople gets one page of detected people
+func (c *Client) ListPeople(ctx context.Context, page, size int, withHidden bool) (*PeoplePage, error) {
+	endpoint := fmt.Sprintf("%s/api/people?page=%d&size=%d&withHidden=%t", c.baseURL, page, size, withHidden)
+
+	var result PeoplePage
+	if err := c.get(ctx, endpoint, &result); err != nil {
+		return nil, fmt.Errorf("failed to list people: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetPerson gets a single person by ID
+func (c *Client) GetPerson(ctx context.Context, personID string) (*Person, error) {
+	endpoint := fmt.Sprintf("%s/api/people/%s", c.baseURL, personID)
+
+	var person Person
+	if err := c.get(ctx, endpoint, &person); err != nil {
+		return nil, fmt.Errorf("failed to get person %s: %w", personID, err)
+	}
+
+	return &person, nil
+}
+
+// UpdatePerson updates a person's name, birth date, or hidden status
+func (c *Client) UpdatePerson(ctx context.Context, personID string, updates map[string]interface{}) (*Person, error) {
+	endpoint := fmt.Sprintf("%s/api/people/%s", c.baseURL, personID)
+
+	var person Person
+	if err := c.put(ctx, endpoint, updates, &person); err != nil {
+		return nil, fmt.Errorf("failed to update person %s: %w", personID, err)
+	}
+
+	return &person, nil
+}
+
+// MergePeople merges the source person clusters into the target person; the
+// sources' faces are reassigned and the source people removed
+func (c *Client) MergePeople(ctx context.Context, targetID string, sourceIDs []string) ([]PersonMergeItem, error) {
+	endpoint := fmt.Sprintf("%s/api/people/%s/merge", c.baseURL, targetID)
+
+	var items []PersonMergeItem
+	if err := c.post(ctx, endpoint, map[string]interface{}{"ids": sourceIDs}, &items); err != nil {
+		return nil, fmt.Errorf("failed to merge people into %s: %w", targetID, err)
+	}
+
+	return items, nil
+}
+
+// GetAssetThumbnail downloads the thumbnail image for an asset
+func (c *Client) GetAssetThumbnail(ctx context.Context, assetID string) ([]byte, error) {
+	endpoint := fmt.Sprintf("%s/api/assets/%s/thumbnail", c.baseURL, assetID)
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	// Binary fetches run under the download timeout
+	if timeout := c.timeouts.Download; timeout > 0 {
+		if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-api-key", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("thumbnail request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{Status: resp.StatusCode, Body: string(bodyBytes)}
+	}
+
+	return io.ReadAll(c.limitDownload(ctx, resp.Body))
+}
+
 // ListAlbums lists all albums
 func (c *Client) ListAlbums(ctx context.Context, shared bool) ([]Album, error) {
 	endpoint := fmt.Sprintf("%s/api/albums", c.baseURL)
@@ -174,6 +328,84 @@ func (c *Client) ListAlbums(ctx context.Context, shared bool) ([]Album, error) {
 	return albums, nil
 }
 
+// GetAlbumsForAsset lists the albums containing a specific asset
+func (c *Client) GetAlbumsForAsset(ctx context.Context, assetID string) ([]Album, error) {
+	endpoint := fmt.Sprintf("%s/api/albums?assetId=%s", c.baseURL, url.QueryEscape(assetID))
+
+	var albums []Album
+	if err := c.get(ctx, endpoint, &albums); err != nil {
+		return nil, err
+	}
+
+	return albums, nil
+}
+
+// GetAllAlbumsConditional fetches the full album listing with a conditional
+// request. When the listing has not changed since the last call — the server
+// answers 304 Not Modified, or the body hashes to the same value as before —
+// notModified is true and albums is nil, so callers can keep serving their
+// cached copy without re-decoding an unchanged payload.
+func (c *Client) GetAllAlbumsConditional(ctx context.Context) (albums []Album, notModified bool, err error) {
+	endpoint := fmt.Sprintf("%s/api/albums", c.baseURL)
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, false, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("x-api-key", c.apiKey)
+
+	c.albumsCondMu.Lock()
+	if c.albumsETag != "" {
+		req.Header.Set("If-None-Match", c.albumsETag)
+	}
+	if c.albumsLastModified != "" {
+		req.Header.Set("If-Modified-Since", c.albumsLastModified)
+	}
+	prevHash := c.albumsBodyHash
+	c.albumsCondMu.Unlock()
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	c.recordRequestDuration(time.Since(start))
+	if err != nil {
+		return nil, false, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, true, nil
+	}
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, false, &APIError{Status: resp.StatusCode, Body: string(bodyBytes)}
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read response: %w", err)
+	}
+	bodyHash := fmt.Sprintf("%x", sha256.Sum256(bodyBytes))
+
+	c.albumsCondMu.Lock()
+	c.albumsETag = resp.Header.Get("ETag")
+	c.albumsLastModified = resp.Header.Get("Last-Modified")
+	c.albumsBodyHash = bodyHash
+	c.albumsCondMu.Unlock()
+
+	if prevHash != "" && bodyHash == prevHash {
+		return nil, true, nil
+	}
+
+	if err := json.Unmarshal(bodyBytes, &albums); err != nil {
+		return nil, false, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return albums, false, nil
+}
+
 // GetAllAlbumsWithInfo gets all albums with full metadata
 func (c *Client) GetAllAlbumsWithInfo(ctx context.Context) ([]Album, error) {
 	// Get all albums (both owned and shared)
@@ -187,8 +419,36 @@ func (c *Client) GetAllAlbumsWithInfo(ctx context.Context) ([]Album, error) {
 	return albums, nil
 }
 
+// MetadataSearchFilters are the filters the scan helpers can push down to
+// the metadata search endpoint, so broad scans only transfer assets that can
+// possibly match. Immich's metadata search accepts asset type and capture
+// date bounds but has no filter for thumbnail integrity, pixel dimensions,
+// or video duration — predicates on those stay client-side:
+//
+//	type                    -> pushed down ("type")
+//	takenAfter/takenBefore  -> pushed down
+//	createdAfter            -> pushed down
+//	isNotInAlbum            -> pushed down
+//	thumbhash               -> client-side (no API filter)
+//	width/height            -> client-side (EXIF only in responses)
+//	duration                -> client-side (no range filter)
+type MetadataSearchFilters struct {
+	Type          string // IMAGE or VIDEO
+	TakenAfter    string // ISO 8601
+	TakenBefore   string // ISO 8601
+	CreatedAfter  string // ISO 8601
+	UpdatedBefore string // ISO 8601; pins scans to an earlier watermark
+	IsNotInAlbum  *bool
+}
+
 // GetAllAssets gets all assets with pagination support
 func (c *Client) GetAllAssets(ctx context.Context, page, size int) (*AssetPage, error) {
+	return c.GetAssetsFiltered(ctx, page, size, MetadataSearchFilters{})
+}
+
+// GetAssetsFiltered gets assets with pagination, pushing the given filters
+// down to the server
+func (c *Client) GetAssetsFiltered(ctx context.Context, page, size int, filters MetadataSearchFilters) (*AssetPage, error) {
 	// Calculate offset from page and size
 	offset := (page - 1) * size
 
@@ -201,6 +461,24 @@ func (c *Client) GetAllAssets(ctx context.Context, page, size int) (*AssetPage,
 		"size":     size,
 		"withExif": true, // Include EXIF data for dimensions
 	}
+	if filters.Type != "" {
+		body["type"] = filters.Type
+	}
+	if filters.TakenAfter != "" {
+		body["takenAfter"] = filters.TakenAfter
+	}
+	if filters.TakenBefore != "" {
+		body["takenBefore"] = filters.TakenBefore
+	}
+	if filters.CreatedAfter != "" {
+		body["createdAfter"] = filters.CreatedAfter
+	}
+	if filters.UpdatedBefore != "" {
+		body["updatedBefore"] = filters.UpdatedBefore
+	}
+	if filters.IsNotInAlbum != nil {
+		body["isNotInAlbum"] = *filters.IsNotInAlbum
+	}
 
 	var searchResult struct {
 		Assets struct {
@@ -239,6 +517,14 @@ func (c *Client) CreateAlbum(ctx context.Context, params CreateAlbumParams) (*Al
 		body["assetIds"] = params.AssetIDs
 	}
 
+	if len(params.SharedUserIDs) > 0 {
+		users := make([]map[string]interface{}, 0, len(params.SharedUserIDs))
+		for _, userID := range params.SharedUserIDs {
+			users = append(users, map[string]interface{}{"userId": userID, "role": "editor"})
+		}
+		body["albumUsers"] = users
+	}
+
 	var album Album
 	if err := c.post(ctx, endpoint, body, &album); err != nil {
 		return nil, err
@@ -247,6 +533,24 @@ func (c *Client) CreateAlbum(ctx context.Context, params CreateAlbumParams) (*Al
 	return &album, nil
 }
 
+// UpdateAlbum updates album properties (name, description, order)
+func (c *Client) UpdateAlbum(ctx context.Context, albumID string, updates map[string]interface{}) (*Album, error) {
+	endpoint := fmt.Sprintf("%s/api/albums/%s", c.baseURL, albumID)
+
+	var album Album
+	if err := c.patch(ctx, endpoint, updates, &album); err != nil {
+		return nil, err
+	}
+
+	return &album, nil
+}
+
+// DeleteAlbum deletes an album (the assets themselves are untouched)
+func (c *Client) DeleteAlbum(ctx context.Context, albumID string) error {
+	endpoint := fmt.Sprintf("%s/api/albums/%s", c.baseURL, albumID)
+	return c.delete(ctx, endpoint, nil)
+}
+
 // AddAssetsToAlbum adds assets to an album
 func (c *Client) AddAssetsToAlbum(ctx context.Context, albumID string, assetIDs []string) (*BulkIDResult, error) {
 	endpoint := fmt.Sprintf("%s/api/albums/%s/assets", c.baseURL, albumID)
@@ -294,6 +598,55 @@ func (c *Client) DeleteAssets(ctx context.Context, assetIDs []string, forceDelet
 	return c.delete(ctx, endpoint, body)
 }
 
+// RestoreAssets restores trashed assets back to the timeline
+func (c *Client) RestoreAssets(ctx context.Context, assetIDs []string) error {
+	endpoint := fmt.Sprintf("%s/api/trash/restore/assets", c.baseURL)
+
+	body := map[string]interface{}{
+		"ids": assetIDs,
+	}
+
+	return c.post(ctx, endpoint, body, nil)
+}
+
+// GetDuplicates gets the duplicate clusters Immich's duplicate detection
+// job has found; empty when the job has never run
+func (c *Client) GetDuplicates(ctx context.Context) ([]DuplicateGroup, error) {
+	endpoint := fmt.Sprintf("%s/api/duplicates", c.baseURL)
+
+	var groups []DuplicateGroup
+	if err := c.get(ctx, endpoint, &groups); err != nil {
+		return nil, fmt.Errorf("failed to get duplicates: %w", err)
+	}
+
+	return groups, nil
+}
+
+// CreateStack stacks assets together; the first ID becomes the stack's
+// primary asset, shown in the timeline in place of the others
+func (c *Client) CreateStack(ctx context.Context, assetIDs []string) (*Stack, error) {
+	endpoint := fmt.Sprintf("%s/api/stacks", c.baseURL)
+
+	var stack Stack
+	if err := c.post(ctx, endpoint, map[string]interface{}{"assetIds": assetIDs}, &stack); err != nil {
+		return nil, fmt.Errorf("failed to create stack: %w", err)
+	}
+
+	return &stack, nil
+}
+
+// GetAlbumInfo gets one album's metadata by ID
+func (c *Client) GetAlbumInfo(ctx context.Context, albumID string) (*Album, error) {
+	endpoint := fmt.Sprintf("%s/api/albums/%s", c.baseURL, albumID)
+
+	var album Album
+	if err := c.get(ctx, endpoint, &album); err != nil {
+		return nil, err
+	}
+
+	return &album, nil
+}
+
 // GetAlbumAssets gets all assets in an album
 func (c *Client) GetAlbumAssets(ctx context.Context, albumID string) ([]Asset, error) {
 	endpoint := fmt.Sprintf("%s/api/albums/%s", c.baseURL, albumID)
@@ -335,6 +688,7 @@ type SmartSearchParams struct {
 	AlbumIds      []string `json:"albumIds,omitempty"`
 	PersonIds     []string `json:"personIds,omitempty"`
 	TagIds        []string `json:"tagIds,omitempty"`
+	TagPaths      []string `json:"tagPaths,omitempty"` // resolved client-side into TagIds; never sent to the API
 	City          string   `json:"city,omitempty"`
 	Country       string   `json:"country,omitempty"`
 	State         string   `json:"state,omitempty"`
@@ -378,9 +732,42 @@ func (c *Client) SmartSearch(ctx context.Context, query string, limit int) ([]As
 
 // SmartSearchAdvanced performs AI-powered search with all available parameters
 func (c *Client) SmartSearchAdvanced(ctx context.Context, params SmartSearchParams) ([]Asset, error) {
+	var allAssets []Asset
+	err := c.SmartSearchStream(ctx, params, func(asset Asset) error {
+		allAssets = append(allAssets, asset)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return allAssets, nil
+}
+
+// SmartSearchStream performs an AI-powered search, delivering each matching
+// asset to fn as soon as its page arrives instead of accumulating the full
+// result set in memory. A non-nil error from fn aborts the search.
+func (c *Client) SmartSearchStream(ctx context.Context, params SmartSearchParams, fn func(Asset) error) error {
 	endpoint := fmt.Sprintf("%s/api/search/smart", c.baseURL)
 
-	var allAssets []Asset
+	// Tag paths expand to the named tag plus all of its descendants
+	if len(params.TagPaths) > 0 {
+		resolved, err := c.ResolveTagPaths(ctx, params.TagPaths)
+		if err != nil {
+			return err
+		}
+		seen := make(map[string]bool, len(params.TagIds))
+		for _, id := range params.TagIds {
+			seen[id] = true
+		}
+		for _, id := range resolved {
+			if !seen[id] {
+				params.TagIds = append(params.TagIds, id)
+			}
+		}
+		params.TagPaths = nil
+	}
+
+	delivered := 0
 	page := 1
 
 	// Set default page size if not specified
@@ -508,16 +895,20 @@ func (c *Client) SmartSearchAdvanced(ctx context.Context, params SmartSearchPara
 		}
 
 		if err := c.post(ctx, endpoint, body, &searchResult); err != nil {
-			return nil, err
+			return err
 		}
 
-		// Add the items from this page
-		allAssets = append(allAssets, searchResult.Assets.Items...)
+		// Deliver the items from this page
+		for _, asset := range searchResult.Assets.Items {
+			if err := fn(asset); err != nil {
+				return err
+			}
+			delivered++
 
-		// Check if we've collected enough
-		if params.Size > 0 && len(allAssets) >= params.Size {
-			allAssets = allAssets[:params.Size]
-			break
+			// Check if we've delivered enough
+			if params.Size > 0 && delivered >= params.Size {
+				return nil
+			}
 		}
 
 		// Check if there are more pages
@@ -533,7 +924,54 @@ func (c *Client) SmartSearchAdvanced(ctx context.Context, params SmartSearchPara
 		}
 	}
 
-	return allAssets, nil
+	return nil
+}
+
+// SmartSearchTotal reports how many assets match the query without paging
+// through the results
+func (c *Client) SmartSearchTotal(ctx context.Context, params SmartSearchParams) (int, error) {
+	// Tag paths expand to the named tag plus all of its descendants, same as
+	// the streaming path
+	if len(params.TagPaths) > 0 {
+		resolved, err := c.ResolveTagPaths(ctx, params.TagPaths)
+		if err != nil {
+			return 0, err
+		}
+		seen := make(map[string]bool, len(params.TagIds))
+		for _, id := range params.TagIds {
+			seen[id] = true
+		}
+		for _, id := range resolved {
+			if !seen[id] {
+				params.TagIds = append(params.TagIds, id)
+			}
+		}
+		params.TagPaths = nil
+	}
+
+	// The omitempty tags on SmartSearchParams produce the same body the
+	// streaming path assembles field by field
+	data, err := json.Marshal(params)
+	if err != nil {
+		return 0, err
+	}
+	body := make(map[string]interface{})
+	if err := json.Unmarshal(data, &body); err != nil {
+		return 0, err
+	}
+	delete(body, "tagPaths")
+	body["size"] = 1
+	body["page"] = 1
+
+	var searchResult struct {
+		Assets struct {
+			Total int `json:"total"`
+		} `json:"assets"`
+	}
+	if err := c.post(ctx, fmt.Sprintf("%s/api/search/smart", c.baseURL), body, &searchResult); err != nil {
+		return 0, err
+	}
+	return searchResult.Assets.Total, nil
 }
 
 // SearchByFace searches for assets containing a specific person
@@ -567,30 +1005,29 @@ func (c *Client) SearchByLocation(ctx context.Context, params LocationSearchPara
 	return &results, nil
 }
 
-// FindBrokenAssets finds assets with issues
+// FindBrokenAssets scans the library page by page for assets with issues.
+// A zero limit returns everything found.
 func (c *Client) FindBrokenAssets(ctx context.Context, checkType, libraryID string, limit int) ([]BrokenAsset, error) {
-	// Get all assets with metadata
-	endpoint := fmt.Sprintf("%s/api/asset", c.baseURL)
+	// Whole-library sweeps run under the scan timeout
+	ctx = WithTimeoutCategory(ctx, TimeoutScan)
 
-	query := url.Values{}
-	if libraryID != "" {
-		query.Set("libraryId", libraryID)
-	}
-
-	fullURL := endpoint
-	if len(query) > 0 {
-		fullURL = fmt.Sprintf("%s?%s", endpoint, query.Encode())
-	}
-
-	var assets []Asset
-	if err := c.get(ctx, fullURL, &assets); err != nil {
-		return nil, err
-	}
-
-	// Filter broken assets
 	var brokenAssets []BrokenAsset
-	for _, asset := range assets {
-		if isBroken(asset, checkType) {
+	page := 1
+	const pageSize = 1000
+	for {
+		assetPage, err := c.GetAssetsFiltered(ctx, page, pageSize, MetadataSearchFilters{})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, asset := range assetPage.Assets {
+			if libraryID != "" && asset.LibraryID != libraryID {
+				continue
+			}
+			if !isBroken(asset, checkType) {
+				continue
+			}
+			issue, fix := brokenIssue(asset)
 			brokenAssets = append(brokenAssets, BrokenAsset{
 				ID:           asset.ID,
 				FileName:     asset.OriginalFileName,
@@ -598,12 +1035,19 @@ func (c *Client) FindBrokenAssets(ctx context.Context, checkType, libraryID stri
 				FileSize:     asset.FileSize,
 				HasThumbnail: asset.Resized,
 				LibraryID:    asset.LibraryID,
+				Status:       asset.Status,
+				IssueType:    issue,
+				SuggestedFix: fix,
 			})
-
-			if len(brokenAssets) >= limit {
-				break
+			if limit > 0 && len(brokenAssets) >= limit {
+				return brokenAssets, nil
 			}
 		}
+
+		if !assetPage.HasNextPage {
+			break
+		}
+		page++
 	}
 
 	return brokenAssets, nil
@@ -621,6 +1065,18 @@ func (c *Client) ListLibraries(ctx context.Context) ([]Library, error) {
 	return libraries, nil
 }
 
+// ListUsers lists all users; requires an admin API key
+func (c *Client) ListUsers(ctx context.Context) ([]map[string]interface{}, error) {
+	endpoint := fmt.Sprintf("%s/api/admin/users", c.baseURL)
+
+	var users []map[string]interface{}
+	if err := c.get(ctx, endpoint, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
 // MoveAssetsToLibrary moves assets to a library
 func (c *Client) MoveAssetsToLibrary(ctx context.Context, params MoveToLibraryParams) (*MoveToLibraryResult, error) {
 	endpoint := fmt.Sprintf("%s/api/library/%s/assets", c.baseURL, params.TargetLibraryID)
@@ -651,6 +1107,18 @@ func (c *Client) UpdateAssetMetadata(ctx context.Context, assetID string, update
 	return c.put(ctx, endpoint, updates, nil)
 }
 
+// UpdateAssetsBulk applies the same metadata updates to many assets in one
+// call (e.g. favoriting or archiving a batch)
+func (c *Client) UpdateAssetsBulk(ctx context.Context, assetIDs []string, updates map[string]interface{}) error {
+	endpoint := fmt.Sprintf("%s/api/assets", c.baseURL)
+
+	body := map[string]interface{}{"ids": assetIDs}
+	for key, value := range updates {
+		body[key] = value
+	}
+	return c.put(ctx, endpoint, body, nil)
+}
+
 // AnalyzeAssets triggers analysis jobs for assets
 func (c *Client) AnalyzeAssets(ctx context.Context, assetIDs []string, options AnalyzeOptions) (*AnalyzeResult, error) {
 	endpoint := fmt.Sprintf("%s/api/jobs", c.baseURL)
@@ -668,6 +1136,31 @@ func (c *Client) AnalyzeAssets(ctx context.Context, assetIDs []string, options A
 	return &result, nil
 }
 
+// RunAssetJobs queues the named job (e.g. smart-search, face-detection,
+// refresh-metadata) for the given assets
+func (c *Client) RunAssetJobs(ctx context.Context, assetIDs []string, name string) error {
+	endpoint := fmt.Sprintf("%s/api/assets/jobs", c.baseURL)
+
+	body := map[string]interface{}{
+		"assetIds": assetIDs,
+		"name":     name,
+	}
+	return c.post(ctx, endpoint, body, nil)
+}
+
+// GetJobStatuses reports the state of every job queue (counts of active,
+// waiting, and failed jobs per queue)
+func (c *Client) GetJobStatuses(ctx context.Context) (map[string]interface{}, error) {
+	endpoint := fmt.Sprintf("%s/api/jobs", c.baseURL)
+
+	var statuses map[string]interface{}
+	if err := c.get(ctx, endpoint, &statuses); err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
 // RepairAssets triggers repair jobs for assets
 func (c *Client) RepairAssets(ctx context.Context, assetIDs []string, actions RepairActions) (*RepairResult, error) {
 	endpoint := fmt.Sprintf("%s/api/jobs", c.baseURL)
@@ -689,34 +1182,58 @@ func (c *Client) RepairAssets(ctx context.Context, assetIDs []string, actions Re
 	return &result, nil
 }
 
-// ExportAssets exports assets for download
-func (c *Client) ExportAssets(ctx context.Context, assetIDs []string, format string) (*ExportResult, error) {
+// AssetDownloadURL returns the direct download URL for an asset's original file
+func (c *Client) AssetDownloadURL(assetID string) string {
+	return fmt.Sprintf("%s/api/asset/download/%s", c.baseURL, assetID)
+}
+
+// DownloadArchive streams a ZIP archive of the given assets from the
+// server's download endpoint into w, returning the number of bytes written.
+// The stream runs under the download timeout and bandwidth limit.
+func (c *Client) DownloadArchive(ctx context.Context, assetIDs []string, w io.Writer) (int64, error) {
 	if len(assetIDs) == 0 {
-		return nil, fmt.Errorf("no asset IDs provided")
+		return 0, fmt.Errorf("no asset IDs provided")
 	}
 
-	// Generate download URLs
-	downloadURLs := make([]string, 0, len(assetIDs))
-	for _, id := range assetIDs {
-		url := fmt.Sprintf("%s/api/asset/download/%s", c.baseURL, id)
-		downloadURLs = append(downloadURLs, url)
+	endpoint := fmt.Sprintf("%s/api/download/archive", c.baseURL)
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return 0, err
 	}
 
-	downloadURL := ""
-	if len(downloadURLs) > 0 {
-		downloadURL = downloadURLs[0]
+	// Binary fetches run under the download timeout
+	if timeout := c.timeouts.Download; timeout > 0 {
+		if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
 	}
 
-	result := &ExportResult{
-		Success:     true,
-		ExportID:    fmt.Sprintf("export-%d", time.Now().Unix()),
-		DownloadURL: downloadURL,
-		ExpiresAt:   time.Now().Add(24 * time.Hour).Format(time.RFC3339),
-		FileCount:   len(assetIDs),
-		Format:      format,
+	body, err := json.Marshal(map[string]interface{}{"assetIds": assetIDs})
+	if err != nil {
+		return 0, err
 	}
 
-	return result, nil
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("archive request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return 0, &APIError{Status: resp.StatusCode, Body: string(bodyBytes)}
+	}
+
+	return io.Copy(w, c.limitDownload(ctx, resp.Body))
 }
 
 // Helper methods for HTTP operations
@@ -733,16 +1250,48 @@ func (c *Client) put(ctx context.Context, url string, body interface{}, result i
 	return c.request(ctx, http.MethodPut, url, body, result)
 }
 
+func (c *Client) patch(ctx context.Context, url string, body interface{}, result interface{}) error {
+	return c.request(ctx, http.MethodPatch, url, body, result)
+}
+
 func (c *Client) delete(ctx context.Context, url string, body interface{}) error {
 	return c.request(ctx, http.MethodDelete, url, body, nil)
 }
 
 func (c *Client) request(ctx context.Context, method, url string, body interface{}, result interface{}) error {
+	// Record-only mode: capture the would-be write and report synthetic
+	// success, leaving the caller's result at its zero value
+	if c.writeRecorder != nil && isMutatingRequest(method, url) {
+		var jsonBody json.RawMessage
+		if body != nil {
+			var err error
+			jsonBody, err = json.Marshal(body)
+			if err != nil {
+				return fmt.Errorf("failed to marshal body: %w", err)
+			}
+		}
+		c.writeRecorder.Record(method, url, jsonBody)
+		log.Info().
+			Str("method", method).
+			Str("url", url).
+			Msg("Recorded write instead of calling Immich (record-only mode)")
+		return nil
+	}
+
 	// Rate limit
 	if err := c.rateLimiter.Wait(ctx); err != nil {
 		return err
 	}
 
+	// Apply the per-category deadline unless the caller already set one
+	if timeout := c.timeoutFor(ctx, method); timeout > 0 {
+		if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
+
 	// Prepare body
 	var bodyReader io.Reader
 	var jsonBody []byte
@@ -784,7 +1333,9 @@ func (c *Client) request(ctx context.Context, method, url string, body interface
 	}
 
 	// Execute request
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
+	c.recordRequestDuration(time.Since(start))
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
@@ -802,7 +1353,7 @@ func (c *Client) request(ctx context.Context, method, url string, body interface
 	// Check status
 	if resp.StatusCode >= 400 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error: status=%d body=%s", resp.StatusCode, string(bodyBytes))
+		return &APIError{Status: resp.StatusCode, Body: string(bodyBytes)}
 	}
 
 	// Decode response
@@ -815,6 +1366,57 @@ func (c *Client) request(ctx context.Context, method, url string, body interface
 	return nil
 }
 
+// recordRequestDuration feeds the observed-throughput stats
+func (c *Client) recordRequestDuration(d time.Duration) {
+	c.statsMu.Lock()
+	c.requestCount++
+	c.requestTotals += d
+	if len(c.recentDurations) < recentDurationWindow {
+		c.recentDurations = append(c.recentDurations, d)
+	} else {
+		c.recentDurations[c.recentIndex] = d
+		c.recentIndex = (c.recentIndex + 1) % recentDurationWindow
+	}
+	c.statsMu.Unlock()
+}
+
+// LatencyPercentile estimates the given latency percentile (0-1) over the
+// most recent requests. Returns zero before any request.
+func (c *Client) LatencyPercentile(p float64) time.Duration {
+	c.statsMu.Lock()
+	recent := append([]time.Duration(nil), c.recentDurations...)
+	c.statsMu.Unlock()
+
+	if len(recent) == 0 {
+		return 0
+	}
+	sort.Slice(recent, func(i, j int) bool { return recent[i] < recent[j] })
+	index := int(p*float64(len(recent))) - 1
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(recent) {
+		index = len(recent) - 1
+	}
+	return recent[index]
+}
+
+// AverageRequestDuration returns the mean observed API request duration and
+// the number of requests it is based on. Returns zero before any request.
+func (c *Client) AverageRequestDuration() (time.Duration, int64) {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+	if c.requestCount == 0 {
+		return 0, 0
+	}
+	return c.requestTotals / time.Duration(c.requestCount), c.requestCount
+}
+
+// RateLimitPerSecond returns the client-side request rate limit
+func (c *Client) RateLimitPerSecond() float64 {
+	return float64(c.rateLimiter.Limit())
+}
+
 // Helper function to check if an asset is broken
 func isBroken(asset Asset, checkType string) bool {
 	switch checkType {
@@ -824,9 +1426,28 @@ func isBroken(asset Asset, checkType string) bool {
 		return asset.FileSize == 0
 	case "processing_failed":
 		return asset.Status == "failed"
+	case "offline":
+		return asset.Status == "offline"
 	default:
 		return (asset.FileSize > 0 && !asset.Resized) ||
 			asset.FileSize == 0 ||
-			asset.Status == "failed"
+			asset.Status == "failed" ||
+			asset.Status == "offline"
 	}
 }
+
+// brokenIssue names a broken asset's most severe issue and the repair most
+// likely to fix it
+func brokenIssue(asset Asset) (string, string) {
+	switch {
+	case asset.FileSize == 0:
+		return "zero_size", "re-import the original; the stored file is empty"
+	case asset.Status == "failed":
+		return "processing_failed", "re-run metadata extraction and thumbnail generation via repairAssets"
+	case asset.Status == "offline":
+		return "offline", "restore the external library file or rescan the library"
+	case !asset.Resized:
+		return "missing_thumbnail", "regenerate thumbnails via repairAssets"
+	}
+	return "", ""
+}
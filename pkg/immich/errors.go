@@ -0,0 +1,28 @@
+package immich
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is a non-2xx response from the Immich API.
+type APIError struct {
+	Status int
+	Body   string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error: status=%d body=%s", e.Status, e.Body)
+}
+
+// IsNotFound reports whether err is an API response for a missing or trashed
+// resource. Bulk operations use this to treat assets deleted mid-operation
+// as tombstones rather than hard failures.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status == http.StatusNotFound || apiErr.Status == http.StatusGone
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package immich
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RecordedWrite is one mutating API call captured in record-only mode instead
+// of being sent to Immich.
+type RecordedWrite struct {
+	Seq        int             `json:"seq"`
+	RecordedAt time.Time       `json:"recordedAt"`
+	Method     string          `json:"method"`
+	URL        string          `json:"url"`
+	Body       json.RawMessage `json:"body,omitempty"`
+}
+
+// WriteRecorder collects the mutating calls a record-only client would have
+// made, so an agent session can be rehearsed against production data and the
+// would-be changes reviewed afterwards.
+type WriteRecorder struct {
+	mu      sync.Mutex
+	nextSeq int
+	writes  []RecordedWrite
+}
+
+// NewWriteRecorder creates an empty recorder
+func NewWriteRecorder() *WriteRecorder {
+	return &WriteRecorder{}
+}
+
+// Record captures one mutating call
+func (r *WriteRecorder) Record(method, url string, body json.RawMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextSeq++
+	r.writes = append(r.writes, RecordedWrite{
+		Seq:        r.nextSeq,
+		RecordedAt: time.Now(),
+		Method:     method,
+		URL:        url,
+		Body:       body,
+	})
+}
+
+// Writes returns a copy of everything recorded so far
+func (r *WriteRecorder) Writes() []RecordedWrite {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]RecordedWrite, len(r.writes))
+	copy(out, r.writes)
+	return out
+}
+
+// Clear drops the recorded calls and reports how many were discarded
+func (r *WriteRecorder) Clear() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := len(r.writes)
+	r.writes = nil
+	return n
+}
+
+// SetWriteRecorder routes all mutating requests into the recorder instead of
+// sending them to Immich; reads still hit the server. Pass nil to restore
+// live writes.
+func (c *Client) SetWriteRecorder(r *WriteRecorder) {
+	c.writeRecorder = r
+}
+
+// isMutatingRequest reports whether a request would change server state.
+// POST doubles as the query verb for the search endpoints, which stay live.
+func isMutatingRequest(method, url string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return false
+	case http.MethodPost:
+		return !strings.Contains(url, "/api/search")
+	default:
+		return true
+	}
+}
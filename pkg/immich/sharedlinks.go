@@ -0,0 +1,39 @@
+package immich
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SharedLink represents an Immich shared link and what it exposes
+type SharedLink struct {
+	ID          string     `json:"id"`
+	Type        string     `json:"type"` // ALBUM or INDIVIDUAL
+	Description string     `json:"description,omitempty"`
+	Key         string     `json:"key,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	Album       *Album     `json:"album,omitempty"`
+	Assets      []Asset    `json:"assets,omitempty"`
+	AllowUpload bool       `json:"allowUpload"`
+	ShowExif    bool       `json:"showMetadata"`
+}
+
+// ListSharedLinks lists all shared links owned by the current user
+func (c *Client) ListSharedLinks(ctx context.Context) ([]SharedLink, error) {
+	endpoint := fmt.Sprintf("%s/api/shared-links", c.baseURL)
+
+	var links []SharedLink
+	if err := c.get(ctx, endpoint, &links); err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// UpdateSharedLink updates a shared link's settings (e.g. showMetadata)
+func (c *Client) UpdateSharedLink(ctx context.Context, linkID string, updates map[string]interface{}) error {
+	endpoint := fmt.Sprintf("%s/api/shared-links/%s", c.baseURL, linkID)
+	return c.patch(ctx, endpoint, updates, nil)
+}
@@ -0,0 +1,181 @@
+package immich
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// tagTreeTTL caps how long a cached tag tree is reused before the tag list
+// is refetched
+const tagTreeTTL = 5 * time.Minute
+
+// Tag represents an Immich tag
+type Tag struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	ParentID string `json:"parentId,omitempty"`
+}
+
+// ListTags returns all tags visible to the current user
+func (c *Client) ListTags(ctx context.Context) ([]Tag, error) {
+	endpoint := fmt.Sprintf("%s/api/tags", c.baseURL)
+
+	var tags []Tag
+	if err := c.get(ctx, endpoint, &tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// UpsertTags creates any missing tags by name and returns the full tag
+// records, existing or newly created
+func (c *Client) UpsertTags(ctx context.Context, names []string) ([]Tag, error) {
+	endpoint := fmt.Sprintf("%s/api/tags", c.baseURL)
+
+	body := map[string]interface{}{
+		"tags": names,
+	}
+
+	var tags []Tag
+	if err := c.put(ctx, endpoint, body, &tags); err != nil {
+		return nil, err
+	}
+
+	// Newly created tags must show up in path resolution
+	c.invalidateTagTree()
+	return tags, nil
+}
+
+// TagAssets links a tag to the given assets
+func (c *Client) TagAssets(ctx context.Context, tagID string, assetIDs []string) (*BulkIDResult, error) {
+	endpoint := fmt.Sprintf("%s/api/tags/%s/assets", c.baseURL, tagID)
+
+	body := map[string]interface{}{
+		"ids": assetIDs,
+	}
+
+	var results []struct {
+		ID      string `json:"id"`
+		Success bool   `json:"success"`
+	}
+	if err := c.put(ctx, endpoint, body, &results); err != nil {
+		return nil, err
+	}
+
+	result := &BulkIDResult{}
+	for _, r := range results {
+		if r.Success {
+			result.Success = append(result.Success, r.ID)
+		} else {
+			result.Error = append(result.Error, r.ID)
+		}
+	}
+
+	return result, nil
+}
+
+// TagTree indexes tags by full path and by parent for hierarchy-aware
+// lookups such as "animals/dogs" including its descendant tags.
+type TagTree struct {
+	byPath   map[string]Tag   // lowercased full path -> tag
+	children map[string][]Tag // parent tag ID -> child tags
+}
+
+// NewTagTree builds a tag tree from a flat tag list
+func NewTagTree(tags []Tag) *TagTree {
+	t := &TagTree{
+		byPath:   make(map[string]Tag, len(tags)),
+		children: make(map[string][]Tag),
+	}
+	for _, tag := range tags {
+		path := tag.Value
+		if path == "" {
+			path = tag.Name
+		}
+		t.byPath[strings.ToLower(path)] = tag
+		if tag.ParentID != "" {
+			t.children[tag.ParentID] = append(t.children[tag.ParentID], tag)
+		}
+	}
+	return t
+}
+
+// Resolve looks up a tag by its full path, case-insensitively
+func (t *TagTree) Resolve(path string) (Tag, bool) {
+	tag, found := t.byPath[strings.ToLower(strings.Trim(path, "/"))]
+	return tag, found
+}
+
+// Descendants returns every tag nested anywhere under the given tag
+func (t *TagTree) Descendants(tagID string) []Tag {
+	var descendants []Tag
+	queue := append([]Tag(nil), t.children[tagID]...)
+	for len(queue) > 0 {
+		tag := queue[0]
+		queue = queue[1:]
+		descendants = append(descendants, tag)
+		queue = append(queue, t.children[tag.ID]...)
+	}
+	return descendants
+}
+
+// GetTagTree returns the tag hierarchy, cached briefly so repeated path
+// resolutions do not refetch the full tag list
+func (c *Client) GetTagTree(ctx context.Context) (*TagTree, error) {
+	c.tagTreeMu.Lock()
+	if c.tagTree != nil && time.Since(c.tagTreeAt) < tagTreeTTL {
+		tree := c.tagTree
+		c.tagTreeMu.Unlock()
+		return tree, nil
+	}
+	c.tagTreeMu.Unlock()
+
+	tags, err := c.ListTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tree := NewTagTree(tags)
+
+	c.tagTreeMu.Lock()
+	c.tagTree = tree
+	c.tagTreeAt = time.Now()
+	c.tagTreeMu.Unlock()
+	return tree, nil
+}
+
+// invalidateTagTree drops the cached tag tree after tags are created
+func (c *Client) invalidateTagTree() {
+	c.tagTreeMu.Lock()
+	c.tagTree = nil
+	c.tagTreeMu.Unlock()
+}
+
+// ResolveTagPaths expands tag paths into the matching tag IDs, automatically
+// including every descendant tag of each path
+func (c *Client) ResolveTagPaths(ctx context.Context, paths []string) ([]string, error) {
+	tree, err := c.GetTagTree(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tags: %w", err)
+	}
+
+	var ids []string
+	seen := map[string]bool{}
+	for _, path := range paths {
+		tag, found := tree.Resolve(path)
+		if !found {
+			return nil, fmt.Errorf("tag path '%s' not found", path)
+		}
+		for _, match := range append([]Tag{tag}, tree.Descendants(tag.ID)...) {
+			if seen[match.ID] {
+				continue
+			}
+			seen[match.ID] = true
+			ids = append(ids, match.ID)
+		}
+	}
+	return ids, nil
+}
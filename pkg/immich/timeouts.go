@@ -0,0 +1,74 @@
+package immich
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// TimeoutCategory classifies Immich API calls so quick lookups and
+// long-running operations can carry different deadlines.
+type TimeoutCategory string
+
+const (
+	TimeoutQuery    TimeoutCategory = "query"
+	TimeoutMutation TimeoutCategory = "mutation"
+	TimeoutScan     TimeoutCategory = "scan"
+	TimeoutDownload TimeoutCategory = "download"
+)
+
+// CategoryTimeouts holds the per-category request timeouts applied by the
+// client wrapper. A zero value leaves that category bounded only by the
+// underlying transport timeout.
+type CategoryTimeouts struct {
+	Query    time.Duration
+	Mutation time.Duration
+	Scan     time.Duration
+	Download time.Duration
+}
+
+type timeoutCategoryKey struct{}
+
+// WithTimeoutCategory marks a context so subsequent client calls apply the
+// given category's timeout instead of the one inferred from the HTTP method.
+func WithTimeoutCategory(ctx context.Context, category TimeoutCategory) context.Context {
+	return context.WithValue(ctx, timeoutCategoryKey{}, category)
+}
+
+// SetCategoryTimeouts configures per-category timeouts. The transport cap is
+// raised if needed so the category deadlines govern long operations.
+func (c *Client) SetCategoryTimeouts(t CategoryTimeouts) {
+	c.timeouts = t
+
+	maxTimeout := c.httpClient.Timeout
+	for _, d := range []time.Duration{t.Query, t.Mutation, t.Scan, t.Download} {
+		if d > maxTimeout {
+			maxTimeout = d
+		}
+	}
+	c.httpClient.Timeout = maxTimeout
+}
+
+// timeoutFor resolves the effective timeout for a request: an explicit
+// category on the context wins, otherwise GETs count as queries and
+// everything else as mutations.
+func (c *Client) timeoutFor(ctx context.Context, method string) time.Duration {
+	category := TimeoutQuery
+	if method != http.MethodGet {
+		category = TimeoutMutation
+	}
+	if v, ok := ctx.Value(timeoutCategoryKey{}).(TimeoutCategory); ok {
+		category = v
+	}
+
+	switch category {
+	case TimeoutMutation:
+		return c.timeouts.Mutation
+	case TimeoutScan:
+		return c.timeouts.Scan
+	case TimeoutDownload:
+		return c.timeouts.Download
+	default:
+		return c.timeouts.Query
+	}
+}
@@ -4,30 +4,73 @@ import "time"
 
 // Asset represents an Immich asset
 type Asset struct {
-	ID               string    `json:"id"`
-	DeviceAssetID    string    `json:"deviceAssetId"`
-	OwnerID          string    `json:"ownerId"`
-	DeviceID         string    `json:"deviceId"`
-	LibraryID        string    `json:"libraryId,omitempty"`
-	Type             string    `json:"type"` // IMAGE or VIDEO
-	OriginalPath     string    `json:"originalPath"`
-	OriginalFileName string    `json:"originalFileName"`
-	Resized          bool      `json:"resized"`     // Has thumbnail
-	Thumbhash        string    `json:"thumbhash,omitempty"`
-	FileCreatedAt    time.Time `json:"fileCreatedAt"`
-	FileModifiedAt   time.Time `json:"fileModifiedAt"`
-	UpdatedAt        time.Time `json:"updatedAt"`
-	IsFavorite       bool      `json:"isFavorite"`
-	IsArchived       bool      `json:"isArchived"`
-	Duration         *string   `json:"duration,omitempty"`
-	FileSize         int64     `json:"fileSizeInByte,omitempty"`
-	Status           string    `json:"status,omitempty"`
-	ExifInfo         *ExifInfo `json:"exifInfo,omitempty"`
+	ID               string     `json:"id"`
+	DeviceAssetID    string     `json:"deviceAssetId"`
+	OwnerID          string     `json:"ownerId"`
+	DeviceID         string     `json:"deviceId"`
+	LibraryID        string     `json:"libraryId,omitempty"`
+	Type             string     `json:"type"` // IMAGE or VIDEO
+	OriginalPath     string     `json:"originalPath"`
+	OriginalFileName string     `json:"originalFileName"`
+	Resized          bool       `json:"resized"` // Has thumbnail
+	Thumbhash        string     `json:"thumbhash,omitempty"`
+	Checksum         string     `json:"checksum,omitempty"` // base64 SHA-1 of the original file
+	FileCreatedAt    time.Time  `json:"fileCreatedAt"`
+	FileModifiedAt   time.Time  `json:"fileModifiedAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+	IsFavorite       bool       `json:"isFavorite"`
+	IsArchived       bool       `json:"isArchived"`
+	Visibility       string     `json:"visibility,omitempty"` // timeline, archive, hidden, locked
+	Duration         *string    `json:"duration,omitempty"`
+	FileSize         int64      `json:"fileSizeInByte,omitempty"`
+	Status           string     `json:"status,omitempty"`
+	ExifInfo         *ExifInfo  `json:"exifInfo,omitempty"`
 	SmartInfo        *SmartInfo `json:"smartInfo,omitempty"`
+	People           []Person   `json:"people,omitempty"`
+}
+
+// Person represents a recognized person on an asset
+type Person struct {
+	ID            string      `json:"id"`
+	Name          string      `json:"name"`
+	BirthDate     string      `json:"birthDate,omitempty"`
+	ThumbnailPath string      `json:"thumbnailPath,omitempty"`
+	IsHidden      bool        `json:"isHidden,omitempty"`
+	Faces         []AssetFace `json:"faces,omitempty"`
+}
+
+// PeoplePage is one page of detected people
+type PeoplePage struct {
+	People []Person `json:"people"`
+	Total  int      `json:"total"`
+	Hidden int      `json:"hidden,omitempty"`
+}
+
+// PersonMergeItem is the per-source outcome of a person merge
+type PersonMergeItem struct {
+	ID      string `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// AssetFace is one detected face region on an asset. The bounding box is in
+// pixel coordinates of the analyzed image, whose dimensions are included so
+// the box can be rescaled to other renditions.
+type AssetFace struct {
+	ID            string  `json:"id"`
+	ImageWidth    int     `json:"imageWidth"`
+	ImageHeight   int     `json:"imageHeight"`
+	BoundingBoxX1 int     `json:"boundingBoxX1"`
+	BoundingBoxY1 int     `json:"boundingBoxY1"`
+	BoundingBoxX2 int     `json:"boundingBoxX2"`
+	BoundingBoxY2 int     `json:"boundingBoxY2"`
+	SourceType    string  `json:"sourceType,omitempty"`
+	Person        *Person `json:"person,omitempty"`
 }
 
 // ExifInfo contains EXIF metadata
 type ExifInfo struct {
+	Description      string   `json:"description,omitempty"`
 	Make             string   `json:"make,omitempty"`
 	Model            string   `json:"model,omitempty"`
 	ExifImageWidth   int      `json:"exifImageWidth,omitempty"`
@@ -40,6 +83,7 @@ type ExifInfo struct {
 	City             string   `json:"city,omitempty"`
 	State            string   `json:"state,omitempty"`
 	Country          string   `json:"country,omitempty"`
+	Rating           int      `json:"rating,omitempty"` // -1 to 5
 	ISO              int      `json:"iso,omitempty"`
 	ExposureTime     string   `json:"exposureTime,omitempty"`
 	FNumber          float64  `json:"fNumber,omitempty"`
@@ -47,6 +91,20 @@ type ExifInfo struct {
 	FocalLength      float64  `json:"focalLength,omitempty"`
 }
 
+// DuplicateGroup is one cluster of near-duplicate assets found by Immich's
+// duplicate detection job
+type DuplicateGroup struct {
+	DuplicateID string  `json:"duplicateId"`
+	Assets      []Asset `json:"assets"`
+}
+
+// Stack is a group of assets shown as one timeline entry
+type Stack struct {
+	ID             string  `json:"id"`
+	PrimaryAssetID string  `json:"primaryAssetId"`
+	Assets         []Asset `json:"assets,omitempty"`
+}
+
 // SmartInfo contains AI-generated information
 type SmartInfo struct {
 	Tags    []string `json:"tags,omitempty"`
@@ -87,8 +145,8 @@ type Library struct {
 
 // TimeBucket represents a time-based grouping of assets
 type TimeBucket struct {
-	Date     string `json:"timeBucket"`
-	Count    int    `json:"count"`
+	Date     string   `json:"timeBucket"`
+	Count    int      `json:"count"`
 	AssetIDs []string `json:"assetIds,omitempty"`
 }
 
@@ -139,15 +197,15 @@ type BulkIDResult struct {
 
 // QueryPhotosParams parameters for photo queries
 type QueryPhotosParams struct {
-	Query       string
-	StartDate   string
-	EndDate     string
-	AlbumID     string
-	Type        string // IMAGE, VIDEO, ALL
-	IsFavorite  bool
-	IsArchived  bool
-	Limit       int
-	Offset      int
+	Query      string
+	StartDate  string
+	EndDate    string
+	AlbumID    string
+	Type       string // IMAGE, VIDEO, ALL
+	IsFavorite bool
+	IsArchived bool
+	Limit      int
+	Offset     int
 }
 
 // BucketParams parameters for bucket queries
@@ -161,9 +219,10 @@ type BucketParams struct {
 
 // CreateAlbumParams parameters for album creation
 type CreateAlbumParams struct {
-	Name        string
-	Description string
-	AssetIDs    []string
+	Name          string
+	Description   string
+	AssetIDs      []string
+	SharedUserIDs []string // users the album is shared with on creation (editor role)
 }
 
 // FaceSearchParams parameters for face search
@@ -183,10 +242,10 @@ type LocationSearchParams struct {
 
 // MoveToLibraryParams parameters for library moves
 type MoveToLibraryParams struct {
-	AssetIDs          []string
-	TargetLibraryID   string
-	RemoveFromSource  bool
-	SkipDuplicates    bool
+	AssetIDs         []string
+	TargetLibraryID  string
+	RemoveFromSource bool
+	SkipDuplicates   bool
 }
 
 // MoveToLibraryResult result from library move
@@ -232,14 +291,3 @@ type RepairResult struct {
 		Failed     int
 	}
 }
-
-// ExportResult result from export
-type ExportResult struct {
-	Success     bool
-	ExportID    string
-	DownloadURL string
-	ExpiresAt   string
-	TotalSize   int64
-	FileCount   int
-	Format      string
-}
\ No newline at end of file
@@ -0,0 +1,214 @@
+// Package jobs runs long bulk operations in the background so a tool call
+// can return a job ID immediately instead of holding the request open for a
+// full library sweep. Job state is persisted to a JSON file, so completed
+// runs survive a restart; jobs that were still running when the process
+// stopped are marked interrupted on the next load.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Status is the lifecycle state of a job.
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// Job records one background run of a bulk tool.
+type Job struct {
+	ID          string                 `json:"id"`
+	Tool        string                 `json:"tool"`
+	Status      Status                 `json:"status"`
+	Progress    string                 `json:"progress,omitempty"` // latest note from the running operation
+	StartedAt   time.Time              `json:"startedAt"`
+	CompletedAt time.Time              `json:"completedAt,omitempty"`
+	Result      map[string]interface{} `json:"result,omitempty"`
+	Error       string                 `json:"error,omitempty"`
+}
+
+// RunFunc is the body of a background job. It should honour ctx cancellation
+// and may call report with short progress notes along the way.
+type RunFunc func(ctx context.Context, report func(note string)) (map[string]interface{}, error)
+
+// Manager starts background jobs and persists their state.
+type Manager struct {
+	path    string
+	mu      sync.Mutex
+	jobs    map[string]*Job
+	cancels map[string]context.CancelFunc
+}
+
+// NewManager opens (or creates) a job manager persisting to path. Jobs left
+// running by a previous process are marked failed as interrupted.
+func NewManager(path string) (*Manager, error) {
+	m := &Manager{
+		path:    path,
+		jobs:    map[string]*Job{},
+		cancels: map[string]context.CancelFunc{},
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, fmt.Errorf("failed to read job state: %w", err)
+	}
+	if len(data) > 0 {
+		var jobs []*Job
+		if err := json.Unmarshal(data, &jobs); err != nil {
+			return nil, fmt.Errorf("failed to parse job state: %w", err)
+		}
+		for _, job := range jobs {
+			if job.Status == StatusRunning {
+				job.Status = StatusFailed
+				job.Error = "interrupted by server restart"
+				job.CompletedAt = time.Now()
+			}
+			m.jobs[job.ID] = job
+		}
+	}
+
+	return m, nil
+}
+
+// Start launches run in the background and returns the tracking job. The
+// job's context is detached from the submitting request so the run outlives
+// the tool call.
+func (m *Manager) Start(tool string, run RunFunc) *Job {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	job := &Job{
+		ID:        fmt.Sprintf("job-%d", time.Now().UnixNano()),
+		Tool:      tool,
+		Status:    StatusRunning,
+		StartedAt: time.Now(),
+	}
+
+	m.mu.Lock()
+	m.jobs[job.ID] = job
+	m.cancels[job.ID] = cancel
+	_ = m.save()
+	m.mu.Unlock()
+
+	report := func(note string) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if job.Status == StatusRunning {
+			job.Progress = note
+		}
+	}
+
+	go func() {
+		defer cancel()
+		result, err := run(ctx, report)
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		delete(m.cancels, job.ID)
+		job.CompletedAt = time.Now()
+		switch {
+		case job.Status == StatusCancelled:
+			// Cancel already set the final state; keep it
+		case err != nil:
+			job.Status = StatusFailed
+			job.Error = err.Error()
+		default:
+			job.Status = StatusCompleted
+			job.Result = result
+		}
+		_ = m.save()
+	}()
+
+	snapshot := *job
+	return &snapshot
+}
+
+// Get returns a copy of the job with the given ID.
+func (m *Manager) Get(id string) (Job, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, found := m.jobs[id]
+	if !found {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// List returns all known jobs, newest first.
+func (m *Manager) List() []Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	jobs := make([]Job, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		jobs = append(jobs, *job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].StartedAt.After(jobs[j].StartedAt) })
+	return jobs
+}
+
+// Cancel stops a running job. The job's context is cancelled; the run's
+// partial work is not rolled back.
+func (m *Manager) Cancel(id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, found := m.jobs[id]
+	if !found {
+		return Job{}, fmt.Errorf("job '%s' not found", id)
+	}
+	if job.Status != StatusRunning {
+		return Job{}, fmt.Errorf("job '%s' is %s, not running", id, job.Status)
+	}
+
+	if cancel, ok := m.cancels[id]; ok {
+		cancel()
+		delete(m.cancels, id)
+	}
+	job.Status = StatusCancelled
+	job.CompletedAt = time.Now()
+	_ = m.save()
+
+	return *job, nil
+}
+
+// save writes the job state atomically via a temp file rename. Caller must
+// hold the mutex.
+func (m *Manager) save() error {
+	jobs := make([]*Job, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].StartedAt.Before(jobs[j].StartedAt) })
+
+	data, err := json.MarshalIndent(jobs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal job state: %w", err)
+	}
+
+	tmp := m.path + ".tmp"
+	if err := os.MkdirAll(filepath.Dir(m.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create job state directory: %w", err)
+	}
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write job state: %w", err)
+	}
+	if err := os.Rename(tmp, m.path); err != nil {
+		return fmt.Errorf("failed to replace job state file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+// Package journal persists intent records for multi-step operations
+// (add-to-target then remove-from-source). A crash between steps leaves a
+// journal entry behind, so the interrupted operation can be detected and
+// completed or rolled back on the next start instead of silently leaving
+// the library inconsistent.
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// State tracks how far a journaled operation has progressed
+type State string
+
+const (
+	// StateIntent means the operation was declared but no step has run;
+	// rollback is a no-op
+	StateIntent State = "intent"
+	// StateApplied means the additive step succeeded but the removal step
+	// has not been confirmed; recovery completes the removal
+	StateApplied State = "applied"
+)
+
+// Entry records one in-flight multi-step operation with enough context to
+// complete or roll it back
+type Entry struct {
+	ID            string    `json:"id"`
+	Operation     string    `json:"operation"` // originating tool
+	State         State     `json:"state"`
+	AssetIDs      []string  `json:"assetIds"`
+	TargetAlbumID string    `json:"targetAlbumId"`
+	SourceAlbumID string    `json:"sourceAlbumId,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// Journal persists operation entries to a JSON file
+type Journal struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]*Entry // id -> entry
+}
+
+// journalFile is the on-disk representation of the journal
+type journalFile struct {
+	Entries []*Entry `json:"entries"`
+}
+
+// New loads (or initializes) an operation journal at the given path
+func New(path string) (*Journal, error) {
+	j := &Journal{
+		path:    path,
+		entries: make(map[string]*Entry),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return j, nil
+		}
+		return nil, fmt.Errorf("failed to read operation journal: %w", err)
+	}
+
+	var file journalFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse operation journal: %w", err)
+	}
+	for _, entry := range file.Entries {
+		j.entries[entry.ID] = entry
+	}
+
+	return j, nil
+}
+
+// save writes all entries to disk; callers must hold the lock
+func (j *Journal) save() error {
+	file := journalFile{
+		Entries: make([]*Entry, 0, len(j.entries)),
+	}
+	for _, entry := range j.entries {
+		file.Entries = append(file.Entries, entry)
+	}
+	sort.Slice(file.Entries, func(i, k int) bool {
+		return file.Entries[i].CreatedAt.Before(file.Entries[k].CreatedAt)
+	})
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal operation journal: %w", err)
+	}
+
+	if dir := filepath.Dir(j.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create journal directory: %w", err)
+		}
+	}
+
+	// Write via temp file so a crash never truncates the journal
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write operation journal: %w", err)
+	}
+	return os.Rename(tmp, j.path)
+}
+
+// Begin records the intent to run a multi-step operation
+func (j *Journal) Begin(operation string, assetIDs []string, targetAlbumID, sourceAlbumID string) (*Entry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	entry := &Entry{
+		ID:            fmt.Sprintf("op-%d", now.UnixNano()),
+		Operation:     operation,
+		State:         StateIntent,
+		AssetIDs:      append([]string(nil), assetIDs...),
+		TargetAlbumID: targetAlbumID,
+		SourceAlbumID: sourceAlbumID,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	j.entries[entry.ID] = entry
+
+	if err := j.save(); err != nil {
+		return nil, err
+	}
+	copied := *entry
+	return &copied, nil
+}
+
+// MarkApplied records that the additive step succeeded for the given asset
+// IDs. Only those assets are eligible for the removal step, so recovery
+// never removes assets that were never added.
+func (j *Journal) MarkApplied(id string, appliedAssetIDs []string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry, found := j.entries[id]
+	if !found {
+		return fmt.Errorf("journal entry '%s' not found", id)
+	}
+	entry.State = StateApplied
+	entry.AssetIDs = append([]string(nil), appliedAssetIDs...)
+	entry.UpdatedAt = time.Now()
+	return j.save()
+}
+
+// Confirm records that every step completed; the entry is removed
+func (j *Journal) Confirm(id string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, found := j.entries[id]; !found {
+		return fmt.Errorf("journal entry '%s' not found", id)
+	}
+	delete(j.entries, id)
+	return j.save()
+}
+
+// Pending returns the entries of operations that never confirmed, oldest
+// first
+func (j *Journal) Pending() []Entry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entries := make([]Entry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, k int) bool {
+		return entries[i].CreatedAt.Before(entries[k].CreatedAt)
+	})
+	return entries
+}
@@ -0,0 +1,222 @@
+// Package oplog writes machine-readable operation logs: one JSONL file per
+// bulk run holding a start record, per-batch progress records, and an end
+// summary. The files give operators durable evidence of what automation did
+// after the chat transcript is gone. Logging is best-effort — a log that
+// cannot be written never fails the operation it describes.
+package oplog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record is one line of an operation log
+type Record struct {
+	Event     string                 `json:"event"` // start, progress, end
+	At        time.Time              `json:"at"`
+	Operation string                 `json:"operation,omitempty"`
+	Detail    map[string]interface{} `json:"detail,omitempty"`
+}
+
+// Writer appends records to one operation's log file. A nil Writer is valid
+// and discards everything, so callers can log unconditionally whether or not
+// operation logging is configured.
+type Writer struct {
+	mu        sync.Mutex
+	file      *os.File
+	path      string
+	operation string
+}
+
+// Open creates a new log file for one run of the named operation. File names
+// sort chronologically: <UTC timestamp>-<operation>.jsonl.
+func Open(dir, operation string) (*Writer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create operation log directory: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s.jsonl", time.Now().UTC().Format("20060102T150405.000"), sanitizeOperation(operation))
+	path := filepath.Join(dir, name)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create operation log: %w", err)
+	}
+	return &Writer{file: file, path: path, operation: operation}, nil
+}
+
+// Path is the log file's location, empty on a nil Writer
+func (w *Writer) Path() string {
+	if w == nil {
+		return ""
+	}
+	return w.path
+}
+
+// Start records the beginning of the run
+func (w *Writer) Start(detail map[string]interface{}) {
+	w.write("start", detail)
+}
+
+// Progress records one batch or step of the run
+func (w *Writer) Progress(detail map[string]interface{}) {
+	w.write("progress", detail)
+}
+
+// End records the run's final summary
+func (w *Writer) End(detail map[string]interface{}) {
+	w.write("end", detail)
+}
+
+// Close flushes and closes the log file
+func (w *Writer) Close() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file != nil {
+		_ = w.file.Close()
+		w.file = nil
+	}
+}
+
+func (w *Writer) write(event string, detail map[string]interface{}) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return
+	}
+	record := Record{
+		Event:     event,
+		At:        time.Now().UTC(),
+		Operation: w.operation,
+		Detail:    detail,
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	_, _ = w.file.Write(append(data, '\n'))
+}
+
+// Info describes one log file in the directory listing
+type Info struct {
+	Name       string    `json:"name"`
+	Operation  string    `json:"operation"`
+	SizeBytes  int64     `json:"sizeBytes"`
+	ModifiedAt time.Time `json:"modifiedAt"`
+}
+
+// List returns the directory's log files, newest first. A missing directory
+// is an empty listing, not an error.
+func List(dir string) ([]Info, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read operation log directory: %w", err)
+	}
+
+	var infos []Info
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".jsonl") {
+			continue
+		}
+		stat, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		infos = append(infos, Info{
+			Name:       entry.Name(),
+			Operation:  operationFromName(entry.Name()),
+			SizeBytes:  stat.Size(),
+			ModifiedAt: stat.ModTime(),
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name > infos[j].Name })
+	return infos, nil
+}
+
+// Read parses one log file's records. The name must be a bare file name from
+// List, never a path.
+func Read(dir, name string) ([]Record, error) {
+	if name != filepath.Base(name) || !strings.HasSuffix(name, ".jsonl") {
+		return nil, fmt.Errorf("invalid operation log name: %s", name)
+	}
+	file, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open operation log: %w", err)
+	}
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			// A torn final line from a crash mid-write is expected; skip it
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read operation log: %w", err)
+	}
+	return records, nil
+}
+
+// Prune deletes the oldest log files beyond the retention limit and reports
+// how many were removed. A non-positive limit keeps everything.
+func Prune(dir string, keep int) (int, error) {
+	if keep <= 0 {
+		return 0, nil
+	}
+	infos, err := List(dir)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, info := range infos[min(keep, len(infos)):] {
+		if err := os.Remove(filepath.Join(dir, info.Name)); err != nil {
+			return removed, fmt.Errorf("failed to prune operation log %s: %w", info.Name, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// sanitizeOperation keeps file names portable
+func sanitizeOperation(operation string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, operation)
+}
+
+// operationFromName recovers the operation from a log file name
+func operationFromName(name string) string {
+	base := strings.TrimSuffix(name, ".jsonl")
+	if i := strings.Index(base, "-"); i >= 0 && i+1 < len(base) {
+		return base[i+1:]
+	}
+	return base
+}
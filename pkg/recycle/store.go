@@ -0,0 +1,186 @@
+// Package recycle persists snapshots of the asset IDs removed by
+// destructive operations, so an accidental bulk removal can be restored
+// even after the album itself no longer lists the assets — a second safety
+// net beyond the Immich trash.
+package recycle
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultRetentionDays is how long snapshots are kept when no retention is
+// configured
+const DefaultRetentionDays = 30
+
+// Snapshot records the assets removed by one destructive operation
+type Snapshot struct {
+	ID        string    `json:"id"`
+	AlbumID   string    `json:"albumId"`
+	AlbumName string    `json:"albumName"`
+	Operation string    `json:"operation"` // tool or subsystem that removed the assets
+	AssetIDs  []string  `json:"assetIds"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Store persists recycle snapshots to a JSON file
+type Store struct {
+	path      string
+	retention time.Duration
+
+	mu        sync.Mutex
+	snapshots map[string]*Snapshot // id -> snapshot
+}
+
+// storeFile is the on-disk representation of the store
+type storeFile struct {
+	Snapshots []*Snapshot `json:"snapshots"`
+}
+
+// NewStore loads (or initializes) a recycle store at the given path.
+// Snapshots older than retentionDays are dropped on load.
+func NewStore(path string, retentionDays int) (*Store, error) {
+	if retentionDays <= 0 {
+		retentionDays = DefaultRetentionDays
+	}
+	s := &Store{
+		path:      path,
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+		snapshots: make(map[string]*Snapshot),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read recycle store: %w", err)
+	}
+
+	var file storeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse recycle store: %w", err)
+	}
+	for _, snapshot := range file.Snapshots {
+		s.snapshots[snapshot.ID] = snapshot
+	}
+
+	if s.prune(time.Now()) > 0 {
+		if err := s.save(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// prune drops expired snapshots, returning how many were removed; callers
+// must hold the lock
+func (s *Store) prune(now time.Time) int {
+	removed := 0
+	for id, snapshot := range s.snapshots {
+		if now.Sub(snapshot.CreatedAt) > s.retention {
+			delete(s.snapshots, id)
+			removed++
+		}
+	}
+	return removed
+}
+
+// save writes all snapshots to disk; callers must hold the lock
+func (s *Store) save() error {
+	file := storeFile{
+		Snapshots: make([]*Snapshot, 0, len(s.snapshots)),
+	}
+	for _, snapshot := range s.snapshots {
+		file.Snapshots = append(file.Snapshots, snapshot)
+	}
+	sort.Slice(file.Snapshots, func(i, j int) bool {
+		return file.Snapshots[i].CreatedAt.Before(file.Snapshots[j].CreatedAt)
+	})
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal recycle store: %w", err)
+	}
+
+	if dir := filepath.Dir(s.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create store directory: %w", err)
+		}
+	}
+
+	// Write via temp file so a crash never truncates the store
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write recycle store: %w", err)
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Record stores a new snapshot of removed assets, pruning expired ones
+func (s *Store) Record(albumID, albumName, operation string, assetIDs []string) (*Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.prune(now)
+
+	snapshot := &Snapshot{
+		ID:        fmt.Sprintf("rb-%d", now.UnixNano()),
+		AlbumID:   albumID,
+		AlbumName: albumName,
+		Operation: operation,
+		AssetIDs:  append([]string(nil), assetIDs...),
+		CreatedAt: now,
+	}
+	s.snapshots[snapshot.ID] = snapshot
+
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	copied := *snapshot
+	return &copied, nil
+}
+
+// Get returns a snapshot by ID
+func (s *Store) Get(id string) (*Snapshot, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot, found := s.snapshots[id]
+	if !found {
+		return nil, false
+	}
+	copied := *snapshot
+	return &copied, true
+}
+
+// List returns all snapshots, newest first
+func (s *Store) List() []Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshots := make([]Snapshot, 0, len(s.snapshots))
+	for _, snapshot := range s.snapshots {
+		snapshots = append(snapshots, *snapshot)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.After(snapshots[j].CreatedAt)
+	})
+	return snapshots
+}
+
+// Delete removes a snapshot by ID
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.snapshots[id]; !found {
+		return fmt.Errorf("recycle snapshot '%s' not found", id)
+	}
+	delete(s.snapshots, id)
+	return s.save()
+}
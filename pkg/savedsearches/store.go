@@ -0,0 +1,182 @@
+package savedsearches
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// SavedSearch is a named, persisted smart-search query that can be re-run
+// across sessions and referenced by name from other tools.
+type SavedSearch struct {
+	ID          string                   `json:"id"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description,omitempty"`
+	Query       immich.SmartSearchParams `json:"query"`
+	CreatedAt   time.Time                `json:"createdAt"`
+	UpdatedAt   time.Time                `json:"updatedAt"`
+
+	// Last run stats
+	LastRunAt      time.Time `json:"lastRunAt,omitempty"`
+	LastRunMatched int       `json:"lastRunMatched,omitempty"`
+}
+
+// Store persists saved searches to a JSON file
+type Store struct {
+	path string
+
+	mu       sync.RWMutex
+	searches map[string]*SavedSearch // id -> search
+}
+
+// NewStore loads (or initializes) a saved search store at the given path
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:     path,
+		searches: make(map[string]*SavedSearch),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read saved search store: %w", err)
+	}
+
+	var searches []*SavedSearch
+	if err := json.Unmarshal(data, &searches); err != nil {
+		return nil, fmt.Errorf("failed to parse saved search store: %w", err)
+	}
+	for _, search := range searches {
+		s.searches[search.ID] = search
+	}
+
+	return s, nil
+}
+
+// save writes all searches to disk; callers must hold the write lock
+func (s *Store) save() error {
+	searches := make([]*SavedSearch, 0, len(s.searches))
+	for _, search := range s.searches {
+		searches = append(searches, search)
+	}
+
+	data, err := json.MarshalIndent(searches, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal saved search store: %w", err)
+	}
+
+	if dir := filepath.Dir(s.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create store directory: %w", err)
+		}
+	}
+
+	// Write via temp file so a crash never truncates the store
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write saved search store: %w", err)
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Save creates a new saved search or updates the one with the same name
+func (s *Store) Save(search SavedSearch) (*SavedSearch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	// Update in place when a search with this name already exists
+	for _, existing := range s.searches {
+		if existing.Name == search.Name {
+			existing.Query = search.Query
+			existing.Description = search.Description
+			existing.UpdatedAt = now
+			if err := s.save(); err != nil {
+				return nil, err
+			}
+			copied := *existing
+			return &copied, nil
+		}
+	}
+
+	search.ID = fmt.Sprintf("ss-%d", now.UnixNano())
+	search.CreatedAt = now
+	search.UpdatedAt = now
+	s.searches[search.ID] = &search
+
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	copied := search
+	return &copied, nil
+}
+
+// Get returns a saved search by ID
+func (s *Store) Get(id string) (*SavedSearch, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	search, found := s.searches[id]
+	if !found {
+		return nil, false
+	}
+	copied := *search
+	return &copied, true
+}
+
+// GetByName returns a saved search by its name
+func (s *Store) GetByName(name string) (*SavedSearch, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, search := range s.searches {
+		if search.Name == name {
+			copied := *search
+			return &copied, true
+		}
+	}
+	return nil, false
+}
+
+// List returns all saved searches
+func (s *Store) List() []SavedSearch {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	searches := make([]SavedSearch, 0, len(s.searches))
+	for _, search := range s.searches {
+		searches = append(searches, *search)
+	}
+	return searches
+}
+
+// RecordRun updates a saved search's last-run stats
+func (s *Store) RecordRun(id string, matched int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	search, found := s.searches[id]
+	if !found {
+		return fmt.Errorf("saved search '%s' not found", id)
+	}
+	search.LastRunAt = time.Now()
+	search.LastRunMatched = matched
+	return s.save()
+}
+
+// Delete removes a saved search by ID
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.searches[id]; !found {
+		return fmt.Errorf("saved search '%s' not found", id)
+	}
+	delete(s.searches, id)
+	return s.save()
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/mcp-immich/pkg/tools"
+)
+
+// schedulerStatusFn is installed by the live-album scheduler once it starts;
+// until then the health report shows the scheduler as not running.
+var (
+	schedulerStatusMu sync.Mutex
+	schedulerStatusFn func() map[string]interface{}
+)
+
+// SetSchedulerStatusProvider installs the callback health reporting uses to
+// describe the live-album scheduler (last cycle, next run, failures).
+func SetSchedulerStatusProvider(fn func() map[string]interface{}) {
+	schedulerStatusMu.Lock()
+	schedulerStatusFn = fn
+	schedulerStatusMu.Unlock()
+}
+
+// schedulerStatus returns the current scheduler report
+func schedulerStatus() map[string]interface{} {
+	schedulerStatusMu.Lock()
+	fn := schedulerStatusFn
+	schedulerStatusMu.Unlock()
+
+	if fn == nil {
+		return map[string]interface{}{"state": "not_running"}
+	}
+	return fn()
+}
+
+// buildSelfStatus assembles the component report shared by /health, /ready
+// and the getSelfStatus tool
+func (s *Server) buildSelfStatus() map[string]interface{} {
+	status := map[string]interface{}{
+		"scheduler":  schedulerStatus(),
+		"automation": tools.AutomationStatus(),
+		"cache": map[string]interface{}{
+			"items": s.cache.ItemCount(),
+		},
+	}
+
+	storeStatus := map[string]interface{}{"accessible": true}
+	if s.smartAlbums != nil {
+		storeStatus["definitions"] = len(s.smartAlbums.List())
+		if err := s.smartAlbums.Check(); err != nil {
+			storeStatus["accessible"] = false
+			storeStatus["error"] = err.Error()
+		}
+	}
+	status["smartAlbumStore"] = storeStatus
+
+	if s.events != nil {
+		status["events"] = map[string]interface{}{
+			"subscribers": s.events.SubscriberCount(),
+		}
+	}
+
+	latency := map[string]interface{}{}
+	avg, samples := s.immich.AverageRequestDuration()
+	latency["samples"] = samples
+	if samples > 0 {
+		latency["avgMs"] = avg.Milliseconds()
+		latency["p95Ms"] = s.immich.LatencyPercentile(0.95).Milliseconds()
+	}
+	status["immichLatency"] = latency
+
+	return status
+}
+
+// writeHealthJSON writes a health payload, logging write failures
+func writeHealthJSON(w http.ResponseWriter, code int, payload map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		log.Error().Err(err).Msg("Failed to write health response")
+	}
+}
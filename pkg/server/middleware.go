@@ -56,8 +56,8 @@ func (s *Server) rateLimitMiddleware(next http.Handler) http.Handler {
 // authMiddleware applies authentication
 func (s *Server) authMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Skip auth for health/ready endpoints
-		if r.URL.Path == "/health" || r.URL.Path == "/ready" {
+		// Skip auth for health/ready/metrics endpoints
+		if r.URL.Path == "/health" || r.URL.Path == "/ready" || r.URL.Path == "/metrics" {
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -103,4 +103,4 @@ func (rw *responseWriter) Write(data []byte) (int, error) {
 		rw.WriteHeader(http.StatusOK)
 	}
 	return rw.ResponseWriter.Write(data)
-}
\ No newline at end of file
+}
@@ -2,18 +2,40 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	stdlog "log"
+	"net"
 	"net/http"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/patrickmn/go-cache"
 	"github.com/rs/zerolog/log"
+	"github.com/yourusername/mcp-immich/pkg/audit"
 	"github.com/yourusername/mcp-immich/pkg/auth"
+	"github.com/yourusername/mcp-immich/pkg/bulkops"
+	"github.com/yourusername/mcp-immich/pkg/classify"
 	"github.com/yourusername/mcp-immich/pkg/config"
+	"github.com/yourusername/mcp-immich/pkg/events"
+	"github.com/yourusername/mcp-immich/pkg/history"
+	"github.com/yourusername/mcp-immich/pkg/homeassistant"
 	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/jobs"
+	"github.com/yourusername/mcp-immich/pkg/journal"
+	"github.com/yourusername/mcp-immich/pkg/recycle"
+	"github.com/yourusername/mcp-immich/pkg/savedsearches"
+	"github.com/yourusername/mcp-immich/pkg/smartalbums"
+	"github.com/yourusername/mcp-immich/pkg/storage"
 	"github.com/yourusername/mcp-immich/pkg/tools"
+	"github.com/yourusername/mcp-immich/pkg/undo"
 	"golang.org/x/time/rate"
 )
 
@@ -27,6 +49,10 @@ type Server struct {
 	cache          *cache.Cache
 	rateLimiter    *rate.Limiter
 	authProvider   auth.Provider
+	smartAlbums    *smartalbums.Store
+	scheduler      *smartalbums.Scheduler
+	events         *events.Bus
+	homeAssistant  *homeassistant.Publisher
 }
 
 // New creates a new MCP Immich server
@@ -49,6 +75,32 @@ func New(cfg *config.Config) (*Server, error) {
 
 	// Create Immich client
 	immichClient := immich.NewClient(cfg.ImmichURL, cfg.ImmichAPIKey, cfg.ImmichTimeout)
+	immichClient.SetCategoryTimeouts(immich.CategoryTimeouts{
+		Query:    cfg.ImmichQueryTimeout,
+		Mutation: cfg.ImmichMutationTimeout,
+		Scan:     cfg.ImmichScanTimeout,
+		Download: cfg.ImmichDownloadTimeout,
+	})
+
+	immichClient.SetDownloadBandwidthLimit(cfg.DownloadBandwidthLimit)
+
+	// Record-only write mode: mutations are journaled with synthetic success
+	// instead of being sent, so agent sessions can be rehearsed safely
+	var writeRecorder *immich.WriteRecorder
+	if cfg.WriteMode == "record-only" {
+		writeRecorder = immich.NewWriteRecorder()
+		immichClient.SetWriteRecorder(writeRecorder)
+		log.Warn().Msg("Write mode is record-only: no mutating Immich calls will be executed")
+	}
+
+	if err := tools.SetExportWindow(cfg.ExportWindowStart, cfg.ExportWindowEnd); err != nil {
+		return nil, err
+	}
+	tools.SetExportMetadataEnabled(cfg.ExportEmbedMetadata)
+	tools.SetExportDir(cfg.ExportDir)
+	if err := tools.SetQuietHours(cfg.QuietHoursStart, cfg.QuietHoursEnd); err != nil {
+		return nil, err
+	}
 
 	// Create cache
 	cacheStore := cache.New(cfg.CacheTTL, cfg.CacheTTL*2)
@@ -62,17 +114,227 @@ func New(cfg *config.Config) (*Server, error) {
 		return nil, fmt.Errorf("failed to create auth provider: %w", err)
 	}
 
+	// Optional persisted tool-call history for session continuity
+	var historyStore *history.Store
+	serverOpts := []server.ServerOption{}
+	if cfg.ToolHistoryPath != "" {
+		historyStore, err = history.NewStore(cfg.ToolHistoryPath, cfg.ToolHistorySize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open tool history store: %w", err)
+		}
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(tools.HistoryMiddleware(historyStore)))
+	}
+
+	// Audit log of mutating tool calls (opt-in via audit_log_path)
+	if cfg.AuditLogPath != "" {
+		auditLog, err := audit.Open(cfg.AuditLogPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open audit log: %w", err)
+		}
+		tools.SetAuditLog(auditLog)
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(tools.AuditMiddleware()))
+	}
+
+	// Read-only mode rejects every destructive tool with a policy error, so
+	// the server can be exposed to untrusted agents
+	if cfg.ReadOnly {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(tools.ReadOnlyMiddleware()))
+		log.Warn().Msg("Server is read-only: destructive tools will refuse to run")
+	}
+
+	// Forced dry-run rewrites destructive calls into their dry-run form; the
+	// middleware is always installed since the X-Dry-Run header can force it
+	// per request even when the config flag is off
+	tools.SetForceDryRun(cfg.ForceDryRun)
+	serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(tools.DryRunMiddleware()))
+	if cfg.ForceDryRun {
+		log.Warn().Msg("Dry-run mode is forced: destructive tools will only report what they would do")
+	}
+
+	// Automation events feed the SSE /events stream; the middleware covers
+	// every tool call, individual tools add richer domain events
+	eventBus := events.NewBus()
+	tools.SetEventBus(eventBus)
+	serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(tools.EventsMiddleware()))
+
+	// Parameter profiles fill in preset defaults for tool calls
+	tools.SetParameterProfiles(cfg.ParameterProfiles, cfg.DefaultProfile)
+	if len(cfg.ParameterProfiles) > 0 {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(tools.ProfileMiddleware()))
+	}
+
 	// Create MCP server
 	mcpServer := server.NewMCPServer(
 		"mcp-immich",
 		"1.0.0",
+		serverOpts...,
 	)
 
+	// Apply the configured result verbosity profile and sample format before
+	// registering tools
+	tools.SetDefaultVerbosity(cfg.ResultVerbosity)
+	tools.SetSampleFormat(cfg.SampleFormat)
+	tools.SetStrictTools(cfg.StrictTools)
+
 	// Register all tools
 	tools.RegisterTools(mcpServer, immichClient, cacheStore)
 
-	// Create StreamableHTTP server
-	streamableHTTP := server.NewStreamableHTTPServer(mcpServer)
+	// Register the asset resource template backing search-result resource links
+	tools.RegisterAssetResources(mcpServer, immichClient)
+	tools.RegisterLibraryResources(mcpServer, immichClient)
+
+	// Expose the tool-call history as a resource when enabled
+	if historyStore != nil {
+		tools.RegisterHistoryResource(mcpServer, historyStore)
+	}
+
+	// Register smart album tools backed by the persistent definition store
+	storePath := cfg.SmartAlbumStorePath
+	if storePath == "" {
+		storePath = "smart_albums.json"
+	}
+	var smartAlbumStore *smartalbums.Store
+	if cfg.SmartAlbumStoreBackend == "sqlite" {
+		// The database lives next to the configured store path; an existing
+		// JSON store is migrated in on first start
+		dbPath := strings.TrimSuffix(storePath, filepath.Ext(storePath)) + ".db"
+		smartAlbumStore, err = smartalbums.NewSQLiteStore(dbPath, storePath)
+		storePath = dbPath
+	} else {
+		smartAlbumStore, err = smartalbums.NewStore(storePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open smart album store: %w", err)
+	}
+
+	// Register saved search tools backed by their own persistent store
+	searchStorePath := cfg.SavedSearchStorePath
+	if searchStorePath == "" {
+		searchStorePath = "saved_searches.json"
+	}
+	savedSearchStore, err := savedsearches.NewStore(searchStorePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open saved search store: %w", err)
+	}
+	tools.RegisterSmartAlbumTools(mcpServer, immichClient, smartAlbumStore, savedSearchStore)
+	tools.RegisterSavedSearchTools(mcpServer, immichClient, savedSearchStore)
+
+	// Register recycle snapshot tools; destructive tools snapshot removed
+	// asset IDs into this store before deleting
+	recyclePath := cfg.RecycleStorePath
+	if recyclePath == "" {
+		recyclePath = "recycle_snapshots.json"
+	}
+	recycleStore, err := recycle.NewStore(recyclePath, cfg.RecycleRetentionDays)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recycle store: %w", err)
+	}
+	tools.RegisterRecycleTools(mcpServer, immichClient, recycleStore)
+
+	// Load the album lock registry; mutating tools refuse to touch albums
+	// that are locked in it
+	lockPath := cfg.AlbumLockStorePath
+	if lockPath == "" {
+		lockPath = "album_locks.json"
+	}
+	if err := tools.InitAlbumLocks(lockPath); err != nil {
+		return nil, fmt.Errorf("failed to load album locks: %w", err)
+	}
+	tools.RegisterAlbumLockTools(mcpServer, immichClient)
+
+	// Structured operation logs for bulk runs (opt-in via operation_log_dir)
+	if cfg.OperationLogDir != "" {
+		tools.InitOperationLogs(cfg.OperationLogDir, cfg.OperationLogRetention)
+	}
+	tools.RegisterOperationLogTools(mcpServer)
+
+	// Background job manager for async bulk runs
+	jobStatePath := cfg.JobStatePath
+	if jobStatePath == "" {
+		jobStatePath = "jobs.json"
+	}
+	jobManager, err := jobs.NewManager(jobStatePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open job state: %w", err)
+	}
+	tools.SetJobManager(jobManager)
+	tools.RegisterJobTools(mcpServer)
+	tools.RegisterAuditTools(mcpServer)
+
+	// Undo journal for rolling back reversible mutations
+	undoPath := cfg.UndoJournalPath
+	if undoPath == "" {
+		undoPath = "undo_journal.json"
+	}
+	undoJournal, err := undo.New(undoPath, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open undo journal: %w", err)
+	}
+	tools.SetUndoJournal(undoJournal)
+	tools.RegisterUndoTools(mcpServer, immichClient)
+
+	// Open the operation journal and resolve anything interrupted mid-flight
+	journalPath := cfg.OperationJournalPath
+	if journalPath == "" {
+		journalPath = "operation_journal.json"
+	}
+	opJournal, err := journal.New(journalPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open operation journal: %w", err)
+	}
+	if len(opJournal.Pending()) > 0 {
+		recoverCtx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		completed, rolledBack, recoverErrs := bulkops.RecoverJournal(recoverCtx, immichClient, opJournal)
+		cancel()
+		log.Info().
+			Int("completed", completed).
+			Int("rolledBack", rolledBack).
+			Strs("errors", recoverErrs).
+			Msg("Recovered interrupted multi-step operations from journal")
+	}
+	tools.SetOperationJournal(opJournal)
+
+	// Register backup/restore tools over every persistent state file via the
+	// storage backend's namespaces
+	stateBackend := storage.NewFileBackend()
+	stateBackend.Register("smart_albums", storePath)
+	stateBackend.Register("saved_searches", searchStorePath)
+	stateBackend.Register("recycle_snapshots", recyclePath)
+	stateBackend.Register("album_locks", lockPath)
+	stateBackend.Register("operation_journal", journalPath)
+	stateBackend.Register("tool_history", cfg.ToolHistoryPath)
+	stateBackend.Register("background_jobs", jobStatePath)
+	stateBackend.Register("undo_journal", undoPath)
+	tools.RegisterStateTools(mcpServer, stateBackend)
+	if writeRecorder != nil {
+		tools.RegisterRecordedWritesTool(mcpServer, writeRecorder)
+	}
+
+	// Register classifier-backed tools when an external classifier is configured
+	if cfg.ClassifierURL != "" {
+		classifier := classify.New(cfg.ClassifierURL, cfg.ClassifierAPIKey, cfg.ClassifierTimeout)
+		tools.RegisterClassifierTools(mcpServer, immichClient, classifier)
+	}
+
+	// Create StreamableHTTP server; the profile header selects which
+	// parameter preset applies to the client's tool calls
+	streamableOpts := []server.StreamableHTTPOption{
+		server.WithHTTPContextFunc(func(ctx context.Context, r *http.Request) context.Context {
+			if profile := r.Header.Get("X-MCP-Profile"); profile != "" {
+				ctx = tools.WithProfile(ctx, profile)
+			}
+			if dry := r.Header.Get("X-Dry-Run"); dry == "1" || strings.EqualFold(dry, "true") {
+				ctx = tools.WithForcedDryRun(ctx)
+			}
+			return ctx
+		}),
+	}
+	// Heartbeat pings keep hour-long tool calls from being cut by idle
+	// timeouts in reverse proxies between the client and this server
+	if cfg.HeartbeatInterval > 0 {
+		streamableOpts = append(streamableOpts, server.WithHeartbeatInterval(cfg.HeartbeatInterval))
+	}
+	streamableHTTP := server.NewStreamableHTTPServer(mcpServer, streamableOpts...)
 	stdioServer := server.NewStdioServer(mcpServer)
 
 	s := &Server{
@@ -84,6 +346,29 @@ func New(cfg *config.Config) (*Server, error) {
 		cache:          cacheStore,
 		rateLimiter:    rateLimiter,
 		authProvider:   authProvider,
+		smartAlbums:    smartAlbumStore,
+		events:         eventBus,
+	}
+
+	// The self-status tool mirrors the /health component report
+	tools.RegisterSelfStatusTool(mcpServer, s.buildSelfStatus)
+
+	// Global pause/resume over background automation
+	tools.RegisterAutomationControls(mcpServer)
+
+	// Background scheduler that keeps smart albums refreshed; started with
+	// the transport in Start. It reports into /health via the status
+	// provider and honors the automation pause switch and quiet hours.
+	s.scheduler = smartalbums.NewScheduler(smartAlbumStore, cfg.SmartAlbumRefreshInterval, tools.CheckAutomationAllowed,
+		func(ctx context.Context, def *smartalbums.SmartAlbumDefinition) error {
+			return tools.RefreshSmartAlbumDefinition(ctx, immichClient, smartAlbumStore, def)
+		})
+	SetSchedulerStatusProvider(s.scheduler.Status)
+	tools.RegisterSchedulerTools(mcpServer, s.scheduler)
+
+	// Bridge into Home Assistant when configured
+	if cfg.HomeAssistant != nil {
+		s.homeAssistant = homeassistant.New(cfg.HomeAssistant)
 	}
 
 	return s, nil
@@ -91,6 +376,14 @@ func New(cfg *config.Config) (*Server, error) {
 
 // Start starts the server with the requested transport
 func (s *Server) Start(ctx context.Context, transportMode string) error {
+	if s.homeAssistant != nil {
+		go s.homeAssistant.ForwardEvents(ctx, s.events)
+		go s.homeAssistant.RunStatsLoop(ctx, s.immich)
+	}
+	if s.scheduler != nil {
+		go s.scheduler.Run(ctx)
+	}
+
 	switch transportMode {
 	case "http":
 		return s.startHTTP(ctx)
@@ -114,6 +407,18 @@ func (s *Server) startHTTP(ctx context.Context) error {
 	// Ready check
 	mux.HandleFunc("/ready", s.handleReady)
 
+	// Capability report: implemented vs planned tools
+	mux.HandleFunc("/capabilities", s.handleCapabilities)
+
+	// SSE stream of automation events for dashboards
+	mux.HandleFunc("/events", s.handleEvents)
+
+	// Prometheus-style scheduler counters
+	mux.HandleFunc("/metrics", s.handleMetrics)
+
+	// Inbound trigger for Home Assistant automations
+	mux.HandleFunc("/ha/refresh-smart-album", s.handleHARefreshSmartAlbum)
+
 	// Apply middleware
 	handler := s.authMiddleware(
 		s.rateLimitMiddleware(
@@ -121,23 +426,50 @@ func (s *Server) startHTTP(ctx context.Context) error {
 		),
 	)
 
+	idleTimeout := s.config.SessionIdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = 60 * time.Second
+	}
+	// With heartbeats enabled the MCP endpoint streams for the lifetime of a
+	// tool call, so the per-response write deadline must not apply; guarding
+	// against stalled clients falls to the idle timeout instead
+	writeTimeout := s.config.RequestTimeout
+	if s.config.HeartbeatInterval > 0 {
+		writeTimeout = 0
+	}
 	httpServer := &http.Server{
 		Addr:         s.config.ListenAddr,
 		Handler:      handler,
 		ReadTimeout:  15 * time.Second,
-		WriteTimeout: s.config.RequestTimeout,
-		IdleTimeout:  60 * time.Second,
+		WriteTimeout: writeTimeout,
+		IdleTimeout:  idleTimeout,
 	}
 
-	log.Info().Str("addr", s.config.ListenAddr).Msg("Starting StreamableHTTP server")
-
 	// Start server in goroutine
 	errChan := make(chan error, 1)
-	go func() {
-		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			errChan <- err
+	if s.config.ListenSocket != "" {
+		// Serve over a Unix domain socket instead of TCP; access control is
+		// delegated to the socket's file permissions
+		listener, err := s.listenUnixSocket()
+		if err != nil {
+			return err
 		}
-	}()
+		defer os.Remove(s.config.ListenSocket)
+
+		log.Info().Str("socket", s.config.ListenSocket).Msg("Starting StreamableHTTP server on Unix socket")
+		go func() {
+			if err := httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+				errChan <- err
+			}
+		}()
+	} else {
+		log.Info().Str("addr", s.config.ListenAddr).Msg("Starting StreamableHTTP server")
+		go func() {
+			if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				errChan <- err
+			}
+		}()
+	}
 
 	// Wait for context or error
 	select {
@@ -151,19 +483,97 @@ func (s *Server) startHTTP(ctx context.Context) error {
 	}
 }
 
-// startStdio starts the server using stdio transport
+// listenUnixSocket creates the Unix domain socket listener, replacing any
+// stale socket file and applying the configured file mode.
+func (s *Server) listenUnixSocket() (net.Listener, error) {
+	path := s.config.ListenSocket
+
+	// Remove a stale socket left behind by a previous run
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on socket %s: %w", path, err)
+	}
+
+	mode := os.FileMode(0o660)
+	if s.config.ListenSocketMode != "" {
+		parsed, err := strconv.ParseUint(s.config.ListenSocketMode, 8, 32)
+		if err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("invalid listen_socket_mode %q: %w", s.config.ListenSocketMode, err)
+		}
+		mode = os.FileMode(parsed)
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+
+	return listener, nil
+}
+
+// startStdio starts the server using stdio transport, for clients that spawn
+// the binary directly (e.g. desktop MCP hosts). The protocol owns stdout, so
+// the transport's internal errors are routed through zerolog on stderr.
 func (s *Server) startStdio(ctx context.Context) error {
 	log.Info().Msg("Starting stdio server")
-	return s.stdioServer.Listen(ctx, os.Stdin, os.Stdout)
+
+	s.stdioServer.SetErrorLogger(stdlog.New(log.Logger.With().Str("component", "stdio").Logger(), "", 0))
+
+	// The client hanging up (EOF on stdin) and a signal-driven context
+	// cancellation are both graceful exits, not failures
+	err := s.stdioServer.Listen(ctx, os.Stdin, os.Stdout)
+	if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	log.Info().Msg("Shutting down stdio server")
+	return nil
 }
 
-// handleHealth handles health check requests
-func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	if _, err := w.Write([]byte(`{"status":"healthy"}`)); err != nil {
-		log.Error().Err(err).Msg("Failed to write health response")
+// handleHARefreshSmartAlbum lets Home Assistant automations trigger a smart
+// album refresh with a plain POST, without speaking MCP. It sits behind the
+// same auth middleware as every other endpoint.
+func (s *Server) handleHARefreshSmartAlbum(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var payload struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.Name == "" {
+		writeHealthJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "body must be JSON with a non-empty 'name'",
+		})
+		return
+	}
+
+	if err := tools.RefreshSmartAlbumByName(r.Context(), s.immich, s.smartAlbums, payload.Name); err != nil {
+		writeHealthJSON(w, http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
 	}
+
+	writeHealthJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"name":    payload.Name,
+	})
+}
+
+// handleHealth handles health check requests, including component status
+// suitable for uptime monitors
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	payload := map[string]interface{}{"status": "healthy"}
+	payload["components"] = s.buildSelfStatus()
+	writeHealthJSON(w, http.StatusOK, payload)
 }
 
 // handleReady handles readiness check requests
@@ -173,18 +583,59 @@ func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	if err := s.immich.Ping(ctx); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		if _, err := w.Write([]byte(`{"status":"not_ready","reason":"immich_unavailable"}`)); err != nil {
-			log.Error().Err(err).Msg("Failed to write ready error response")
-		}
+		writeHealthJSON(w, http.StatusServiceUnavailable, map[string]interface{}{
+			"status":     "not_ready",
+			"reason":     "immich_unavailable",
+			"components": s.buildSelfStatus(),
+		})
 		return
 	}
 
+	writeHealthJSON(w, http.StatusOK, map[string]interface{}{
+		"status":     "ready",
+		"components": s.buildSelfStatus(),
+	})
+}
+
+// handleMetrics exposes the scheduler's counters in the Prometheus text
+// format. The server has too few metrics to warrant a client library.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	var refreshed, failures, assetsAdded int64
+	if s.scheduler != nil {
+		refreshed, failures, assetsAdded = s.scheduler.Counters()
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintf(w, "# HELP immich_mcp_smart_albums_updated_total Smart albums refreshed by the background scheduler.\n")
+	fmt.Fprintf(w, "# TYPE immich_mcp_smart_albums_updated_total counter\n")
+	fmt.Fprintf(w, "immich_mcp_smart_albums_updated_total %d\n", refreshed)
+	fmt.Fprintf(w, "# HELP immich_mcp_smart_album_refresh_failures_total Scheduled smart album refreshes that failed.\n")
+	fmt.Fprintf(w, "# TYPE immich_mcp_smart_album_refresh_failures_total counter\n")
+	fmt.Fprintf(w, "immich_mcp_smart_album_refresh_failures_total %d\n", failures)
+	fmt.Fprintf(w, "# HELP immich_mcp_smart_album_assets_added_total Assets added to albums by scheduled refreshes.\n")
+	fmt.Fprintf(w, "# TYPE immich_mcp_smart_album_assets_added_total counter\n")
+	fmt.Fprintf(w, "immich_mcp_smart_album_assets_added_total %d\n", assetsAdded)
+}
+
+// handleCapabilities reports which tools are implemented and which are only
+// planned, so clients can avoid calling tools that don't work yet.
+func (s *Server) handleCapabilities(w http.ResponseWriter, r *http.Request) {
+	implemented := make([]string, 0)
+	for name := range s.mcpServer.ListTools() {
+		implemented = append(implemented, name)
+	}
+	sort.Strings(implemented)
+
+	planned := tools.PlannedTools()
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if _, err := w.Write([]byte(`{"status":"ready"}`)); err != nil {
-		log.Error().Err(err).Msg("Failed to write ready response")
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"implemented": implemented,
+		"planned":     planned,
+		"strictMode":  s.config.StrictTools,
+	}); err != nil {
+		log.Error().Err(err).Msg("Failed to write capabilities response")
 	}
 }
 
@@ -0,0 +1,139 @@
+package smartalbums
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed five-field cron expression (minute, hour,
+// day-of-month, month, day-of-week). The scheduler only ever asks whether the
+// current minute matches, so the type carries per-field bitmasks rather than
+// a next-fire computation.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+// cronField describes one field's valid range.
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day-of-month", 1, 31},
+	{"month", 1, 12},
+	{"day-of-week", 0, 7}, // 7 is accepted as an alias for Sunday
+}
+
+// ParseCron parses a standard five-field cron expression supporting "*",
+// lists, ranges and steps (e.g. "0 3 * * *", "*/15 9-17 * * 1-5"). Day 7
+// is accepted as an alias for Sunday.
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression must have 5 fields (minute hour day-of-month month day-of-week), got %d", len(fields))
+	}
+
+	masks := make([]uint64, 5)
+	stars := make([]bool, 5)
+	for i, field := range fields {
+		mask, star, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		masks[i] = mask
+		stars[i] = star
+	}
+
+	return &CronSchedule{
+		minute:  masks[0],
+		hour:    masks[1],
+		dom:     masks[2],
+		month:   masks[3],
+		dow:     masks[4],
+		domStar: stars[2],
+		dowStar: stars[4],
+	}, nil
+}
+
+// parseCronField builds the bitmask for one field from its comma-separated
+// parts, each "*", "a", "a-b", optionally with a "/step" suffix.
+func parseCronField(field string, spec cronField) (uint64, bool, error) {
+	var mask uint64
+	star := false
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if slash := strings.IndexByte(part, '/'); slash >= 0 {
+			parsed, err := strconv.Atoi(part[slash+1:])
+			if err != nil || parsed <= 0 {
+				return 0, false, fmt.Errorf("invalid step in %s field: %s", spec.name, part)
+			}
+			step = parsed
+			part = part[:slash]
+		}
+
+		lo, hi := spec.min, spec.max
+		switch {
+		case part == "*":
+			if step == 1 {
+				star = true
+			}
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(bounds[0])
+			hi, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return 0, false, fmt.Errorf("invalid range in %s field: %s", spec.name, part)
+			}
+		default:
+			value, err := strconv.Atoi(part)
+			if err != nil {
+				return 0, false, fmt.Errorf("invalid value in %s field: %s", spec.name, part)
+			}
+			lo, hi = value, value
+		}
+
+		if lo < spec.min || hi > spec.max || lo > hi {
+			return 0, false, fmt.Errorf("%s field out of range %d-%d: %s", spec.name, spec.min, spec.max, part)
+		}
+		for v := lo; v <= hi; v += step {
+			bit := v
+			// Day-of-week 7 is Sunday
+			if spec.name == "day-of-week" && bit == 7 {
+				bit = 0
+			}
+			mask |= 1 << uint(bit)
+		}
+	}
+
+	return mask, star, nil
+}
+
+// Matches reports whether the schedule fires during t's minute. Following
+// standard cron semantics, a restricted day-of-month and day-of-week match
+// when either one does.
+func (c *CronSchedule) Matches(t time.Time) bool {
+	if c.minute&(1<<uint(t.Minute())) == 0 {
+		return false
+	}
+	if c.hour&(1<<uint(t.Hour())) == 0 {
+		return false
+	}
+	if c.month&(1<<uint(int(t.Month()))) == 0 {
+		return false
+	}
+
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(int(t.Weekday()))) != 0
+	if !c.domStar && !c.dowStar {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
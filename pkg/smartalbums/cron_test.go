@@ -0,0 +1,81 @@
+package smartalbums
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronMatches(t *testing.T) {
+	// 2026-11-01 is a Sunday, 2026-11-13 a Friday, 2026-08-13 a Thursday
+	cases := []struct {
+		name  string
+		expr  string
+		at    time.Time
+		match bool
+	}{
+		{"daily at 3, on the minute", "0 3 * * *", time.Date(2026, 3, 4, 3, 0, 0, 0, time.UTC), true},
+		{"daily at 3, wrong minute", "0 3 * * *", time.Date(2026, 3, 4, 3, 1, 0, 0, time.UTC), false},
+		{"daily at 3, wrong hour", "0 3 * * *", time.Date(2026, 3, 4, 4, 0, 0, 0, time.UTC), false},
+		{"step matches on the quarter hour", "*/15 * * * *", time.Date(2026, 3, 4, 9, 45, 0, 0, time.UTC), true},
+		{"step misses off the quarter hour", "*/15 * * * *", time.Date(2026, 3, 4, 9, 50, 0, 0, time.UTC), false},
+		{"hour range with weekday range, weekday", "30 9-17 * * 1-5", time.Date(2026, 11, 11, 10, 30, 0, 0, time.UTC), true},
+		{"hour range with weekday range, weekend", "30 9-17 * * 1-5", time.Date(2026, 11, 14, 10, 30, 0, 0, time.UTC), false},
+		{"hour range with weekday range, outside hours", "30 9-17 * * 1-5", time.Date(2026, 11, 11, 18, 30, 0, 0, time.UTC), false},
+		{"hour list matches", "0 6,18 * * *", time.Date(2026, 3, 4, 18, 0, 0, 0, time.UTC), true},
+		{"hour list misses", "0 6,18 * * *", time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC), false},
+		{"first of month", "0 0 1 * *", time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), true},
+		{"first of month, second day", "0 0 1 * *", time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC), false},
+		{"month restriction matches", "0 0 * 12 *", time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC), true},
+		{"month restriction misses", "0 0 * 12 *", time.Date(2026, 11, 25, 0, 0, 0, 0, time.UTC), false},
+		// Standard cron: restricted dom and dow match when either does
+		{"dom/dow OR, both match", "0 12 13 * 5", time.Date(2026, 11, 13, 12, 0, 0, 0, time.UTC), true},
+		{"dom/dow OR, only weekday matches", "0 12 13 * 5", time.Date(2026, 11, 6, 12, 0, 0, 0, time.UTC), true},
+		{"dom/dow OR, only day matches", "0 12 13 * 5", time.Date(2026, 8, 13, 12, 0, 0, 0, time.UTC), true},
+		{"dom/dow OR, neither matches", "0 12 13 * 5", time.Date(2026, 11, 4, 12, 0, 0, 0, time.UTC), false},
+		{"dow 0 is Sunday", "0 0 * * 0", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), true},
+		{"dow 7 is Sunday too", "0 0 * * 7", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), true},
+		{"dow range ending at 7 covers Sunday", "0 0 * * 5-7", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), true},
+		{"dow range ending at 7 excludes Thursday", "0 0 * * 5-7", time.Date(2026, 11, 5, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := ParseCron(tc.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) failed: %v", tc.expr, err)
+			}
+			if got := schedule.Matches(tc.at); got != tc.match {
+				t.Errorf("ParseCron(%q).Matches(%s) = %v, want %v", tc.expr, tc.at, got, tc.match)
+			}
+		})
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	cases := []struct {
+		name string
+		expr string
+	}{
+		{"empty", ""},
+		{"four fields", "* * * *"},
+		{"six fields", "* * * * * *"},
+		{"minute out of range", "60 * * * *"},
+		{"hour out of range", "* 24 * * *"},
+		{"day-of-month zero", "* * 0 * *"},
+		{"month out of range", "* * * 13 *"},
+		{"day-of-week out of range", "* * * * 8"},
+		{"zero step", "*/0 * * * *"},
+		{"negative step", "*/-5 * * * *"},
+		{"inverted range", "5-1 * * * *"},
+		{"non-numeric", "a * * * *"},
+		{"malformed range", "1-b * * * *"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ParseCron(tc.expr); err == nil {
+				t.Errorf("ParseCron(%q) succeeded, want error", tc.expr)
+			}
+		})
+	}
+}
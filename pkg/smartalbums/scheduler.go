@@ -0,0 +1,245 @@
+package smartalbums
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// schedulerTick is how often the scheduler checks whether any definition is
+// due for a refresh; actual refresh cadence comes from the intervals.
+const schedulerTick = time.Minute
+
+// maxCycleHistory caps the in-memory record of past refresh cycles.
+const maxCycleHistory = 50
+
+// CycleAlbumResult is what one scheduled refresh did to one definition.
+type CycleAlbumResult struct {
+	Definition string `json:"definition"`
+	Added      int    `json:"added"`
+	Removed    int    `json:"removed,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// CycleRecord is the telemetry of one scheduler cycle that attempted at
+// least one refresh; idle cycles are not recorded.
+type CycleRecord struct {
+	StartedAt  time.Time          `json:"startedAt"`
+	DurationMs int64              `json:"durationMs"`
+	Refreshed  int                `json:"refreshed"`
+	Failed     int                `json:"failed"`
+	Albums     []CycleAlbumResult `json:"albums"`
+}
+
+// RefreshFunc refreshes one stored definition; the scheduler stays decoupled
+// from the tool layer that implements the refresh.
+type RefreshFunc func(ctx context.Context, def *SmartAlbumDefinition) error
+
+// GateFunc reports whether background automation may run right now; a non-nil
+// error skips the cycle with the error as the reason.
+type GateFunc func(now time.Time) error
+
+// Scheduler refreshes every stored smart album definition in the background.
+// Each definition runs on its own RefreshCron expression when set, otherwise
+// at its RefreshIntervalMinutes, falling back to the scheduler's global
+// default; a zero effective interval leaves the definition manual-only.
+type Scheduler struct {
+	store           *Store
+	defaultInterval time.Duration
+	refresh         RefreshFunc
+	gate            GateFunc
+
+	mu          sync.Mutex
+	lastAttempt map[string]time.Time // definition ID -> last refresh attempt
+	lastCycle   time.Time
+	lastSkip    string // why the last cycle was skipped, if it was
+	refreshed   int    // total successful refreshes
+	failures    int    // total failed refreshes
+	assetsAdded int64  // total assets added by scheduled refreshes
+	lastError   string
+	cycles      []CycleRecord // most recent non-idle cycles, oldest first
+}
+
+// NewScheduler builds a scheduler over the store. defaultInterval applies to
+// definitions without their own interval; zero disables those definitions.
+func NewScheduler(store *Store, defaultInterval time.Duration, gate GateFunc, refresh RefreshFunc) *Scheduler {
+	return &Scheduler{
+		store:           store,
+		defaultInterval: defaultInterval,
+		refresh:         refresh,
+		gate:            gate,
+		lastAttempt:     make(map[string]time.Time),
+	}
+}
+
+// Run drives the refresh loop until the context is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	log.Info().
+		Dur("defaultInterval", s.defaultInterval).
+		Msg("Smart album scheduler started")
+
+	ticker := time.NewTicker(schedulerTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("Smart album scheduler stopped")
+			return
+		case <-ticker.C:
+			s.runCycle(ctx)
+		}
+	}
+}
+
+// runCycle refreshes every definition that is due.
+func (s *Scheduler) runCycle(ctx context.Context) {
+	now := time.Now()
+
+	if s.gate != nil {
+		if err := s.gate(now); err != nil {
+			s.mu.Lock()
+			s.lastCycle = now
+			s.lastSkip = err.Error()
+			s.mu.Unlock()
+			return
+		}
+	}
+
+	s.mu.Lock()
+	s.lastCycle = now
+	s.lastSkip = ""
+	s.mu.Unlock()
+
+	var albums []CycleAlbumResult
+	cycleRefreshed, cycleFailed := 0, 0
+
+	for _, def := range s.store.List() {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		s.mu.Lock()
+		last, seen := s.lastAttempt[def.ID]
+		s.mu.Unlock()
+		if !seen {
+			// First sight of this definition: seed from its stored run
+			// stats so a restart doesn't trigger an immediate refresh
+			last = def.LastRunAt
+		}
+
+		if def.RefreshCron != "" {
+			schedule, err := ParseCron(def.RefreshCron)
+			if err != nil {
+				log.Warn().Err(err).Str("definition", def.Name).Msg("Invalid refresh cron expression; definition skipped")
+				continue
+			}
+			// Guard against double-firing when a cycle lands twice in the
+			// same matching minute
+			if !schedule.Matches(now) || now.Sub(last) < schedulerTick {
+				continue
+			}
+		} else {
+			interval := s.defaultInterval
+			if def.RefreshIntervalMinutes > 0 {
+				interval = time.Duration(def.RefreshIntervalMinutes) * time.Minute
+			}
+			if interval <= 0 || now.Sub(last) < interval {
+				continue
+			}
+		}
+
+		s.mu.Lock()
+		s.lastAttempt[def.ID] = now
+		s.mu.Unlock()
+
+		copied := def
+		if err := s.refresh(ctx, &copied); err != nil {
+			log.Warn().Err(err).Str("definition", def.Name).Msg("Scheduled smart album refresh failed")
+			cycleFailed++
+			albums = append(albums, CycleAlbumResult{Definition: def.Name, Error: err.Error()})
+			s.mu.Lock()
+			s.failures++
+			s.lastError = err.Error()
+			s.mu.Unlock()
+			continue
+		}
+		log.Debug().Str("definition", def.Name).Msg("Scheduled smart album refresh completed")
+		cycleRefreshed++
+		// The refresh wrote its stats back onto the copy it was handed
+		albums = append(albums, CycleAlbumResult{
+			Definition: def.Name,
+			Added:      copied.LastRunAdded,
+			Removed:    copied.LastRunRemoved,
+		})
+		s.mu.Lock()
+		s.refreshed++
+		s.assetsAdded += int64(copied.LastRunAdded)
+		s.mu.Unlock()
+	}
+
+	// Idle cycles (nothing due) are not worth a record
+	if len(albums) > 0 {
+		s.mu.Lock()
+		s.cycles = append(s.cycles, CycleRecord{
+			StartedAt:  now,
+			DurationMs: time.Since(now).Milliseconds(),
+			Refreshed:  cycleRefreshed,
+			Failed:     cycleFailed,
+			Albums:     albums,
+		})
+		if len(s.cycles) > maxCycleHistory {
+			s.cycles = s.cycles[len(s.cycles)-maxCycleHistory:]
+		}
+		s.mu.Unlock()
+	}
+}
+
+// Cycles returns up to limit recorded refresh cycles, newest first.
+func (s *Scheduler) Cycles(limit int) []CycleRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cycles := make([]CycleRecord, 0, len(s.cycles))
+	for i := len(s.cycles) - 1; i >= 0; i-- {
+		cycles = append(cycles, s.cycles[i])
+		if limit > 0 && len(cycles) >= limit {
+			break
+		}
+	}
+	return cycles
+}
+
+// Counters returns the scheduler's monotonic totals for metrics exposition.
+func (s *Scheduler) Counters() (refreshed, failures, assetsAdded int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return int64(s.refreshed), int64(s.failures), s.assetsAdded
+}
+
+// Status reports the scheduler state for health reporting.
+func (s *Scheduler) Status() map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := map[string]interface{}{
+		"state":           "running",
+		"defaultInterval": s.defaultInterval.String(),
+		"refreshed":       s.refreshed,
+		"failures":        s.failures,
+	}
+	if !s.lastCycle.IsZero() {
+		status["lastCycle"] = s.lastCycle.Format(time.RFC3339)
+	}
+	if s.lastSkip != "" {
+		status["lastCycleSkipped"] = s.lastSkip
+	}
+	if s.lastError != "" {
+		status["lastError"] = s.lastError
+	}
+	return status
+}
@@ -0,0 +1,326 @@
+package smartalbums
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	// Pure-Go SQLite driver; registers itself as "sqlite"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchema creates the store's tables. Definitions are stored as JSON
+// documents so the columns never lag behind SmartAlbumDefinition; pins and
+// tags get row-per-entry tables, and runs is the per-refresh history that
+// would not scale inside a rewritten-on-every-save JSON file.
+var sqliteSchema = []string{
+	`CREATE TABLE IF NOT EXISTS meta (
+		key   TEXT PRIMARY KEY,
+		value TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS definitions (
+		id   TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		data TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS pins (
+		album_id TEXT    NOT NULL,
+		asset_id TEXT    NOT NULL,
+		pos      INTEGER NOT NULL,
+		PRIMARY KEY (album_id, asset_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS album_tags (
+		album_id TEXT    NOT NULL,
+		tag      TEXT    NOT NULL,
+		pos      INTEGER NOT NULL,
+		PRIMARY KEY (album_id, tag)
+	)`,
+	`CREATE TABLE IF NOT EXISTS runs (
+		id            INTEGER PRIMARY KEY AUTOINCREMENT,
+		definition_id TEXT    NOT NULL,
+		started_at    TEXT    NOT NULL,
+		finished_at   TEXT,
+		matched       INTEGER NOT NULL DEFAULT 0,
+		added         INTEGER NOT NULL DEFAULT 0,
+		error         TEXT
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_runs_definition ON runs (definition_id, id)`,
+}
+
+// NewSQLiteStore opens a smart album store backed by a SQLite database at
+// dbPath. On first open, if a legacy JSON store exists at jsonPath its
+// contents are migrated in and the JSON file is renamed aside so the
+// migration runs once.
+func NewSQLiteStore(dbPath, jsonPath string) (*Store, error) {
+	if dir := filepath.Dir(dbPath); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create store directory: %w", err)
+		}
+	}
+
+	// WAL keeps readers and the writer from blocking each other; the busy
+	// timeout makes concurrent writers queue instead of failing
+	db, err := sql.Open("sqlite", "file:"+dbPath+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open smart album database: %w", err)
+	}
+	// SQLite allows one writer at a time; a single connection avoids
+	// SQLITE_BUSY between this process's own goroutines
+	db.SetMaxOpenConns(1)
+
+	for _, stmt := range sqliteSchema {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create smart album schema: %w", err)
+		}
+	}
+
+	backend := &sqliteBackend{db: db}
+
+	fresh, err := backend.isFresh()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	if fresh && jsonPath != "" {
+		if err := backend.migrateFromJSON(jsonPath); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+
+	store, err := newStore(backend)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// sqliteBackend persists the store in a SQLite database.
+type sqliteBackend struct {
+	db *sql.DB
+}
+
+// isFresh reports whether the database has never been saved to.
+func (b *sqliteBackend) isFresh() (bool, error) {
+	var value string
+	err := b.db.QueryRow(`SELECT value FROM meta WHERE key = 'schema_version'`).Scan(&value)
+	if err == sql.ErrNoRows {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read smart album schema version: %w", err)
+	}
+	return false, nil
+}
+
+// migrateFromJSON imports a legacy JSON store file, then renames it aside so
+// a later start doesn't import it again. A missing file is not an error.
+func (b *sqliteBackend) migrateFromJSON(jsonPath string) error {
+	if _, err := os.Stat(jsonPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to check legacy smart album store: %w", err)
+	}
+
+	file, _, err := (&jsonBackend{path: jsonPath}).load()
+	if err != nil {
+		return fmt.Errorf("failed to load legacy smart album store for migration: %w", err)
+	}
+	if err := b.save(file); err != nil {
+		return fmt.Errorf("failed to migrate smart album store to sqlite: %w", err)
+	}
+	for _, run := range file.Runs {
+		if err := b.appendRun(run); err != nil {
+			return fmt.Errorf("failed to migrate run history to sqlite: %w", err)
+		}
+	}
+	if err := os.Rename(jsonPath, jsonPath+".migrated"); err != nil {
+		return fmt.Errorf("failed to rename migrated smart album store: %w", err)
+	}
+	return nil
+}
+
+func (b *sqliteBackend) load() (*storeFile, bool, error) {
+	file := &storeFile{SchemaVersion: schemaVersion}
+
+	var version string
+	err := b.db.QueryRow(`SELECT value FROM meta WHERE key = 'schema_version'`).Scan(&version)
+	if err == sql.ErrNoRows {
+		return file, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read smart album schema version: %w", err)
+	}
+	if v, err := strconv.Atoi(version); err == nil && v > schemaVersion {
+		return nil, false, fmt.Errorf("smart album store schema version %d is newer than this build supports (%d)", v, schemaVersion)
+	}
+
+	rows, err := b.db.Query(`SELECT data FROM definitions`)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read smart album definitions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, false, fmt.Errorf("failed to scan smart album definition: %w", err)
+		}
+		var def SmartAlbumDefinition
+		if err := json.Unmarshal([]byte(data), &def); err != nil {
+			return nil, false, fmt.Errorf("failed to parse smart album definition: %w", err)
+		}
+		file.Definitions = append(file.Definitions, &def)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("failed to read smart album definitions: %w", err)
+	}
+
+	file.Pins, err = b.loadGrouped(`SELECT album_id, asset_id FROM pins ORDER BY album_id, pos`)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read pins: %w", err)
+	}
+	file.AlbumTags, err = b.loadGrouped(`SELECT album_id, tag FROM album_tags ORDER BY album_id, pos`)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read album tags: %w", err)
+	}
+
+	return file, false, nil
+}
+
+// loadGrouped collects a two-column (group, value) query into a map of
+// ordered slices; an empty result yields nil.
+func (b *sqliteBackend) loadGrouped(query string) (map[string][]string, error) {
+	rows, err := b.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var grouped map[string][]string
+	for rows.Next() {
+		var group, value string
+		if err := rows.Scan(&group, &value); err != nil {
+			return nil, err
+		}
+		if grouped == nil {
+			grouped = make(map[string][]string)
+		}
+		grouped[group] = append(grouped[group], value)
+	}
+	return grouped, rows.Err()
+}
+
+func (b *sqliteBackend) save(file *storeFile) error {
+	tx, err := b.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin smart album transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The store's state fits in memory, so a full rewrite per save keeps the
+	// tables and the in-memory maps trivially consistent; only the runs
+	// history, which grows unbounded, is appended to instead
+	for _, table := range []string{"definitions", "pins", "album_tags"} {
+		if _, err := tx.Exec("DELETE FROM " + table); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", table, err)
+		}
+	}
+
+	for _, def := range file.Definitions {
+		data, err := json.Marshal(def)
+		if err != nil {
+			return fmt.Errorf("failed to marshal smart album definition: %w", err)
+		}
+		if _, err := tx.Exec(`INSERT INTO definitions (id, name, data) VALUES (?, ?, ?)`, def.ID, def.Name, string(data)); err != nil {
+			return fmt.Errorf("failed to write smart album definition: %w", err)
+		}
+	}
+	for albumID, assetIDs := range file.Pins {
+		for pos, assetID := range assetIDs {
+			if _, err := tx.Exec(`INSERT INTO pins (album_id, asset_id, pos) VALUES (?, ?, ?)`, albumID, assetID, pos); err != nil {
+				return fmt.Errorf("failed to write pin: %w", err)
+			}
+		}
+	}
+	for albumID, tags := range file.AlbumTags {
+		for pos, tag := range tags {
+			if _, err := tx.Exec(`INSERT INTO album_tags (album_id, tag, pos) VALUES (?, ?, ?)`, albumID, tag, pos); err != nil {
+				return fmt.Errorf("failed to write album tag: %w", err)
+			}
+		}
+	}
+
+	if _, err := tx.Exec(`INSERT INTO meta (key, value) VALUES ('schema_version', ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value`, strconv.Itoa(file.SchemaVersion)); err != nil {
+		return fmt.Errorf("failed to write schema version: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit smart album store: %w", err)
+	}
+	return nil
+}
+
+func (b *sqliteBackend) appendRun(run RunRecord) error {
+	var errText interface{}
+	if run.Error != "" {
+		errText = run.Error
+	}
+	_, err := b.db.Exec(`INSERT INTO runs (definition_id, started_at, finished_at, matched, added, error)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		run.DefinitionID,
+		run.StartedAt.Format(time.RFC3339Nano),
+		run.FinishedAt.Format(time.RFC3339Nano),
+		run.Matched, run.Added, errText)
+	if err != nil {
+		return fmt.Errorf("failed to record run: %w", err)
+	}
+	return nil
+}
+
+func (b *sqliteBackend) runs(definitionID string, limit int) ([]RunRecord, error) {
+	query := `SELECT definition_id, started_at, finished_at, matched, added, COALESCE(error, '') FROM runs`
+	args := []interface{}{}
+	if definitionID != "" {
+		query += ` WHERE definition_id = ?`
+		args = append(args, definitionID)
+	}
+	query += ` ORDER BY id DESC`
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := b.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run history: %w", err)
+	}
+	defer rows.Close()
+
+	var selected []RunRecord
+	for rows.Next() {
+		var run RunRecord
+		var startedAt, finishedAt string
+		if err := rows.Scan(&run.DefinitionID, &startedAt, &finishedAt, &run.Matched, &run.Added, &run.Error); err != nil {
+			return nil, fmt.Errorf("failed to scan run record: %w", err)
+		}
+		run.StartedAt, _ = time.Parse(time.RFC3339Nano, startedAt)
+		run.FinishedAt, _ = time.Parse(time.RFC3339Nano, finishedAt)
+		selected = append(selected, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read run history: %w", err)
+	}
+	return selected, nil
+}
+
+func (b *sqliteBackend) check() error {
+	return b.db.Ping()
+}
@@ -0,0 +1,157 @@
+package smartalbums
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+func TestSQLiteStoreRoundTrip(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "smart_albums.db")
+
+	store, err := NewSQLiteStore(dbPath, "")
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+
+	def, err := store.Define(SmartAlbumDefinition{
+		Name:      "Beach",
+		AlbumName: "Beach",
+		Query:     immich.SmartSearchParams{Query: "beach sunset"},
+		MaxAssets: 100,
+	})
+	if err != nil {
+		t.Fatalf("Define failed: %v", err)
+	}
+	if _, err := store.Pin("album-1", []string{"a", "b"}); err != nil {
+		t.Fatalf("Pin failed: %v", err)
+	}
+	if err := store.SetAlbumTags("album-1", []string{"vacation", "family"}); err != nil {
+		t.Fatalf("SetAlbumTags failed: %v", err)
+	}
+
+	// A fresh store over the same database must see everything
+	reloaded, err := NewSQLiteStore(dbPath, "")
+	if err != nil {
+		t.Fatalf("NewSQLiteStore reopen failed: %v", err)
+	}
+
+	got, ok := reloaded.Get(def.ID)
+	if !ok {
+		t.Fatalf("definition %s lost across reopen", def.ID)
+	}
+	if got.Name != "Beach" || got.Query.Query != "beach sunset" || got.MaxAssets != 100 {
+		t.Errorf("definition lost detail across reopen: %+v", got)
+	}
+	if pinned := reloaded.PinnedAssets("album-1"); len(pinned) != 2 {
+		t.Errorf("PinnedAssets returned %v, want 2 entries", pinned)
+	}
+	if tags := reloaded.AlbumTags("album-1"); len(tags) != 2 || tags[0] != "vacation" {
+		t.Errorf("AlbumTags returned %v, want [vacation family]", tags)
+	}
+}
+
+func TestSQLiteStoreRunHistory(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "smart_albums.db")
+
+	store, err := NewSQLiteStore(dbPath, "")
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+
+	started := time.Now().Add(-time.Minute)
+	for i := 0; i < 3; i++ {
+		if err := store.AppendRun(RunRecord{
+			DefinitionID: "def-1",
+			StartedAt:    started.Add(time.Duration(i) * time.Second),
+			FinishedAt:   started.Add(time.Duration(i)*time.Second + 500*time.Millisecond),
+			Matched:      10 + i,
+			Added:        i,
+		}); err != nil {
+			t.Fatalf("AppendRun failed: %v", err)
+		}
+	}
+	if err := store.AppendRun(RunRecord{
+		DefinitionID: "def-2",
+		StartedAt:    started,
+		FinishedAt:   started,
+		Error:        "boom",
+	}); err != nil {
+		t.Fatalf("AppendRun failed: %v", err)
+	}
+
+	runs, err := store.Runs("def-1", 2)
+	if err != nil {
+		t.Fatalf("Runs failed: %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("Runs returned %d records, want 2", len(runs))
+	}
+	// Newest first, filtered to the requested definition
+	if runs[0].Matched != 12 || runs[1].Matched != 11 {
+		t.Errorf("Runs order wrong: %+v", runs)
+	}
+
+	failed, err := store.Runs("def-2", 0)
+	if err != nil {
+		t.Fatalf("Runs failed: %v", err)
+	}
+	if len(failed) != 1 || failed[0].Error != "boom" {
+		t.Errorf("failed run not recorded: %+v", failed)
+	}
+}
+
+func TestSQLiteStoreMigratesFromJSON(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "smart_albums.json")
+	dbPath := filepath.Join(dir, "smart_albums.db")
+
+	legacy, err := NewStore(jsonPath)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	def, err := legacy.Define(SmartAlbumDefinition{
+		Name:      "Pets",
+		AlbumName: "Pets",
+		Query:     immich.SmartSearchParams{Query: "dog"},
+	})
+	if err != nil {
+		t.Fatalf("Define failed: %v", err)
+	}
+
+	store, err := NewSQLiteStore(dbPath, jsonPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+
+	got, ok := store.Get(def.ID)
+	if !ok {
+		t.Fatalf("definition %s not migrated", def.ID)
+	}
+	if got.Query.Query != "dog" {
+		t.Errorf("migrated definition lost detail: %+v", got)
+	}
+
+	// The JSON file is renamed aside so the import runs exactly once
+	if _, err := os.Stat(jsonPath); !os.IsNotExist(err) {
+		t.Errorf("legacy JSON file still present after migration")
+	}
+	if _, err := os.Stat(jsonPath + ".migrated"); err != nil {
+		t.Errorf("migrated JSON file not kept aside: %v", err)
+	}
+
+	// Reopening must not re-import; the database is authoritative now
+	if err := store.Delete(def.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	reopened, err := NewSQLiteStore(dbPath, jsonPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore reopen failed: %v", err)
+	}
+	if _, ok := reopened.Get(def.ID); ok {
+		t.Error("deleted definition reappeared; migration ran twice")
+	}
+}
@@ -0,0 +1,642 @@
+package smartalbums
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// maxQueryHistory caps how many superseded queries are kept per definition;
+// the oldest versions are dropped first.
+const maxQueryHistory = 20
+
+// maxRunHistory caps the run history kept by the JSON backend, which rewrites
+// the whole store per save; the SQLite backend appends rows and keeps all.
+const maxRunHistory = 500
+
+// RunRecord is one refresh execution of a smart album definition.
+type RunRecord struct {
+	DefinitionID string    `json:"definitionId"`
+	StartedAt    time.Time `json:"startedAt"`
+	FinishedAt   time.Time `json:"finishedAt"`
+	Matched      int       `json:"matched"`
+	Added        int       `json:"added"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// QueryVersion is a superseded query kept so an edit can be rolled back.
+type QueryVersion struct {
+	Version int                      `json:"version"`
+	Query   immich.SmartSearchParams `json:"query"`
+	SavedAt time.Time                `json:"savedAt"`
+}
+
+// Sync strategies controlling what a refresh does with album assets that no
+// longer match the definition's query.
+const (
+	// SyncAddOnly never removes assets; the default
+	SyncAddOnly = "add-only"
+	// SyncFull removes non-matching assets from the album
+	SyncFull = "full-sync"
+	// SyncArchiveRemoved removes non-matching assets and archives them
+	SyncArchiveRemoved = "archive-removed"
+)
+
+// SmartAlbumDefinition is a persisted rule that keeps an Immich album
+// populated from a saved smart-search query.
+type SmartAlbumDefinition struct {
+	ID           string                   `json:"id"`
+	Name         string                   `json:"name"`
+	AlbumID      string                   `json:"albumId"`
+	AlbumName    string                   `json:"albumName"`
+	Description  string                   `json:"description,omitempty"`
+	Query        immich.SmartSearchParams `json:"query"`
+	QueryHistory []QueryVersion           `json:"queryHistory,omitempty"`
+	MaxAssets    int                      `json:"maxAssets,omitempty"`
+	CreatedAt    time.Time                `json:"createdAt"`
+	UpdatedAt    time.Time                `json:"updatedAt"`
+
+	// SyncStrategy controls removal of album assets that no longer match:
+	// add-only (default), full-sync, or archive-removed. Pinned assets are
+	// never removed regardless of strategy.
+	SyncStrategy string `json:"syncStrategy,omitempty"`
+
+	// RefreshIntervalMinutes overrides the scheduler's global default
+	// interval for this definition; 0 means use the default
+	RefreshIntervalMinutes int `json:"refreshIntervalMinutes,omitempty"`
+
+	// RefreshCron schedules refreshes with a five-field cron expression,
+	// taking precedence over the interval when set
+	RefreshCron string `json:"refreshCron,omitempty"`
+
+	// Last refresh stats
+	LastRunAt      time.Time `json:"lastRunAt,omitempty"`
+	LastRunMatched int       `json:"lastRunMatched,omitempty"`
+	LastRunAdded   int       `json:"lastRunAdded,omitempty"`
+	LastRunRemoved int       `json:"lastRunRemoved,omitempty"`
+	LastRunError   string    `json:"lastRunError,omitempty"`
+}
+
+// Store persists smart album definitions, per-album pins, and per-album tag
+// rules through a pluggable backend (JSON file by default, SQLite optionally)
+type Store struct {
+	backend storeBackend
+
+	mu          sync.RWMutex
+	definitions map[string]*SmartAlbumDefinition // id -> definition
+	pins        map[string][]string              // albumID -> pinned asset IDs
+	albumTags   map[string][]string              // albumID -> tags kept applied to contents
+}
+
+// storeBackend persists the store's state. The JSON backend snapshots the
+// whole store per save; the SQLite backend rewrites tables in a transaction.
+type storeBackend interface {
+	// load returns the persisted state and whether an on-disk format
+	// migration ran, so the caller re-saves in the current format
+	load() (*storeFile, bool, error)
+	save(*storeFile) error
+	check() error
+
+	// appendRun records one refresh execution; runs returns the most recent
+	// records newest first, optionally filtered to one definition
+	appendRun(RunRecord) error
+	runs(definitionID string, limit int) ([]RunRecord, error)
+}
+
+// schemaVersion is the current on-disk format version. Version 0 was a bare
+// array of definitions, version 1 introduced the storeFile envelope (pins),
+// and version 2 added the explicit schemaVersion field. Format changes bump
+// this and append an upgrade step to migrations.
+const schemaVersion = 2
+
+// storeFile is the on-disk representation of the store
+type storeFile struct {
+	SchemaVersion int                     `json:"schemaVersion"`
+	Definitions   []*SmartAlbumDefinition `json:"definitions"`
+	Pins          map[string][]string     `json:"pins,omitempty"`
+	AlbumTags     map[string][]string     `json:"albumTags,omitempty"`
+	Runs          []RunRecord             `json:"runs,omitempty"`
+}
+
+// migrations upgrade a store file one version at a time; entry i upgrades
+// from version i to i+1. New fields with omitempty don't need a step — only
+// structural changes do.
+var migrations = []func(*storeFile) error{
+	// 0 -> 1: bare array to storeFile envelope; handled during parse
+	func(*storeFile) error { return nil },
+	// 1 -> 2: explicit schemaVersion field only
+	func(*storeFile) error { return nil },
+}
+
+// NewStore loads (or initializes) a JSON-file smart album store at the given
+// path, migrating older on-disk formats forward; the pre-migration file is
+// backed up first so a bad upgrade never loses data.
+func NewStore(path string) (*Store, error) {
+	return newStore(&jsonBackend{path: path})
+}
+
+// newStore loads the backend's state into memory and re-saves it when the
+// backend reports a format migration, so the migration runs once.
+func newStore(backend storeBackend) (*Store, error) {
+	s := &Store{
+		backend:     backend,
+		definitions: make(map[string]*SmartAlbumDefinition),
+		pins:        make(map[string][]string),
+		albumTags:   make(map[string][]string),
+	}
+
+	file, migrated, err := backend.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, def := range file.Definitions {
+		s.definitions[def.ID] = def
+	}
+	if file.Pins != nil {
+		s.pins = file.Pins
+	}
+	if file.AlbumTags != nil {
+		s.albumTags = file.AlbumTags
+	}
+
+	if migrated {
+		s.mu.Lock()
+		err := s.save()
+		s.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Check verifies the store's backing file or database is accessible; used by
+// health reporting
+func (s *Store) Check() error {
+	return s.backend.check()
+}
+
+// save writes all definitions through the backend; callers must hold the
+// write lock
+func (s *Store) save() error {
+	file := storeFile{
+		SchemaVersion: schemaVersion,
+		Definitions:   make([]*SmartAlbumDefinition, 0, len(s.definitions)),
+		Pins:          s.pins,
+		AlbumTags:     s.albumTags,
+	}
+	for _, def := range s.definitions {
+		file.Definitions = append(file.Definitions, def)
+	}
+	if len(file.Pins) == 0 {
+		file.Pins = nil
+	}
+	if len(file.AlbumTags) == 0 {
+		file.AlbumTags = nil
+	}
+
+	return s.backend.save(&file)
+}
+
+// AppendRun records one refresh execution in the run history.
+func (s *Store) AppendRun(run RunRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.backend.appendRun(run)
+}
+
+// Runs returns the most recent run records, newest first, optionally
+// filtered to one definition ID.
+func (s *Store) Runs(definitionID string, limit int) ([]RunRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.backend.runs(definitionID, limit)
+}
+
+// jsonBackend is the default backend: the whole store is one JSON document
+// rewritten atomically on each save.
+type jsonBackend struct {
+	path string
+
+	// runHistory carries the run records through saves, since the Store
+	// doesn't hold them in memory; loaded once at open
+	runHistory []RunRecord
+}
+
+func (b *jsonBackend) load() (*storeFile, bool, error) {
+	data, err := os.ReadFile(b.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &storeFile{SchemaVersion: schemaVersion}, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read smart album store: %w", err)
+	}
+
+	var file storeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		// Version 0 stored a bare array of definitions
+		var defs []*SmartAlbumDefinition
+		if legacyErr := json.Unmarshal(data, &defs); legacyErr != nil {
+			return nil, false, fmt.Errorf("failed to parse smart album store: %w", err)
+		}
+		file.Definitions = defs
+		file.SchemaVersion = 0
+	}
+
+	if file.SchemaVersion > schemaVersion {
+		return nil, false, fmt.Errorf("smart album store schema version %d is newer than this build supports (%d)", file.SchemaVersion, schemaVersion)
+	}
+	migrated := file.SchemaVersion < schemaVersion
+	if migrated {
+		// Keep the pre-migration file next to the store so an interrupted
+		// or buggy upgrade can be recovered by hand
+		backup := fmt.Sprintf("%s.v%d.bak", b.path, file.SchemaVersion)
+		if err := os.WriteFile(backup, data, 0o644); err != nil {
+			return nil, false, fmt.Errorf("failed to back up smart album store before migration: %w", err)
+		}
+		for v := file.SchemaVersion; v < schemaVersion; v++ {
+			if err := migrations[v](&file); err != nil {
+				return nil, false, fmt.Errorf("failed to migrate smart album store from schema version %d: %w", v, err)
+			}
+		}
+	}
+
+	b.runHistory = file.Runs
+	return &file, migrated, nil
+}
+
+func (b *jsonBackend) save(file *storeFile) error {
+	file.Runs = b.runHistory
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal smart album store: %w", err)
+	}
+
+	if dir := filepath.Dir(b.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create store directory: %w", err)
+		}
+	}
+
+	// Write via temp file so a crash never truncates the store
+	tmp := b.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write smart album store: %w", err)
+	}
+	return os.Rename(tmp, b.path)
+}
+
+func (b *jsonBackend) check() error {
+	if _, err := os.Stat(b.path); err != nil {
+		if os.IsNotExist(err) {
+			if dir := filepath.Dir(b.path); dir != "." {
+				if _, dirErr := os.Stat(dir); dirErr != nil {
+					return dirErr
+				}
+			}
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (b *jsonBackend) appendRun(run RunRecord) error {
+	// Re-read the file so the rewrite keeps definitions, pins and tags; the
+	// store serializes backend calls under its lock
+	file, _, err := b.load()
+	if err != nil {
+		return err
+	}
+	b.runHistory = append(b.runHistory, run)
+	if len(b.runHistory) > maxRunHistory {
+		b.runHistory = b.runHistory[len(b.runHistory)-maxRunHistory:]
+	}
+	return b.save(file)
+}
+
+func (b *jsonBackend) runs(definitionID string, limit int) ([]RunRecord, error) {
+	var selected []RunRecord
+	for i := len(b.runHistory) - 1; i >= 0; i-- {
+		if definitionID != "" && b.runHistory[i].DefinitionID != definitionID {
+			continue
+		}
+		selected = append(selected, b.runHistory[i])
+		if limit > 0 && len(selected) >= limit {
+			break
+		}
+	}
+	return selected, nil
+}
+
+// queriesEqual reports whether two queries serialize identically
+func queriesEqual(a, b immich.SmartSearchParams) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return string(aJSON) == string(bJSON)
+}
+
+// appendQueryVersion records a superseded query, trimming the oldest entries
+// past maxQueryHistory
+func appendQueryVersion(history []QueryVersion, query immich.SmartSearchParams, at time.Time) []QueryVersion {
+	next := 1
+	if len(history) > 0 {
+		next = history[len(history)-1].Version + 1
+	}
+	history = append(history, QueryVersion{Version: next, Query: query, SavedAt: at})
+	if len(history) > maxQueryHistory {
+		history = history[len(history)-maxQueryHistory:]
+	}
+	return history
+}
+
+// Define creates a new definition or updates the one with the same name
+func (s *Store) Define(def SmartAlbumDefinition) (*SmartAlbumDefinition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	// Update in place when a definition with this name already exists
+	for _, existing := range s.definitions {
+		if existing.Name == def.Name {
+			// Keep the old query so the edit can be rolled back
+			if !queriesEqual(existing.Query, def.Query) {
+				existing.QueryHistory = appendQueryVersion(existing.QueryHistory, existing.Query, now)
+			}
+			existing.Query = def.Query
+			existing.Description = def.Description
+			existing.MaxAssets = def.MaxAssets
+			existing.RefreshIntervalMinutes = def.RefreshIntervalMinutes
+			existing.RefreshCron = def.RefreshCron
+			existing.SyncStrategy = def.SyncStrategy
+			if def.AlbumID != "" {
+				existing.AlbumID = def.AlbumID
+			}
+			if def.AlbumName != "" {
+				existing.AlbumName = def.AlbumName
+			}
+			existing.UpdatedAt = now
+			if err := s.save(); err != nil {
+				return nil, err
+			}
+			copied := *existing
+			return &copied, nil
+		}
+	}
+
+	def.ID = fmt.Sprintf("sa-%d", now.UnixNano())
+	def.CreatedAt = now
+	def.UpdatedAt = now
+	s.definitions[def.ID] = &def
+
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	copied := def
+	return &copied, nil
+}
+
+// Get returns a definition by ID
+func (s *Store) Get(id string) (*SmartAlbumDefinition, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	def, found := s.definitions[id]
+	if !found {
+		return nil, false
+	}
+	copied := *def
+	return &copied, true
+}
+
+// GetByName returns a definition by its name
+func (s *Store) GetByName(name string) (*SmartAlbumDefinition, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, def := range s.definitions {
+		if def.Name == name {
+			copied := *def
+			return &copied, true
+		}
+	}
+	return nil, false
+}
+
+// List returns all definitions
+func (s *Store) List() []SmartAlbumDefinition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	defs := make([]SmartAlbumDefinition, 0, len(s.definitions))
+	for _, def := range s.definitions {
+		defs = append(defs, *def)
+	}
+	return defs
+}
+
+// Update replaces a stored definition by ID
+func (s *Store) Update(def SmartAlbumDefinition) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, found := s.definitions[def.ID]
+	if !found {
+		return fmt.Errorf("smart album definition '%s' not found", def.ID)
+	}
+	def.UpdatedAt = time.Now()
+	// Keep the old query so the edit can be rolled back
+	if !queriesEqual(stored.Query, def.Query) {
+		def.QueryHistory = appendQueryVersion(stored.QueryHistory, stored.Query, def.UpdatedAt)
+	}
+	s.definitions[def.ID] = &def
+	return s.save()
+}
+
+// RollbackQuery restores a previous query version on a definition. The query
+// being replaced is itself recorded in the history, so a rollback can be
+// rolled back.
+func (s *Store) RollbackQuery(id string, version int) (*SmartAlbumDefinition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	def, found := s.definitions[id]
+	if !found {
+		return nil, fmt.Errorf("smart album definition '%s' not found", id)
+	}
+
+	var target *QueryVersion
+	for i := range def.QueryHistory {
+		if def.QueryHistory[i].Version == version {
+			target = &def.QueryHistory[i]
+			break
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("query version %d not found for smart album '%s'", version, def.Name)
+	}
+
+	now := time.Now()
+	restored := target.Query
+	if !queriesEqual(def.Query, restored) {
+		def.QueryHistory = appendQueryVersion(def.QueryHistory, def.Query, now)
+	}
+	def.Query = restored
+	def.UpdatedAt = now
+
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	copied := *def
+	return &copied, nil
+}
+
+// Delete removes a definition by ID
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.definitions[id]; !found {
+		return fmt.Errorf("smart album definition '%s' not found", id)
+	}
+	delete(s.definitions, id)
+	return s.save()
+}
+
+// RenameAlbumReferences updates every definition that targets the given album
+// (by ID or old name) to use the new album name, returning the updated IDs.
+func (s *Store) RenameAlbumReferences(albumID, oldName, newName string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var updated []string
+	for _, def := range s.definitions {
+		if (albumID != "" && def.AlbumID == albumID) || (oldName != "" && def.AlbumName == oldName) {
+			def.AlbumName = newName
+			if albumID != "" {
+				def.AlbumID = albumID
+			}
+			def.UpdatedAt = time.Now()
+			updated = append(updated, def.ID)
+		}
+	}
+
+	if len(updated) == 0 {
+		return nil, nil
+	}
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Pin marks assets in an album as manually curated. Pinned assets must
+// survive automated pruning (e.g. full-sync smart album refreshes). Returns
+// how many of the given IDs were newly pinned.
+func (s *Store) Pin(albumID string, assetIDs []string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing := make(map[string]bool, len(s.pins[albumID]))
+	for _, id := range s.pins[albumID] {
+		existing[id] = true
+	}
+
+	added := 0
+	for _, id := range assetIDs {
+		if id == "" || existing[id] {
+			continue
+		}
+		s.pins[albumID] = append(s.pins[albumID], id)
+		existing[id] = true
+		added++
+	}
+
+	if added == 0 {
+		return 0, nil
+	}
+	if err := s.save(); err != nil {
+		return 0, err
+	}
+	return added, nil
+}
+
+// Unpin removes the pin on the given assets in an album, returning how many
+// pins were actually removed.
+func (s *Store) Unpin(albumID string, assetIDs []string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	toRemove := make(map[string]bool, len(assetIDs))
+	for _, id := range assetIDs {
+		toRemove[id] = true
+	}
+
+	kept := s.pins[albumID][:0]
+	removed := 0
+	for _, id := range s.pins[albumID] {
+		if toRemove[id] {
+			removed++
+			continue
+		}
+		kept = append(kept, id)
+	}
+
+	if removed == 0 {
+		return 0, nil
+	}
+	if len(kept) == 0 {
+		delete(s.pins, albumID)
+	} else {
+		s.pins[albumID] = kept
+	}
+	if err := s.save(); err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+// SetAlbumTags records tags that must stay applied to an album's contents
+// (enforced by tagAlbumContents and on every smart album refresh); an empty
+// list clears the rule.
+func (s *Store) SetAlbumTags(albumID string, tags []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(tags) == 0 {
+		delete(s.albumTags, albumID)
+	} else {
+		copied := make([]string, len(tags))
+		copy(copied, tags)
+		s.albumTags[albumID] = copied
+	}
+	return s.save()
+}
+
+// AlbumTags returns the sync-tag rule for an album, or nil when none is set.
+func (s *Store) AlbumTags(albumID string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tags := make([]string, len(s.albumTags[albumID]))
+	copy(tags, s.albumTags[albumID])
+	if len(tags) == 0 {
+		return nil
+	}
+	return tags
+}
+
+// PinnedAssets returns the pinned asset IDs for an album.
+func (s *Store) PinnedAssets(albumID string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	pinned := make([]string, len(s.pins[albumID]))
+	copy(pinned, s.pins[albumID])
+	return pinned
+}
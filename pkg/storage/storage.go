@@ -0,0 +1,185 @@
+// Package storage unifies operational management of the server's own
+// persistent state. Each store (smart albums, saved searches, recycle
+// snapshots, operation journal, tool history) keeps its JSON format; a
+// Backend exposes them as named namespaces so backup and restore can
+// operate on all of them uniformly. The interface is deliberately small so
+// SQLite or Redis backends can implement the same contract.
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Backend is the contract every storage implementation satisfies
+type Backend interface {
+	// Load returns a namespace's raw state; found is false when the
+	// namespace holds no data yet
+	Load(namespace string) (data []byte, found bool, err error)
+	// Save replaces a namespace's raw state atomically
+	Save(namespace string, data []byte) error
+	// Namespaces lists every registered namespace, sorted
+	Namespaces() []string
+}
+
+// FileBackend maps namespaces onto the JSON files the stores already use
+type FileBackend struct {
+	mu    sync.Mutex
+	paths map[string]string // namespace -> file path
+}
+
+// NewFileBackend creates an empty file backend
+func NewFileBackend() *FileBackend {
+	return &FileBackend{paths: make(map[string]string)}
+}
+
+// Register maps a namespace onto a state file; empty paths are ignored so
+// optional stores can be registered unconditionally
+func (b *FileBackend) Register(namespace, path string) {
+	if path == "" {
+		return
+	}
+	b.mu.Lock()
+	b.paths[namespace] = path
+	b.mu.Unlock()
+}
+
+// path resolves a namespace, erroring on unknown names
+func (b *FileBackend) path(namespace string) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	path, found := b.paths[namespace]
+	if !found {
+		return "", fmt.Errorf("unknown storage namespace '%s'", namespace)
+	}
+	return path, nil
+}
+
+// Load implements Backend
+func (b *FileBackend) Load(namespace string) ([]byte, bool, error) {
+	path, err := b.path(namespace)
+	if err != nil {
+		return nil, false, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read namespace '%s': %w", namespace, err)
+	}
+	return data, true, nil
+}
+
+// Save implements Backend
+func (b *FileBackend) Save(namespace string, data []byte) error {
+	path, err := b.path(namespace)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create directory for namespace '%s': %w", namespace, err)
+		}
+	}
+
+	// Write via temp file so a crash never truncates the state
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write namespace '%s': %w", namespace, err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// Namespaces implements Backend
+func (b *FileBackend) Namespaces() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	names := make([]string, 0, len(b.paths))
+	for namespace := range b.paths {
+		names = append(names, namespace)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SnapshotVersion is the current backup format version; Restore migrates
+// older versions forward and refuses newer ones
+const SnapshotVersion = 1
+
+// StateSnapshot bundles every namespace's state into one restorable backup
+type StateSnapshot struct {
+	Version   int                        `json:"version"`
+	CreatedAt time.Time                  `json:"createdAt"`
+	State     map[string]json.RawMessage `json:"state"`
+}
+
+// Snapshot captures the current state of every namespace
+func Snapshot(b Backend) (*StateSnapshot, error) {
+	snap := &StateSnapshot{
+		Version:   SnapshotVersion,
+		CreatedAt: time.Now(),
+		State:     make(map[string]json.RawMessage),
+	}
+	for _, namespace := range b.Namespaces() {
+		data, found, err := b.Load(namespace)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		snap.State[namespace] = json.RawMessage(data)
+	}
+	return snap, nil
+}
+
+// migrateSnapshot upgrades older snapshot formats in place
+func migrateSnapshot(snap *StateSnapshot) error {
+	switch {
+	case snap.Version == SnapshotVersion:
+		return nil
+	case snap.Version > SnapshotVersion:
+		return fmt.Errorf("snapshot version %d is newer than this server supports (%d)", snap.Version, SnapshotVersion)
+	default:
+		// No older formats exist yet; version bumps add cases here
+		return fmt.Errorf("unsupported snapshot version %d", snap.Version)
+	}
+}
+
+// Restore writes a snapshot's state back into every namespace it covers,
+// returning the restored namespace names. Namespaces in the snapshot the
+// backend does not know are skipped and reported.
+func Restore(b Backend, snap *StateSnapshot) (restored, skipped []string, err error) {
+	if err := migrateSnapshot(snap); err != nil {
+		return nil, nil, err
+	}
+
+	known := map[string]bool{}
+	for _, namespace := range b.Namespaces() {
+		known[namespace] = true
+	}
+
+	namespaces := make([]string, 0, len(snap.State))
+	for namespace := range snap.State {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	for _, namespace := range namespaces {
+		if !known[namespace] {
+			skipped = append(skipped, namespace)
+			continue
+		}
+		if err := b.Save(namespace, snap.State[namespace]); err != nil {
+			return restored, skipped, err
+		}
+		restored = append(restored, namespace)
+	}
+	return restored, skipped, nil
+}
@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"time"
+
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// aggregateOnlySchema is the shared schema fragment for the aggregateOnly
+// parameter on search and scan tools.
+func aggregateOnlySchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "boolean",
+		"description": "Return only counts, size sums, and by-month/type/camera breakdowns instead of per-asset output",
+		"default":     false,
+	}
+}
+
+// aggregateAssets summarizes a result set into counts, a size sum, and
+// group-by breakdowns, replacing per-asset output when the caller only
+// needs the numbers.
+func aggregateAssets(assets []immich.Asset) map[string]interface{} {
+	byMonth := map[string]int{}
+	byType := map[string]int{}
+	byCamera := map[string]int{}
+	var totalSize int64
+	favorites := 0
+
+	for _, asset := range assets {
+		if taken := assetCaptureTime(asset); !taken.IsZero() {
+			byMonth[taken.Format("2006-01")]++
+		} else {
+			byMonth["unknown"]++
+		}
+
+		assetType := asset.Type
+		if assetType == "" {
+			assetType = "unknown"
+		}
+		byType[assetType]++
+
+		camera := "unknown"
+		if asset.ExifInfo != nil {
+			totalSize += asset.ExifInfo.FileSizeInByte
+			if asset.ExifInfo.Make != "" || asset.ExifInfo.Model != "" {
+				camera = asset.ExifInfo.Make
+				if asset.ExifInfo.Model != "" {
+					if camera != "" {
+						camera += " "
+					}
+					camera += asset.ExifInfo.Model
+				}
+			}
+		}
+		byCamera[camera]++
+
+		if asset.IsFavorite {
+			favorites++
+		}
+	}
+
+	aggregate := map[string]interface{}{
+		"count":    len(assets),
+		"byMonth":  byMonth,
+		"byType":   byType,
+		"byCamera": byCamera,
+	}
+	if totalSize > 0 {
+		aggregate["totalSizeBytes"] = totalSize
+		aggregate["totalSizeMB"] = totalSize / (1024 * 1024)
+	}
+	if favorites > 0 {
+		aggregate["favorites"] = favorites
+	}
+	if earliest, latest := assetTimeRange(assets); !earliest.IsZero() {
+		aggregate["earliest"] = earliest.Format(time.RFC3339)
+		aggregate["latest"] = latest.Format(time.RFC3339)
+	}
+	return aggregate
+}
+
+// assetTimeRange returns the earliest and latest capture times in the set;
+// zero times when no asset has one.
+func assetTimeRange(assets []immich.Asset) (time.Time, time.Time) {
+	var earliest, latest time.Time
+	for _, asset := range assets {
+		taken := assetCaptureTime(asset)
+		if taken.IsZero() {
+			continue
+		}
+		if earliest.IsZero() || taken.Before(earliest) {
+			earliest = taken
+		}
+		if latest.IsZero() || taken.After(latest) {
+			latest = taken
+		}
+	}
+	return earliest, latest
+}
@@ -0,0 +1,166 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// registerGetAlbumGrowth registers the album growth timeline tool
+func registerGetAlbumGrowth(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "getAlbumGrowth",
+		Description: "Report how an album's asset count evolved over time, useful for verifying that live/smart album automation is actually adding content",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"albumName": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the album to analyze",
+				},
+				"albumId": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the album (alternative to albumName)",
+				},
+				"granularity": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"day", "month", "year"},
+					"description": "Bucket size for the growth timeline",
+					"default":     "month",
+				},
+				"dateField": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"addedAt", "captureDate"},
+					"description": "Timestamp to bucket by: when assets entered Immich (approximated by their update time) or when they were captured",
+					"default":     "addedAt",
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AlbumName   string `json:"albumName"`
+			AlbumID     string `json:"albumId"`
+			Granularity string `json:"granularity"`
+			DateField   string `json:"dateField"`
+		}
+
+		// Set defaults
+		params.Granularity = "month"
+		params.DateField = "addedAt"
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		// Resolve album
+		albumID := params.AlbumID
+		albumName := params.AlbumName
+		if albumID == "" {
+			if albumName == "" {
+				return nil, fmt.Errorf("either albumName or albumId must be provided")
+			}
+			albums, err := immichClient.ListAlbums(ctx, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list albums: %w", err)
+			}
+			for _, album := range albums {
+				if album.AlbumName == albumName {
+					albumID = album.ID
+					break
+				}
+			}
+			if albumID == "" {
+				return nil, fmt.Errorf("album '%s' not found", albumName)
+			}
+		}
+
+		assets, err := immichClient.GetAlbumAssets(ctx, albumID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get album assets: %w", err)
+		}
+
+		if len(assets) == 0 {
+			return makeMCPResult(map[string]interface{}{
+				"success": true,
+				"albumId": albumID,
+				"message": "Album is empty",
+			})
+		}
+
+		var bucketFormat string
+		switch params.Granularity {
+		case "day":
+			bucketFormat = "2006-01-02"
+		case "month":
+			bucketFormat = "2006-01"
+		case "year":
+			bucketFormat = "2006"
+		default:
+			return nil, fmt.Errorf("invalid granularity: %s", params.Granularity)
+		}
+
+		// Bucket assets by period
+		buckets := map[string]int{}
+		var firstDate, lastDate time.Time
+		for _, asset := range assets {
+			ts := asset.UpdatedAt
+			if params.DateField == "captureDate" {
+				ts = asset.FileCreatedAt
+			}
+			if ts.IsZero() {
+				continue
+			}
+			buckets[ts.Format(bucketFormat)]++
+			if firstDate.IsZero() || ts.Before(firstDate) {
+				firstDate = ts
+			}
+			if ts.After(lastDate) {
+				lastDate = ts
+			}
+		}
+
+		periods := make([]string, 0, len(buckets))
+		for period := range buckets {
+			periods = append(periods, period)
+		}
+		sort.Strings(periods)
+
+		type growthPoint struct {
+			Period     string `json:"period"`
+			Added      int    `json:"added"`
+			Cumulative int    `json:"cumulative"`
+		}
+
+		timeline := make([]growthPoint, 0, len(periods))
+		cumulative := 0
+		for _, period := range periods {
+			cumulative += buckets[period]
+			timeline = append(timeline, growthPoint{
+				Period:     period,
+				Added:      buckets[period],
+				Cumulative: cumulative,
+			})
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":     true,
+			"albumId":     albumID,
+			"albumName":   albumName,
+			"totalAssets": len(assets),
+			"dateField":   params.DateField,
+			"granularity": params.Granularity,
+			"firstDate":   firstDate.Format(time.RFC3339),
+			"lastDate":    lastDate.Format(time.RFC3339),
+			"timeline":    timeline,
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
@@ -0,0 +1,293 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// albumLock marks one curated album as read-only for every mutating tool
+// and for background automation.
+type albumLock struct {
+	AlbumID   string    `json:"albumId"`
+	AlbumName string    `json:"albumName"`
+	Reason    string    `json:"reason,omitempty"`
+	LockedAt  time.Time `json:"lockedAt"`
+}
+
+// albumLockRegistry is the shared protection registry, persisted as a JSON
+// file so locks survive restarts. Mutating tools consult it through
+// checkAlbumLock before touching an album.
+var albumLockRegistry struct {
+	mu    sync.Mutex
+	path  string
+	locks map[string]albumLock // keyed by album ID
+}
+
+// InitAlbumLocks loads the lock registry from path; a missing file starts
+// the registry empty.
+func InitAlbumLocks(path string) error {
+	albumLockRegistry.mu.Lock()
+	defer albumLockRegistry.mu.Unlock()
+
+	albumLockRegistry.path = path
+	albumLockRegistry.locks = map[string]albumLock{}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read album locks: %w", err)
+	}
+
+	var locks []albumLock
+	if err := json.Unmarshal(data, &locks); err != nil {
+		return fmt.Errorf("failed to parse album locks: %w", err)
+	}
+	for _, lock := range locks {
+		albumLockRegistry.locks[lock.AlbumID] = lock
+	}
+	return nil
+}
+
+// saveAlbumLocksLocked persists the registry; the caller holds the mutex.
+// Write via temp file so a crash never truncates the registry.
+func saveAlbumLocksLocked() error {
+	if albumLockRegistry.path == "" {
+		return nil
+	}
+
+	locks := make([]albumLock, 0, len(albumLockRegistry.locks))
+	for _, lock := range albumLockRegistry.locks {
+		locks = append(locks, lock)
+	}
+	sort.Slice(locks, func(i, j int) bool { return locks[i].AlbumName < locks[j].AlbumName })
+
+	data, err := json.MarshalIndent(locks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode album locks: %w", err)
+	}
+
+	tmp := albumLockRegistry.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write album locks: %w", err)
+	}
+	return os.Rename(tmp, albumLockRegistry.path)
+}
+
+// checkAlbumLock refuses mutations against locked albums. Matching is by ID
+// when known, falling back to the name for call sites that only know the
+// target album's name. A nil return means the mutation may proceed.
+func checkAlbumLock(albumID, albumName string) error {
+	albumLockRegistry.mu.Lock()
+	defer albumLockRegistry.mu.Unlock()
+
+	if len(albumLockRegistry.locks) == 0 {
+		return nil
+	}
+	if albumID != "" {
+		if lock, found := albumLockRegistry.locks[albumID]; found {
+			return albumLockedError(lock)
+		}
+	}
+	if albumName != "" {
+		for _, lock := range albumLockRegistry.locks {
+			if strings.EqualFold(lock.AlbumName, albumName) {
+				return albumLockedError(lock)
+			}
+		}
+	}
+	return nil
+}
+
+// albumLockedError explains why a mutation was refused
+func albumLockedError(lock albumLock) error {
+	if lock.Reason != "" {
+		return fmt.Errorf("album '%s' is locked (%s); run unlockAlbum before modifying it", lock.AlbumName, lock.Reason)
+	}
+	return fmt.Errorf("album '%s' is locked; run unlockAlbum before modifying it", lock.AlbumName)
+}
+
+// currentAlbumLocks lists the registry for tool results
+func currentAlbumLocks() []albumLock {
+	albumLockRegistry.mu.Lock()
+	defer albumLockRegistry.mu.Unlock()
+
+	locks := make([]albumLock, 0, len(albumLockRegistry.locks))
+	for _, lock := range albumLockRegistry.locks {
+		locks = append(locks, lock)
+	}
+	sort.Slice(locks, func(i, j int) bool { return locks[i].AlbumName < locks[j].AlbumName })
+	return locks
+}
+
+// RegisterAlbumLockTools registers the lockAlbum/unlockAlbum pair that
+// manages the protection registry.
+func RegisterAlbumLockTools(s *server.MCPServer, immichClient *immich.Client) {
+	registerLockAlbum(s, immichClient)
+	registerUnlockAlbum(s, immichClient)
+}
+
+// lockAlbum tool
+func registerLockAlbum(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "lockAlbum",
+		Description: "Mark a curated album read-only: every mutating tool and all background automation will refuse to add to, remove from, rename, or delete it until unlockAlbum is called",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"albumName": map[string]interface{}{
+					"type":        "string",
+					"description": "Album to lock",
+				},
+				"albumId": map[string]interface{}{
+					"type":        "string",
+					"description": "Album ID (alternative to albumName)",
+				},
+				"reason": map[string]interface{}{
+					"type":        "string",
+					"description": "Why the album is protected, shown when a mutation is refused",
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AlbumName string `json:"albumName"`
+			AlbumID   string `json:"albumId"`
+			Reason    string `json:"reason"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		album, candidates, err := resolveAlbumForLock(ctx, s, immichClient, params.AlbumID, params.AlbumName)
+		if err != nil {
+			return nil, err
+		}
+		if album == nil {
+			return albumCandidatesResult(params.AlbumName, candidates)
+		}
+
+		albumLockRegistry.mu.Lock()
+		albumLockRegistry.locks[album.ID] = albumLock{
+			AlbumID:   album.ID,
+			AlbumName: album.AlbumName,
+			Reason:    params.Reason,
+			LockedAt:  time.Now(),
+		}
+		err = saveAlbumLocksLocked()
+		albumLockRegistry.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":      true,
+			"albumId":      album.ID,
+			"albumName":    album.AlbumName,
+			"lockedAlbums": currentAlbumLocks(),
+			"message":      fmt.Sprintf("Album '%s' is now locked against modification", album.AlbumName),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// unlockAlbum tool
+func registerUnlockAlbum(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "unlockAlbum",
+		Description: "Remove an album from the read-only protection registry",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"albumName": map[string]interface{}{
+					"type":        "string",
+					"description": "Album to unlock",
+				},
+				"albumId": map[string]interface{}{
+					"type":        "string",
+					"description": "Album ID (alternative to albumName)",
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AlbumName string `json:"albumName"`
+			AlbumID   string `json:"albumId"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if params.AlbumID == "" && params.AlbumName == "" {
+			return nil, fmt.Errorf("either albumName or albumId must be provided")
+		}
+
+		albumLockRegistry.mu.Lock()
+		var removed *albumLock
+		for id, lock := range albumLockRegistry.locks {
+			if id == params.AlbumID || strings.EqualFold(lock.AlbumName, params.AlbumName) {
+				l := lock
+				removed = &l
+				delete(albumLockRegistry.locks, id)
+				break
+			}
+		}
+		var err error
+		if removed != nil {
+			err = saveAlbumLocksLocked()
+		}
+		albumLockRegistry.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+
+		if removed == nil {
+			return makeMCPResult(map[string]interface{}{
+				"success": false,
+				"message": "No matching album lock found",
+			})
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":      true,
+			"albumId":      removed.AlbumID,
+			"albumName":    removed.AlbumName,
+			"lockedAlbums": currentAlbumLocks(),
+			"message":      fmt.Sprintf("Album '%s' is unlocked", removed.AlbumName),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// resolveAlbumForLock resolves the lock target by ID or interactively by name
+func resolveAlbumForLock(ctx context.Context, s *server.MCPServer, immichClient *immich.Client, albumID, albumName string) (*immich.Album, []string, error) {
+	if albumID != "" {
+		album, err := immichClient.GetAlbumInfo(ctx, albumID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get album %s: %w", albumID, err)
+		}
+		return album, nil, nil
+	}
+	if albumName == "" {
+		return nil, nil, fmt.Errorf("either albumName or albumId must be provided")
+	}
+	return findAlbumInteractive(ctx, s, immichClient, albumName)
+}
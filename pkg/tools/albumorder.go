@@ -0,0 +1,203 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// assetCaptureTime returns the best capture timestamp for ordering: EXIF
+// original date when present, file creation date otherwise.
+func assetCaptureTime(asset immich.Asset) time.Time {
+	if asset.ExifInfo != nil && asset.ExifInfo.DateTimeOriginal != "" {
+		if t, err := time.Parse(time.RFC3339, asset.ExifInfo.DateTimeOriginal); err == nil {
+			return t
+		}
+	}
+	return asset.FileCreatedAt
+}
+
+// registerNormalizeAlbumOrder registers the tool that repairs albums ordered
+// by upload date instead of capture date.
+func registerNormalizeAlbumOrder(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "normalizeAlbumOrder",
+		Description: "Enforce capture-date ordering for an album via the album order field, optionally re-adding assets in sorted order, and verify the resulting order",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"albumName": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the album to normalize",
+				},
+				"albumId": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the album (if known, otherwise will search by name)",
+				},
+				"order": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"asc", "desc"},
+					"description": "Capture-date direction to enforce",
+					"default":     "asc",
+				},
+				"reAdd": map[string]interface{}{
+					"type":        "boolean",
+					"description": "If the order field alone doesn't fix it, remove and re-add assets in capture order",
+					"default":     false,
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Report how far out of order the album is without changing anything",
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AlbumName string `json:"albumName"`
+			AlbumID   string `json:"albumId"`
+			Order     string `json:"order"`
+			ReAdd     bool   `json:"reAdd"`
+			DryRun    bool   `json:"dryRun"`
+		}
+
+		// Set defaults
+		params.Order = "asc"
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if params.Order != "asc" && params.Order != "desc" {
+			return nil, fmt.Errorf("invalid order: %s", params.Order)
+		}
+
+		// Find album if not provided by ID
+		albumID := params.AlbumID
+		albumName := params.AlbumName
+		if albumID == "" {
+			if params.AlbumName == "" {
+				return nil, fmt.Errorf("either albumName or albumId must be provided")
+			}
+			albums, err := immichClient.ListAlbums(ctx, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list albums: %w", err)
+			}
+			for _, album := range albums {
+				if album.AlbumName == params.AlbumName {
+					albumID = album.ID
+					break
+				}
+			}
+			if albumID == "" {
+				return nil, fmt.Errorf("album '%s' not found", params.AlbumName)
+			}
+		}
+
+		assets, err := immichClient.GetAlbumAssets(ctx, albumID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get album assets: %w", err)
+		}
+
+		outOfOrder := countOutOfOrder(assets, params.Order)
+
+		result := map[string]interface{}{
+			"albumID":    albumID,
+			"albumName":  albumName,
+			"assetCount": len(assets),
+			"order":      params.Order,
+			"outOfOrder": outOfOrder,
+		}
+
+		if params.DryRun {
+			result["dryRun"] = true
+			result["success"] = true
+			if outOfOrder == 0 {
+				result["message"] = "Album is already in capture-date order"
+			} else {
+				result["message"] = fmt.Sprintf("Dry run: %d of %d assets are out of capture-date order", outOfOrder, len(assets))
+			}
+			return makeMCPResult(result)
+		}
+
+		if err := checkAlbumLock(albumID, albumName); err != nil {
+			return nil, err
+		}
+
+		// Set the album order field first — for most albums that is enough
+		if _, err := immichClient.UpdateAlbum(ctx, albumID, map[string]interface{}{"order": params.Order}); err != nil {
+			return nil, fmt.Errorf("failed to update album order: %w", err)
+		}
+		result["orderFieldSet"] = true
+
+		// Re-add in sorted order when requested and still needed
+		if params.ReAdd && outOfOrder > 0 {
+			sorted := make([]immich.Asset, len(assets))
+			copy(sorted, assets)
+			sort.SliceStable(sorted, func(i, j int) bool {
+				ti, tj := assetCaptureTime(sorted[i]), assetCaptureTime(sorted[j])
+				if params.Order == "desc" {
+					return ti.After(tj)
+				}
+				return ti.Before(tj)
+			})
+
+			assetIDs := make([]string, len(sorted))
+			for i, asset := range sorted {
+				assetIDs[i] = asset.ID
+			}
+
+			if _, err := immichClient.RemoveAssetsFromAlbum(ctx, albumID, assetIDs); err != nil {
+				return nil, fmt.Errorf("failed to remove assets for re-add: %w", err)
+			}
+			bulkResult, err := immichClient.AddAssetsToAlbum(ctx, albumID, assetIDs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to re-add assets in sorted order: %w", err)
+			}
+			result["reAdded"] = len(bulkResult.Success)
+			result["reAddFailed"] = len(bulkResult.Error)
+		}
+
+		// Verify the resulting order
+		verified, err := immichClient.GetAlbumAssets(ctx, albumID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify album order: %w", err)
+		}
+		remaining := countOutOfOrder(verified, params.Order)
+		result["outOfOrderAfter"] = remaining
+		result["success"] = true
+		if remaining == 0 {
+			result["message"] = fmt.Sprintf("Album now follows capture-date order (%s)", params.Order)
+		} else {
+			result["message"] = fmt.Sprintf("%d assets remain out of order; retry with reAdd=true to rewrite album membership", remaining)
+		}
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// countOutOfOrder counts adjacent pairs that violate the requested
+// capture-date direction.
+func countOutOfOrder(assets []immich.Asset, order string) int {
+	count := 0
+	for i := 0; i+1 < len(assets); i++ {
+		ti, tj := assetCaptureTime(assets[i]), assetCaptureTime(assets[i+1])
+		if order == "desc" {
+			if ti.Before(tj) {
+				count++
+			}
+		} else if ti.After(tj) {
+			count++
+		}
+	}
+	return count
+}
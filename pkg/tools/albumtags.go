@@ -0,0 +1,191 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/smartalbums"
+)
+
+// applyTagsToAssets upserts the named tags and links each of them to the
+// given assets, returning how many asset-tag pairs were linked
+func applyTagsToAssets(ctx context.Context, immichClient *immich.Client, tagNames, assetIDs []string) (int, error) {
+	tags, err := immichClient.UpsertTags(ctx, tagNames)
+	if err != nil {
+		return 0, fmt.Errorf("failed to upsert tags: %w", err)
+	}
+
+	tagsByName := map[string]immich.Tag{}
+	for _, tag := range tags {
+		tagsByName[strings.ToLower(tag.Name)] = tag
+	}
+
+	linked := 0
+	for _, name := range tagNames {
+		// Check for context cancellation
+		select {
+		case <-ctx.Done():
+			return linked, fmt.Errorf("operation cancelled: %w", ctx.Err())
+		default:
+		}
+
+		tag, found := tagsByName[strings.ToLower(name)]
+		if !found {
+			return linked, fmt.Errorf("tag '%s' missing from upsert response", name)
+		}
+		linkResult, err := immichClient.TagAssets(ctx, tag.ID, assetIDs)
+		if err != nil {
+			return linked, fmt.Errorf("failed to tag assets with '%s': %w", name, err)
+		}
+		linked += len(linkResult.Success)
+	}
+	return linked, nil
+}
+
+// registerTagAlbumContents registers the tool that applies tags to every
+// asset in an album, optionally persisting the rule so smart album refreshes
+// keep tagging newly added assets.
+func registerTagAlbumContents(s *server.MCPServer, immichClient *immich.Client, store *smartalbums.Store) {
+	tool := mcp.Tool{
+		Name:        "tagAlbumContents",
+		Description: "Apply one or more tags to every asset in an album, optionally keeping the tags in sync as smart album refreshes add new assets",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"albumName": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the album to tag",
+				},
+				"albumId": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the album (if known, otherwise will search by name)",
+				},
+				"tags": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Tag names to apply (created if missing)",
+				},
+				"keepInSync": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Persist the rule so assets added by smart album refreshes get the tags too",
+					"default":     false,
+				},
+				"clearSync": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Remove a previously persisted sync rule for this album instead of tagging",
+					"default":     false,
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Report what would be tagged without writing anything",
+					"default":     false,
+				},
+			},
+			Required: []string{"tags"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AlbumName  string   `json:"albumName"`
+			AlbumID    string   `json:"albumId"`
+			Tags       []string `json:"tags"`
+			KeepInSync bool     `json:"keepInSync"`
+			ClearSync  bool     `json:"clearSync"`
+			DryRun     bool     `json:"dryRun"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if len(params.Tags) == 0 && !params.ClearSync {
+			return nil, fmt.Errorf("at least one tag is required")
+		}
+
+		// Resolve the album
+		var albumID, albumName string
+		if params.AlbumID != "" {
+			albumID = params.AlbumID
+			albumName = params.AlbumName
+		} else if params.AlbumName != "" {
+			album, candidates, err := findAlbumInteractive(ctx, s, immichClient, params.AlbumName)
+			if err != nil {
+				return nil, err
+			}
+			if album == nil {
+				return albumCandidatesResult(params.AlbumName, candidates)
+			}
+			albumID = album.ID
+			albumName = album.AlbumName
+		} else {
+			return nil, fmt.Errorf("either albumName or albumId must be provided")
+		}
+
+		if params.ClearSync {
+			if err := store.SetAlbumTags(albumID, nil); err != nil {
+				return nil, fmt.Errorf("failed to clear tag sync rule: %w", err)
+			}
+			return makeMCPResult(map[string]interface{}{
+				"success":   true,
+				"albumID":   albumID,
+				"albumName": albumName,
+				"message":   "Cleared the tag sync rule; existing tags on assets are untouched",
+			})
+		}
+
+		assets, err := immichClient.GetAlbumAssets(ctx, albumID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get album assets: %w", err)
+		}
+
+		result := map[string]interface{}{
+			"albumID":    albumID,
+			"albumName":  albumName,
+			"assetCount": len(assets),
+			"tags":       params.Tags,
+		}
+
+		if params.DryRun {
+			result["dryRun"] = true
+			result["success"] = true
+			result["message"] = fmt.Sprintf("Dry run: would apply %d tags to %d assets", len(params.Tags), len(assets))
+			return makeMCPResult(result)
+		}
+
+		linked := 0
+		if len(assets) > 0 {
+			assetIDs := make([]string, len(assets))
+			for i, asset := range assets {
+				assetIDs[i] = asset.ID
+			}
+			linked, err = applyTagsToAssets(ctx, immichClient, params.Tags, assetIDs)
+			if err != nil {
+				return nil, err
+			}
+		}
+		result["assetsLinked"] = linked
+
+		if params.KeepInSync {
+			if err := store.SetAlbumTags(albumID, params.Tags); err != nil {
+				return nil, fmt.Errorf("failed to persist tag sync rule: %w", err)
+			}
+			result["syncRule"] = true
+		}
+
+		result["success"] = true
+		if params.KeepInSync {
+			result["message"] = fmt.Sprintf("Applied %d tags across %d assets; refreshes will keep tagging new additions", len(params.Tags), len(assets))
+		} else {
+			result["message"] = fmt.Sprintf("Applied %d tags across %d assets", len(params.Tags), len(assets))
+		}
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
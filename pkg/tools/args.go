@@ -0,0 +1,38 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// decodeArgs decodes a tool call's arguments into params. Depending on the
+// transport the arguments arrive either as raw JSON bytes or as an
+// already-decoded value; both shapes funnel through one strict decode, so a
+// malformed or mistyped argument is an error instead of a silently zeroed
+// field. Callers set defaults on params before decoding: only arguments the
+// client actually sent overwrite them, which means an explicit zero wins
+// over a default rather than being clobbered by it. Unknown keys are
+// tolerated because parameter profiles merge their presets into every call
+// regardless of which parameters each tool declares.
+func decodeArgs[T any](request mcp.CallToolRequest, params *T) error {
+	argBytes, ok := request.Params.Arguments.([]byte)
+	if !ok {
+		var err error
+		argBytes, err = json.Marshal(request.Params.Arguments)
+		if err != nil {
+			return fmt.Errorf("invalid parameters: %w", err)
+		}
+	}
+
+	// No arguments at all leaves the defaults untouched
+	if len(argBytes) == 0 || string(argBytes) == "null" {
+		return nil
+	}
+
+	if err := json.Unmarshal(argBytes, params); err != nil {
+		return fmt.Errorf("invalid parameters: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+type decodeArgsParams struct {
+	Name  string `json:"name"`
+	Limit int    `json:"limit"`
+	Dry   bool   `json:"dry"`
+}
+
+func requestWithArguments(args interface{}) mcp.CallToolRequest {
+	var request mcp.CallToolRequest
+	request.Params.Arguments = args
+	return request
+}
+
+func TestDecodeArgsFromBytes(t *testing.T) {
+	t.Parallel()
+
+	var params decodeArgsParams
+	err := decodeArgs(requestWithArguments([]byte(`{"name":"x","limit":5}`)), &params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "x", params.Name)
+	assert.Equal(t, 5, params.Limit)
+}
+
+func TestDecodeArgsFromMap(t *testing.T) {
+	t.Parallel()
+
+	var params decodeArgsParams
+	err := decodeArgs(requestWithArguments(map[string]interface{}{"dry": true}), &params)
+
+	assert.NoError(t, err)
+	assert.True(t, params.Dry)
+}
+
+func TestDecodeArgsKeepsDefaultsForAbsentFields(t *testing.T) {
+	t.Parallel()
+
+	params := decodeArgsParams{Limit: 100}
+	err := decodeArgs(requestWithArguments([]byte(`{"name":"x"}`)), &params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 100, params.Limit)
+}
+
+func TestDecodeArgsExplicitZeroOverridesDefault(t *testing.T) {
+	t.Parallel()
+
+	params := decodeArgsParams{Limit: 100}
+	err := decodeArgs(requestWithArguments([]byte(`{"limit":0}`)), &params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, params.Limit)
+}
+
+func TestDecodeArgsNilArguments(t *testing.T) {
+	t.Parallel()
+
+	params := decodeArgsParams{Limit: 100}
+	err := decodeArgs(requestWithArguments(nil), &params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 100, params.Limit)
+}
+
+func TestDecodeArgsRejectsMistypedField(t *testing.T) {
+	t.Parallel()
+
+	var params decodeArgsParams
+	err := decodeArgs(requestWithArguments([]byte(`{"limit":"many"}`)), &params)
+
+	assert.ErrorContains(t, err, "invalid parameters")
+}
+
+func TestDecodeArgsToleratesUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	// Parameter profiles merge preset keys into every call, so keys a tool
+	// does not declare must not be an error
+	var params decodeArgsParams
+	err := decodeArgs(requestWithArguments([]byte(`{"name":"x","verbosity":"ids"}`)), &params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "x", params.Name)
+}
@@ -0,0 +1,141 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/mcp-immich/pkg/audit"
+	"github.com/yourusername/mcp-immich/pkg/auth"
+)
+
+// auditLog records mutating tool calls; nil when auditing is disabled
+var auditLog *audit.Log
+
+// SetAuditLog wires the persistent audit log for mutating tool calls.
+func SetAuditLog(l *audit.Log) {
+	auditLog = l
+}
+
+// auditIDKeys are the argument names from which affected asset, album, and
+// person IDs are collected into audit entries.
+var auditIDKeys = []string{"assetId", "assetIds", "albumId", "albumIds", "albumName", "personId", "personIds"}
+
+// affectedIDsFromArgs collects the asset/album/person identifiers a call
+// names in its arguments
+func affectedIDsFromArgs(args map[string]interface{}) []string {
+	var ids []string
+	for _, key := range auditIDKeys {
+		switch value := args[key].(type) {
+		case string:
+			if value != "" {
+				ids = append(ids, value)
+			}
+		case []interface{}:
+			for _, item := range value {
+				if id, ok := item.(string); ok && id != "" {
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// AuditMiddleware returns tool middleware that records every destructive
+// tool invocation — arguments, caller identity, affected IDs, and outcome —
+// into the audit log. Recording is best-effort and never fails the call.
+func AuditMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !IsDestructiveTool(request.Params.Name) {
+				return next(ctx, request)
+			}
+
+			began := time.Now()
+			result, err := next(ctx, request)
+
+			entry := audit.Entry{
+				At:         began,
+				Tool:       request.Params.Name,
+				Caller:     auth.CallerIdentity(ctx),
+				Success:    err == nil,
+				DurationMs: time.Since(began).Milliseconds(),
+			}
+			if args := argumentsAsMap(request.Params.Arguments); args != nil {
+				entry.Arguments = args
+				entry.AffectedIDs = affectedIDsFromArgs(args)
+			}
+			if err != nil {
+				entry.Error = err.Error()
+			}
+			if recordErr := auditLog.Record(entry); recordErr != nil {
+				log.Warn().Err(recordErr).Str("tool", entry.Tool).Msg("Failed to record audit entry")
+			}
+
+			return result, err
+		}
+	}
+}
+
+// RegisterAuditTools registers the tool for querying the audit log
+func RegisterAuditTools(s *server.MCPServer) {
+	tool := mcp.Tool{
+		Name:        "getAuditLog",
+		Description: "Query recent entries from the audit log of mutating tool invocations",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"tool": map[string]interface{}{
+					"type":        "string",
+					"description": "Only return entries for this tool",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of entries to return, newest first",
+					"default":     50,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Tool  string `json:"tool"`
+			Limit int    `json:"limit"`
+		}
+
+		// Set defaults
+		params.Limit = 50
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if auditLog == nil {
+			return makeMCPResult(map[string]interface{}{
+				"success": true,
+				"count":   0,
+				"entries": []audit.Entry{},
+				"message": "Audit logging is disabled; set audit_log_path to enable it",
+			})
+		}
+
+		entries, err := auditLog.Tail(params.Limit, params.Tool)
+		if err != nil {
+			return nil, err
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success": true,
+			"count":   len(entries),
+			"entries": entries,
+			"message": fmt.Sprintf("%d audit entries", len(entries)),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
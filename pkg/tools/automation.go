@@ -0,0 +1,222 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// automationGate is the global pause switch and quiet-hours window for
+// background automation (the live-album scheduler, hygiene monitors). Both
+// window values are minutes past midnight; unconfigured means no quiet
+// hours.
+var automationGate struct {
+	mu          sync.Mutex
+	paused      bool
+	pausedUntil time.Time
+	reason      string
+
+	quietConfigured bool
+	quietStart      int
+	quietEnd        int
+}
+
+// SetQuietHours restricts background automation to outside the daily window
+// between start and end ("HH:MM"). Empty strings clear the window. Windows
+// may wrap past midnight (e.g. 19:00-23:00 or 22:00-06:00).
+func SetQuietHours(start, end string) error {
+	if start == "" && end == "" {
+		automationGate.quietConfigured = false
+		return nil
+	}
+
+	startMin, err := parseClockMinutes(start)
+	if err != nil {
+		return fmt.Errorf("invalid quiet hours start: %w", err)
+	}
+	endMin, err := parseClockMinutes(end)
+	if err != nil {
+		return fmt.Errorf("invalid quiet hours end: %w", err)
+	}
+
+	automationGate.quietConfigured = true
+	automationGate.quietStart = startMin
+	automationGate.quietEnd = endMin
+	return nil
+}
+
+// CheckAutomationAllowed reports whether background automation may run right
+// now, returning an error that says why not (paused, or inside quiet hours).
+// Interactive tool calls are never gated — only scheduled work consults this.
+func CheckAutomationAllowed(now time.Time) error {
+	automationGate.mu.Lock()
+	paused := automationGate.paused
+	pausedUntil := automationGate.pausedUntil
+	reason := automationGate.reason
+	// An expired timed pause resumes automatically
+	if paused && !pausedUntil.IsZero() && now.After(pausedUntil) {
+		automationGate.paused = false
+		automationGate.pausedUntil = time.Time{}
+		automationGate.reason = ""
+		paused = false
+	}
+	automationGate.mu.Unlock()
+
+	if paused {
+		if reason != "" {
+			return fmt.Errorf("automation is paused: %s", reason)
+		}
+		return fmt.Errorf("automation is paused")
+	}
+
+	if automationGate.quietConfigured {
+		minutes := now.Hour()*60 + now.Minute()
+		inQuiet := false
+		if automationGate.quietStart <= automationGate.quietEnd {
+			inQuiet = minutes >= automationGate.quietStart && minutes < automationGate.quietEnd
+		} else {
+			// Window wraps past midnight
+			inQuiet = minutes >= automationGate.quietStart || minutes < automationGate.quietEnd
+		}
+		if inQuiet {
+			return fmt.Errorf("quiet hours are in effect (%02d:%02d-%02d:%02d)",
+				automationGate.quietStart/60, automationGate.quietStart%60,
+				automationGate.quietEnd/60, automationGate.quietEnd%60)
+		}
+	}
+
+	return nil
+}
+
+// automationStatus describes the gate for tool results and health reporting
+func automationStatus() map[string]interface{} {
+	automationGate.mu.Lock()
+	defer automationGate.mu.Unlock()
+
+	status := map[string]interface{}{
+		"paused": automationGate.paused,
+	}
+	if automationGate.paused {
+		if automationGate.reason != "" {
+			status["reason"] = automationGate.reason
+		}
+		if !automationGate.pausedUntil.IsZero() {
+			status["pausedUntil"] = automationGate.pausedUntil.Format(time.RFC3339)
+		}
+	}
+	if automationGate.quietConfigured {
+		status["quietHours"] = fmt.Sprintf("%02d:%02d-%02d:%02d",
+			automationGate.quietStart/60, automationGate.quietStart%60,
+			automationGate.quietEnd/60, automationGate.quietEnd%60)
+	}
+	return status
+}
+
+// AutomationStatus exposes the gate state for health reporting.
+func AutomationStatus() map[string]interface{} {
+	return automationStatus()
+}
+
+// RegisterAutomationControls registers the pause/resume tools over the
+// global automation gate.
+func RegisterAutomationControls(s *server.MCPServer) {
+	registerPauseAutomation(s)
+	registerResumeAutomation(s)
+}
+
+// registerPauseAutomation registers the tool that halts background
+// automation until resumed (or for a bounded number of hours).
+func registerPauseAutomation(s *server.MCPServer) {
+	tool := mcp.Tool{
+		Name:        "pauseAutomation",
+		Description: "Pause all background automation (schedulers, hygiene monitors) until resumeAutomation is called or an optional duration elapses; interactive tool calls are unaffected",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"hours": map[string]interface{}{
+					"type":        "number",
+					"description": "Resume automatically after this many hours (0 to pause until resumed)",
+					"default":     0,
+				},
+				"reason": map[string]interface{}{
+					"type":        "string",
+					"description": "Why automation is paused, shown in status reports",
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Hours  float64 `json:"hours"`
+			Reason string  `json:"reason"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		automationGate.mu.Lock()
+		automationGate.paused = true
+		automationGate.reason = params.Reason
+		if params.Hours > 0 {
+			automationGate.pausedUntil = time.Now().Add(time.Duration(params.Hours * float64(time.Hour)))
+		} else {
+			automationGate.pausedUntil = time.Time{}
+		}
+		automationGate.mu.Unlock()
+
+		publishEvent("automation_paused", map[string]interface{}{"reason": params.Reason})
+
+		message := "Background automation paused until resumeAutomation is called"
+		if params.Hours > 0 {
+			message = fmt.Sprintf("Background automation paused for %.1f hours", params.Hours)
+		}
+		return makeMCPResult(map[string]interface{}{
+			"success":    true,
+			"automation": automationStatus(),
+			"message":    message,
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// registerResumeAutomation registers the tool that lifts the pause.
+func registerResumeAutomation(s *server.MCPServer) {
+	tool := mcp.Tool{
+		Name:        "resumeAutomation",
+		Description: "Resume background automation after pauseAutomation; quiet hours from the configuration still apply",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		automationGate.mu.Lock()
+		wasPaused := automationGate.paused
+		automationGate.paused = false
+		automationGate.pausedUntil = time.Time{}
+		automationGate.reason = ""
+		automationGate.mu.Unlock()
+
+		publishEvent("automation_resumed", nil)
+
+		message := "Background automation resumed"
+		if !wasPaused {
+			message = "Background automation was not paused"
+		}
+		return makeMCPResult(map[string]interface{}{
+			"success":    true,
+			"automation": automationStatus(),
+			"message":    message,
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
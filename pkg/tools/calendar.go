@@ -0,0 +1,396 @@
+package tools
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// calendarEvent is a single VEVENT parsed from an ICS source
+type calendarEvent struct {
+	Summary string    `json:"summary"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	AllDay  bool      `json:"allDay"`
+}
+
+// registerCreateAlbumsFromCalendar registers the calendar-driven album creation tool
+func registerCreateAlbumsFromCalendar(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "createAlbumsFromCalendar",
+		Description: "Match calendar events (from an ICS URL or file) to photo date ranges and build per-event albums",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"icsUrl": map[string]interface{}{
+					"type":        "string",
+					"description": "HTTP(S) URL of an ICS calendar",
+				},
+				"icsPath": map[string]interface{}{
+					"type":        "string",
+					"description": "Local file path of an ICS calendar (alternative to icsUrl)",
+				},
+				"eventsAfter": map[string]interface{}{
+					"type":        "string",
+					"format":      "date-time",
+					"description": "Only consider events starting after this date (ISO 8601)",
+				},
+				"eventsBefore": map[string]interface{}{
+					"type":        "string",
+					"format":      "date-time",
+					"description": "Only consider events starting before this date (ISO 8601)",
+				},
+				"albumPrefix": map[string]interface{}{
+					"type":        "string",
+					"description": "Prefix for created album names",
+					"default":     "",
+				},
+				"minAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Minimum matching assets required to create an album for an event",
+					"default":     3,
+				},
+				"maxAssetsPerEvent": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum assets to add per event album",
+					"default":     500,
+				},
+				"paddingHours": map[string]interface{}{
+					"type":        "integer",
+					"description": "Hours to extend the event window on each side when matching photos",
+					"default":     0,
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Report event/photo matches without creating albums",
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			IcsURL            string `json:"icsUrl"`
+			IcsPath           string `json:"icsPath"`
+			EventsAfter       string `json:"eventsAfter"`
+			EventsBefore      string `json:"eventsBefore"`
+			AlbumPrefix       string `json:"albumPrefix"`
+			MinAssets         int    `json:"minAssets"`
+			MaxAssetsPerEvent int    `json:"maxAssetsPerEvent"`
+			PaddingHours      int    `json:"paddingHours"`
+			DryRun            bool   `json:"dryRun"`
+		}
+
+		// Set defaults
+		params.MinAssets = 3
+		params.MaxAssetsPerEvent = 500
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if params.IcsURL == "" && params.IcsPath == "" {
+			return nil, fmt.Errorf("either icsUrl or icsPath must be provided")
+		}
+
+		// Load calendar
+		events, err := loadCalendarEvents(ctx, params.IcsURL, params.IcsPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load calendar: %w", err)
+		}
+
+		// Filter events by date window
+		events = filterEventsByWindow(events, params.EventsAfter, params.EventsBefore)
+
+		if len(events) == 0 {
+			return makeMCPResult(map[string]interface{}{
+				"success": true,
+				"message": "No calendar events found in the requested window",
+			})
+		}
+
+		// Existing albums (for find-or-skip)
+		albums, err := immichClient.ListAlbums(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list albums: %w", err)
+		}
+		albumsByName := map[string]string{}
+		for _, album := range albums {
+			albumsByName[album.AlbumName] = album.ID
+		}
+
+		padding := time.Duration(params.PaddingHours) * time.Hour
+
+		type eventReport struct {
+			Event       string `json:"event"`
+			Start       string `json:"start"`
+			End         string `json:"end"`
+			AlbumName   string `json:"albumName"`
+			FoundAssets int    `json:"foundAssets"`
+			AddedAssets int    `json:"addedAssets,omitempty"`
+			Skipped     string `json:"skipped,omitempty"`
+		}
+
+		reports := []eventReport{}
+		albumsCreated := 0
+
+		for _, event := range events {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			albumName := params.AlbumPrefix + event.Summary
+			report := eventReport{
+				Event:     event.Summary,
+				Start:     event.Start.Format(time.RFC3339),
+				End:       event.End.Format(time.RFC3339),
+				AlbumName: albumName,
+			}
+
+			// Stream matches, keeping only the IDs
+			var assetIDs []string
+			err := immichClient.SmartSearchStream(ctx, immich.SmartSearchParams{
+				TakenAfter:  event.Start.Add(-padding).Format(time.RFC3339),
+				TakenBefore: event.End.Add(padding).Format(time.RFC3339),
+				Size:        params.MaxAssetsPerEvent,
+			}, func(asset immich.Asset) error {
+				assetIDs = append(assetIDs, asset.ID)
+				return nil
+			})
+			if err != nil {
+				report.Skipped = fmt.Sprintf("search failed: %v", err)
+				reports = append(reports, report)
+				continue
+			}
+
+			report.FoundAssets = len(assetIDs)
+
+			if len(assetIDs) < params.MinAssets {
+				report.Skipped = fmt.Sprintf("only %d assets (minAssets=%d)", len(assetIDs), params.MinAssets)
+				reports = append(reports, report)
+				continue
+			}
+
+			if params.DryRun {
+				reports = append(reports, report)
+				continue
+			}
+
+			// Find or create the event album
+			albumID, found := albumsByName[albumName]
+			if !found {
+				newAlbum, err := immichClient.CreateAlbum(ctx, immich.CreateAlbumParams{
+					Name:        albumName,
+					Description: fmt.Sprintf("Photos from calendar event '%s' (%s)", event.Summary, event.Start.Format("2006-01-02")),
+				})
+				if err != nil {
+					report.Skipped = fmt.Sprintf("album creation failed: %v", err)
+					reports = append(reports, report)
+					continue
+				}
+				albumID = newAlbum.ID
+				albumsByName[albumName] = albumID
+				albumsCreated++
+			}
+
+			if err := checkAlbumLock(albumID, albumName); err != nil {
+				report.Skipped = err.Error()
+				reports = append(reports, report)
+				continue
+			}
+
+			bulkResult, err := immichClient.AddAssetsToAlbum(ctx, albumID, assetIDs)
+			if err != nil {
+				report.Skipped = fmt.Sprintf("adding assets failed: %v", err)
+				reports = append(reports, report)
+				continue
+			}
+
+			report.AddedAssets = len(bulkResult.Success)
+			reports = append(reports, report)
+		}
+
+		result := map[string]interface{}{
+			"success":     true,
+			"totalEvents": len(events),
+			"events":      reports,
+		}
+
+		if params.DryRun {
+			result["dryRun"] = true
+			result["message"] = fmt.Sprintf("Dry run: matched %d calendar events against the library", len(events))
+		} else {
+			result["albumsCreated"] = albumsCreated
+			result["message"] = fmt.Sprintf("Processed %d calendar events, created %d new albums", len(events), albumsCreated)
+		}
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// loadCalendarEvents fetches and parses an ICS calendar from a URL or local file
+func loadCalendarEvents(ctx context.Context, icsURL, icsPath string) ([]calendarEvent, error) {
+	var reader io.ReadCloser
+
+	if icsURL != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, icsURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("calendar fetch failed with status %d", resp.StatusCode)
+		}
+		reader = resp.Body
+	} else {
+		f, err := os.Open(icsPath)
+		if err != nil {
+			return nil, err
+		}
+		reader = f
+	}
+	defer reader.Close()
+
+	return parseICS(reader)
+}
+
+// parseICS parses VEVENT blocks from an ICS stream. It handles line folding,
+// all-day (VALUE=DATE) events, and UTC or floating timestamps; TZID-qualified
+// times are interpreted in the server's local zone.
+func parseICS(r io.Reader) ([]calendarEvent, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	// Unfold continuation lines (RFC 5545: folded lines start with space or tab)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += strings.TrimLeft(line, " \t")
+		} else {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var events []calendarEvent
+	var current *calendarEvent
+
+	for _, line := range lines {
+		switch {
+		case line == "BEGIN:VEVENT":
+			current = &calendarEvent{}
+		case line == "END:VEVENT":
+			if current != nil && !current.Start.IsZero() {
+				if current.End.IsZero() {
+					if current.AllDay {
+						current.End = current.Start.Add(24 * time.Hour)
+					} else {
+						current.End = current.Start.Add(time.Hour)
+					}
+				}
+				events = append(events, *current)
+			}
+			current = nil
+		case current == nil:
+			continue
+		case strings.HasPrefix(line, "SUMMARY"):
+			if idx := strings.Index(line, ":"); idx >= 0 {
+				current.Summary = unescapeICSText(line[idx+1:])
+			}
+		case strings.HasPrefix(line, "DTSTART"):
+			t, allDay, err := parseICSTime(line)
+			if err == nil {
+				current.Start = t
+				current.AllDay = allDay
+			}
+		case strings.HasPrefix(line, "DTEND"):
+			t, _, err := parseICSTime(line)
+			if err == nil {
+				current.End = t
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// parseICSTime parses a DTSTART/DTEND property line into a timestamp
+func parseICSTime(line string) (time.Time, bool, error) {
+	idx := strings.Index(line, ":")
+	if idx < 0 {
+		return time.Time{}, false, fmt.Errorf("malformed property: %s", line)
+	}
+	value := strings.TrimSpace(line[idx+1:])
+	props := line[:idx]
+
+	// All-day event (date only)
+	if strings.Contains(props, "VALUE=DATE") || len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, time.Local)
+		return t, true, err
+	}
+
+	// UTC timestamp
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+
+	// Floating or TZID-qualified timestamp: use local time
+	t, err := time.ParseInLocation("20060102T150405", value, time.Local)
+	return t, false, err
+}
+
+// unescapeICSText reverses RFC 5545 text escaping
+func unescapeICSText(s string) string {
+	replacer := strings.NewReplacer("\\n", "\n", "\\N", "\n", "\\,", ",", "\\;", ";", "\\\\", "\\")
+	return replacer.Replace(s)
+}
+
+// filterEventsByWindow drops events outside the requested start-date window
+func filterEventsByWindow(events []calendarEvent, after, before string) []calendarEvent {
+	var afterT, beforeT time.Time
+	if after != "" {
+		if t, err := time.Parse(time.RFC3339, after); err == nil {
+			afterT = t
+		}
+	}
+	if before != "" {
+		if t, err := time.Parse(time.RFC3339, before); err == nil {
+			beforeT = t
+		}
+	}
+
+	filtered := events[:0]
+	for _, event := range events {
+		if !afterT.IsZero() && event.Start.Before(afterT) {
+			continue
+		}
+		if !beforeT.IsZero() && event.Start.After(beforeT) {
+			continue
+		}
+		filtered = append(filtered, event)
+	}
+	return filtered
+}
@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseICS(t *testing.T) {
+	// Folded lines, escaped text, UTC, all-day, and defaulted DTEND, per
+	// RFC 5545; the property outside any VEVENT must be ignored
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"SUMMARY:not an event",
+		"BEGIN:VEVENT",
+		"SUMMARY:Beach trip with th",
+		" e family\\, day 1",
+		"DTSTART:20260704T100000Z",
+		"DTEND:20260704T160000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Birthday",
+		"DTSTART;VALUE=DATE:20260815",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Quick call",
+		"DTSTART:20260704T090000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:No start, skipped",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, err := parseICS(strings.NewReader(ics))
+	if err != nil {
+		t.Fatalf("parseICS failed: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("parseICS returned %d events, want 3", len(events))
+	}
+
+	trip := events[0]
+	if trip.Summary != "Beach trip with the family, day 1" {
+		t.Errorf("folded/escaped summary = %q", trip.Summary)
+	}
+	wantStart := time.Date(2026, 7, 4, 10, 0, 0, 0, time.UTC)
+	if !trip.Start.Equal(wantStart) || trip.AllDay {
+		t.Errorf("UTC event start = %s (allDay=%v), want %s", trip.Start, trip.AllDay, wantStart)
+	}
+	if !trip.End.Equal(wantStart.Add(6 * time.Hour)) {
+		t.Errorf("UTC event end = %s", trip.End)
+	}
+
+	birthday := events[1]
+	if !birthday.AllDay {
+		t.Error("VALUE=DATE event not flagged all-day")
+	}
+	if got := birthday.End.Sub(birthday.Start); got != 24*time.Hour {
+		t.Errorf("all-day event without DTEND spans %s, want 24h", got)
+	}
+
+	call := events[2]
+	if got := call.End.Sub(call.Start); got != time.Hour {
+		t.Errorf("timed event without DTEND spans %s, want 1h", got)
+	}
+}
+
+func TestParseICSTime(t *testing.T) {
+	cases := []struct {
+		name   string
+		line   string
+		want   time.Time
+		allDay bool
+		err    bool
+	}{
+		{"UTC timestamp", "DTSTART:20260704T100000Z", time.Date(2026, 7, 4, 10, 0, 0, 0, time.UTC), false, false},
+		{"date-only value", "DTSTART;VALUE=DATE:20260815", time.Date(2026, 8, 15, 0, 0, 0, 0, time.Local), true, false},
+		{"bare date without VALUE param", "DTSTART:20260815", time.Date(2026, 8, 15, 0, 0, 0, 0, time.Local), true, false},
+		{"TZID-qualified uses local zone", "DTSTART;TZID=Europe/Oslo:20260704T100000", time.Date(2026, 7, 4, 10, 0, 0, 0, time.Local), false, false},
+		{"missing colon", "DTSTART", time.Time{}, false, true},
+		{"garbage value", "DTSTART:not-a-time", time.Time{}, false, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, allDay, err := parseICSTime(tc.line)
+			if tc.err {
+				if err == nil {
+					t.Errorf("parseICSTime(%q) succeeded, want error", tc.line)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseICSTime(%q) failed: %v", tc.line, err)
+			}
+			if !got.Equal(tc.want) || allDay != tc.allDay {
+				t.Errorf("parseICSTime(%q) = %s, allDay=%v; want %s, allDay=%v", tc.line, got, allDay, tc.want, tc.allDay)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// Supported sample output formats. Cards render each asset as a compact
+// one-liner, which reads much better when results are shown directly to
+// end users; JSON keeps the structured objects.
+const (
+	SampleFormatJSON  = "json"
+	SampleFormatCards = "cards"
+)
+
+var sampleFormat = SampleFormatJSON
+
+// SetSampleFormat sets the server-wide sample output format. Unknown values
+// are ignored and the JSON format is kept.
+func SetSampleFormat(format string) {
+	switch format {
+	case SampleFormatJSON, SampleFormatCards:
+		sampleFormat = format
+	}
+}
+
+// formatAssetCard renders one asset as a compact single-line card:
+// filename • date • place • people • size. Empty segments are omitted.
+func formatAssetCard(asset immich.Asset) string {
+	segments := []string{asset.OriginalFileName}
+
+	date := asset.FileCreatedAt
+	if asset.ExifInfo != nil && asset.ExifInfo.DateTimeOriginal != "" {
+		if parsed, err := time.Parse(time.RFC3339, asset.ExifInfo.DateTimeOriginal); err == nil {
+			date = parsed
+		}
+	}
+	if !date.IsZero() {
+		segments = append(segments, date.Format("2006-01-02"))
+	}
+
+	if asset.ExifInfo != nil {
+		var place []string
+		if asset.ExifInfo.City != "" {
+			place = append(place, asset.ExifInfo.City)
+		}
+		if asset.ExifInfo.Country != "" {
+			place = append(place, asset.ExifInfo.Country)
+		}
+		if len(place) > 0 {
+			segments = append(segments, strings.Join(place, ", "))
+		}
+	}
+
+	var people []string
+	for _, person := range asset.People {
+		if person.Name != "" && !person.IsHidden {
+			people = append(people, person.Name)
+		}
+	}
+	if len(people) > 0 {
+		segments = append(segments, strings.Join(people, ", "))
+	}
+
+	size := asset.FileSize
+	if size == 0 && asset.ExifInfo != nil {
+		size = asset.ExifInfo.FileSizeInByte
+	}
+	if size > 0 {
+		segments = append(segments, humanSize(size))
+	}
+
+	return strings.Join(segments, " • ")
+}
+
+// formatAssetSamples renders up to limit assets in the configured sample
+// format: compact cards or small JSON objects.
+func formatAssetSamples(assets []immich.Asset, limit int) interface{} {
+	if limit > len(assets) {
+		limit = len(assets)
+	}
+
+	if sampleFormat == SampleFormatCards {
+		cards := make([]string, 0, limit)
+		for i := 0; i < limit; i++ {
+			cards = append(cards, formatAssetCard(assets[i]))
+		}
+		return cards
+	}
+
+	sampleData := make([]map[string]interface{}, 0, limit)
+	for i := 0; i < limit; i++ {
+		asset := assets[i]
+		sampleData = append(sampleData, map[string]interface{}{
+			"id":       asset.ID,
+			"fileName": asset.OriginalFileName,
+			"type":     asset.Type,
+			"date":     asset.FileCreatedAt,
+		})
+	}
+	return sampleData
+}
+
+// humanSize renders a byte count in a readable unit
+func humanSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
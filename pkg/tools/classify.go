@@ -0,0 +1,223 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/classify"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// RegisterClassifierTools registers tools backed by the external ML classifier.
+// Called only when a classifier is configured.
+func RegisterClassifierTools(s *server.MCPServer, immichClient *immich.Client, classifier *classify.Classifier) {
+	registerClassifyAssets(s, immichClient, classifier)
+	registerSearchByCustomLabel(s, immichClient, classifier)
+	registerTriageNonPhotos(s, immichClient, classifier)
+}
+
+// classifyAssets tool
+func registerClassifyAssets(s *server.MCPServer, immichClient *immich.Client, classifier *classify.Classifier) {
+	tool := mcp.Tool{
+		Name:        "classifyAssets",
+		Description: "Run the configured external classifier over asset thumbnails to produce custom labels (e.g. 'document', 'receipt', 'meme'); results are cached locally",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"assetIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Explicit asset IDs to classify",
+				},
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Smart search query selecting assets to classify (alternative to assetIds)",
+				},
+				"maxAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of assets to classify in this call",
+					"default":     100,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AssetIds  []string `json:"assetIds"`
+			Query     string   `json:"query"`
+			MaxAssets int      `json:"maxAssets"`
+		}
+
+		// Set defaults
+		params.MaxAssets = 100
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		assetIDs := params.AssetIds
+		if len(assetIDs) == 0 {
+			if params.Query == "" {
+				return nil, fmt.Errorf("either assetIds or query must be provided")
+			}
+			searchResults, err := immichClient.SmartSearch(ctx, params.Query, params.MaxAssets)
+			if err != nil {
+				return nil, fmt.Errorf("smart search failed: %w", err)
+			}
+			for _, asset := range searchResults {
+				assetIDs = append(assetIDs, asset.ID)
+			}
+		}
+
+		if params.MaxAssets > 0 && len(assetIDs) > params.MaxAssets {
+			assetIDs = assetIDs[:params.MaxAssets]
+		}
+
+		classified := 0
+		failed := 0
+		var errors []string
+		labelCounts := map[string]int{}
+
+		for _, id := range assetIDs {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			// Skip already-cached assets without re-downloading thumbnails
+			if cached, found := classifier.Get(id); found {
+				for _, label := range cached.Labels {
+					labelCounts[label.Name]++
+				}
+				classified++
+				continue
+			}
+
+			thumbnail, err := immichClient.GetAssetThumbnail(ctx, id)
+			if err != nil {
+				failed++
+				errors = append(errors, fmt.Sprintf("%s: thumbnail: %v", id, err))
+				continue
+			}
+
+			result, err := classifier.Classify(ctx, id, thumbnail)
+			if err != nil {
+				failed++
+				errors = append(errors, fmt.Sprintf("%s: %v", id, err))
+				continue
+			}
+
+			for _, label := range result.Labels {
+				labelCounts[label.Name]++
+			}
+			classified++
+		}
+
+		result := map[string]interface{}{
+			"success":     failed == 0,
+			"classified":  classified,
+			"failed":      failed,
+			"labelCounts": labelCounts,
+			"cacheSize":   classifier.Size(),
+		}
+		if len(errors) > 0 {
+			if len(errors) > 10 {
+				errors = errors[:10]
+			}
+			result["errors"] = errors
+		}
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// searchByCustomLabel tool
+func registerSearchByCustomLabel(s *server.MCPServer, immichClient *immich.Client, classifier *classify.Classifier) {
+	tool := mcp.Tool{
+		Name:        "searchByCustomLabel",
+		Description: "Find assets by custom classifier label from the local classification cache (run classifyAssets first)",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"label": map[string]interface{}{
+					"type":        "string",
+					"description": "Custom label to search for",
+				},
+				"minConfidence": map[string]interface{}{
+					"type":        "number",
+					"description": "Minimum classifier confidence (0-1)",
+					"default":     0.5,
+				},
+				"withDetails": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Fetch filename and type for each matching asset",
+					"default":     false,
+				},
+			},
+			Required: []string{"label"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Label         string  `json:"label"`
+			MinConfidence float64 `json:"minConfidence"`
+			WithDetails   bool    `json:"withDetails"`
+		}
+
+		// Set defaults
+		params.MinConfidence = 0.5
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		matches := classifier.Search(params.Label, params.MinConfidence)
+
+		assetIDs := make([]string, len(matches))
+		for i, match := range matches {
+			assetIDs[i] = match.AssetID
+		}
+
+		result := map[string]interface{}{
+			"success":       true,
+			"label":         params.Label,
+			"minConfidence": params.MinConfidence,
+			"foundCount":    len(matches),
+			"assetIds":      assetIDs,
+			"cacheSize":     classifier.Size(),
+		}
+
+		if params.WithDetails {
+			sampleSize := 10
+			if len(matches) < sampleSize {
+				sampleSize = len(matches)
+			}
+			sampleData := []map[string]interface{}{}
+			for i := 0; i < sampleSize; i++ {
+				asset, err := immichClient.GetAssetMetadata(ctx, matches[i].AssetID)
+				if err != nil {
+					continue
+				}
+				sampleData = append(sampleData, map[string]interface{}{
+					"id":       asset.ID,
+					"fileName": asset.OriginalFileName,
+					"type":     asset.Type,
+					"labels":   matches[i].Labels,
+				})
+			}
+			result["sampleResults"] = sampleData
+		}
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
@@ -0,0 +1,170 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// registerFindCrossAlbumDuplicates registers the cross-album dedupe tool
+func registerFindCrossAlbumDuplicates(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "findCrossAlbumDuplicates",
+		Description: "Find visually identical assets that were curated into different albums (matched by checksum, then thumbhash), reporting clusters whose groups can be fed to resolveDuplicates for consolidation",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"maxClusters": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum clusters to return",
+					"default":     duplicateGroupLimit,
+				},
+				"includeShared": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Also examine shared albums",
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			MaxClusters   int  `json:"maxClusters"`
+			IncludeShared bool `json:"includeShared"`
+		}
+
+		// Set defaults
+		params.MaxClusters = duplicateGroupLimit
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if params.MaxClusters <= 0 {
+			params.MaxClusters = duplicateGroupLimit
+		}
+
+		albums, err := immichClient.ListAlbums(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list albums: %w", err)
+		}
+
+		// Walk album contents, keying each asset by checksum (exact copies)
+		// or, failing that, thumbhash (near-identical renditions)
+		type clusterMember struct {
+			asset  immich.Asset
+			albums []string
+		}
+		members := map[string]*clusterMember{}
+		clusters := map[string][]string{}
+		clusterKind := map[string]string{}
+		scannedAlbums := 0
+
+		for _, album := range albums {
+			if album.Shared && !params.IncludeShared {
+				continue
+			}
+
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			assets, err := immichClient.GetAlbumAssets(ctx, album.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get assets for album '%s': %w", album.AlbumName, err)
+			}
+			scannedAlbums++
+
+			for _, asset := range assets {
+				member := members[asset.ID]
+				if member == nil {
+					member = &clusterMember{asset: asset}
+					members[asset.ID] = member
+
+					key, kind := "", ""
+					if asset.Checksum != "" {
+						key, kind = "checksum:"+asset.Checksum, "checksum"
+					} else if asset.Thumbhash != "" {
+						key, kind = "thumbhash:"+asset.Thumbhash, "thumbhash"
+					}
+					if key != "" {
+						clusters[key] = append(clusters[key], asset.ID)
+						clusterKind[key] = kind
+					}
+				}
+				member.albums = append(member.albums, album.AlbumName)
+			}
+		}
+
+		// A cluster is redundant curation only when distinct copies of the
+		// same content ended up in more than one album
+		keys := make([]string, 0, len(clusters))
+		for key, ids := range clusters {
+			if len(ids) < 2 {
+				continue
+			}
+			albumSet := map[string]bool{}
+			for _, id := range ids {
+				for _, name := range members[id].albums {
+					albumSet[name] = true
+				}
+			}
+			if len(albumSet) < 2 {
+				continue
+			}
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		totalClusters := len(keys)
+		if len(keys) > params.MaxClusters {
+			keys = keys[:params.MaxClusters]
+		}
+
+		entries := make([]map[string]interface{}, 0, len(keys))
+		groups := make([][]string, 0, len(keys))
+		for _, key := range keys {
+			ids := clusters[key]
+			sort.Strings(ids)
+			groupAssets := make([]immich.Asset, 0, len(ids))
+			assetEntries := make([]map[string]interface{}, 0, len(ids))
+			for _, id := range ids {
+				member := members[id]
+				groupAssets = append(groupAssets, member.asset)
+				sort.Strings(member.albums)
+				assetEntries = append(assetEntries, map[string]interface{}{
+					"id":       id,
+					"fileName": member.asset.OriginalFileName,
+					"albums":   member.albums,
+				})
+			}
+			entries = append(entries, map[string]interface{}{
+				"clusterId":     key,
+				"match":         clusterKind[key],
+				"count":         len(ids),
+				"suggestedKeep": pickDuplicateKeeper(groupAssets).ID,
+				"assets":        assetEntries,
+			})
+			groups = append(groups, ids)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":       true,
+			"albumsScanned": scannedAlbums,
+			"totalClusters": totalClusters,
+			"shown":         len(entries),
+			"clusters":      entries,
+			"groups":        groups,
+			"message":       fmt.Sprintf("Found %d cross-album duplicate clusters across %d albums; pass groups to resolveDuplicates to consolidate", totalClusters, scannedAlbums),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
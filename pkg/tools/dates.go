@@ -0,0 +1,273 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/patrickmn/go-cache"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// dateShiftRecord remembers an applied shift so it can be undone later
+type dateShiftRecord struct {
+	ShiftID  string        `json:"shiftId"`
+	Offset   time.Duration `json:"offset"`
+	AssetIDs []string      `json:"assetIds"`
+	Applied  time.Time     `json:"applied"`
+}
+
+// registerShiftAssetDates registers the tool for bulk timestamp correction
+func registerShiftAssetDates(s *server.MCPServer, immichClient *immich.Client, cacheStore *cache.Cache) {
+	tool := mcp.Tool{
+		Name:        "shiftAssetDates",
+		Description: "Apply a time offset to the capture date of all assets matching a filter (e.g. +7h for a camera set to the wrong timezone)",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"offset": map[string]interface{}{
+					"type":        "string",
+					"description": "Time offset as a Go duration (e.g. '7h', '-30m', '26h30m')",
+				},
+				"offsetDays": map[string]interface{}{
+					"type":        "integer",
+					"description": "Additional offset in whole days (can be negative)",
+					"default":     0,
+				},
+				"deviceId": map[string]interface{}{
+					"type":        "string",
+					"description": "Only shift assets from this device ID",
+				},
+				"make": map[string]interface{}{
+					"type":        "string",
+					"description": "Only shift assets from this camera make",
+				},
+				"model": map[string]interface{}{
+					"type":        "string",
+					"description": "Only shift assets from this camera model",
+				},
+				"takenAfter": map[string]interface{}{
+					"type":        "string",
+					"format":      "date-time",
+					"description": "Only shift assets taken after this date (ISO 8601)",
+				},
+				"takenBefore": map[string]interface{}{
+					"type":        "string",
+					"format":      "date-time",
+					"description": "Only shift assets taken before this date (ISO 8601)",
+				},
+				"assetIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Explicit asset IDs to shift (bypasses the search filter)",
+				},
+				"maxAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of assets to shift",
+					"default":     1000,
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Show the old->new timestamp mapping without applying it",
+					"default":     false,
+				},
+				"undoShiftId": map[string]interface{}{
+					"type":        "string",
+					"description": "Undo a previously applied shift by its shiftId (ignores other filters)",
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Offset      string   `json:"offset"`
+			OffsetDays  int      `json:"offsetDays"`
+			DeviceID    string   `json:"deviceId"`
+			Make        string   `json:"make"`
+			Model       string   `json:"model"`
+			TakenAfter  string   `json:"takenAfter"`
+			TakenBefore string   `json:"takenBefore"`
+			AssetIds    []string `json:"assetIds"`
+			MaxAssets   int      `json:"maxAssets"`
+			DryRun      bool     `json:"dryRun"`
+			UndoShiftID string   `json:"undoShiftId"`
+		}
+
+		// Set defaults
+		params.MaxAssets = 1000
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		// Undo path: re-apply the inverse of a recorded shift
+		if params.UndoShiftID != "" {
+			cached, found := cacheStore.Get("dateShift:" + params.UndoShiftID)
+			if !found {
+				return nil, fmt.Errorf("shift '%s' not found (undo records are kept in memory only)", params.UndoShiftID)
+			}
+			record := cached.(dateShiftRecord)
+			return applyDateShift(ctx, immichClient, cacheStore, record.AssetIDs, -record.Offset, params.DryRun)
+		}
+
+		// Parse offset
+		var offset time.Duration
+		if params.Offset != "" {
+			parsed, err := time.ParseDuration(params.Offset)
+			if err != nil {
+				return nil, fmt.Errorf("invalid offset '%s': %w", params.Offset, err)
+			}
+			offset = parsed
+		}
+		offset += time.Duration(params.OffsetDays) * 24 * time.Hour
+		if offset == 0 {
+			return nil, fmt.Errorf("offset must be non-zero (use 'offset' and/or 'offsetDays')")
+		}
+
+		// Resolve target assets
+		assetIDs := params.AssetIds
+		if len(assetIDs) == 0 {
+			// Stream matches, keeping only the IDs
+			err := immichClient.SmartSearchStream(ctx, immich.SmartSearchParams{
+				DeviceId:    params.DeviceID,
+				Make:        params.Make,
+				Model:       params.Model,
+				TakenAfter:  params.TakenAfter,
+				TakenBefore: params.TakenBefore,
+				Size:        params.MaxAssets,
+			}, func(asset immich.Asset) error {
+				assetIDs = append(assetIDs, asset.ID)
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("asset search failed: %w", err)
+			}
+		}
+
+		if len(assetIDs) == 0 {
+			return makeMCPResult(map[string]interface{}{
+				"success": true,
+				"message": "No assets matched the filter",
+			})
+		}
+
+		if params.MaxAssets > 0 && len(assetIDs) > params.MaxAssets {
+			assetIDs = assetIDs[:params.MaxAssets]
+		}
+
+		return applyDateShift(ctx, immichClient, cacheStore, assetIDs, offset, params.DryRun)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// applyDateShift shifts the capture timestamp of each asset by offset,
+// recording the operation so it can be undone within the same process.
+func applyDateShift(ctx context.Context, immichClient *immich.Client, cacheStore *cache.Cache, assetIDs []string, offset time.Duration, dryRun bool) (*mcp.CallToolResult, error) {
+	type shiftEntry struct {
+		ID      string `json:"id"`
+		Name    string `json:"fileName"`
+		OldDate string `json:"oldDate"`
+		NewDate string `json:"newDate"`
+	}
+
+	var mapping []shiftEntry
+	shifted := 0
+	failed := 0
+	var errors []string
+	tombstones := newTombstoneTracker()
+
+	for _, id := range assetIDs {
+		// Check for context cancellation
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+		default:
+		}
+
+		asset, err := immichClient.GetAssetMetadata(ctx, id)
+		if err != nil {
+			// Assets trashed mid-operation are tombstoned, not failures
+			if tombstones.Note(id, err) {
+				continue
+			}
+			failed++
+			errors = append(errors, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+
+		oldDate := asset.FileCreatedAt
+		if asset.ExifInfo != nil && asset.ExifInfo.DateTimeOriginal != "" {
+			if parsed, err := time.Parse(time.RFC3339, asset.ExifInfo.DateTimeOriginal); err == nil {
+				oldDate = parsed
+			}
+		}
+		newDate := oldDate.Add(offset)
+
+		if len(mapping) < 20 || dryRun {
+			mapping = append(mapping, shiftEntry{
+				ID:      asset.ID,
+				Name:    asset.OriginalFileName,
+				OldDate: oldDate.Format(time.RFC3339),
+				NewDate: newDate.Format(time.RFC3339),
+			})
+		}
+
+		if dryRun {
+			continue
+		}
+
+		err = immichClient.UpdateAssetMetadata(ctx, id, map[string]interface{}{
+			"dateTimeOriginal": newDate.Format(time.RFC3339),
+		})
+		if err != nil {
+			if tombstones.Note(id, err) {
+				continue
+			}
+			failed++
+			errors = append(errors, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+		shifted++
+	}
+
+	result := map[string]interface{}{
+		"offset":      offset.String(),
+		"assetCount":  len(assetIDs),
+		"dateMapping": mapping,
+	}
+
+	if dryRun {
+		result["dryRun"] = true
+		result["success"] = true
+		result["message"] = fmt.Sprintf("Dry run: would shift %d assets by %s", len(assetIDs), offset)
+		return makeMCPResult(result)
+	}
+
+	// Record the shift for undo
+	shiftID := fmt.Sprintf("shift-%d", time.Now().UnixNano())
+	cacheStore.Set("dateShift:"+shiftID, dateShiftRecord{
+		ShiftID:  shiftID,
+		Offset:   offset,
+		AssetIDs: assetIDs,
+		Applied:  time.Now(),
+	}, cache.NoExpiration)
+
+	result["shifted"] = shifted
+	result["failed"] = failed
+	result["shiftId"] = shiftID
+	result["success"] = failed == 0
+	result["message"] = fmt.Sprintf("Shifted %d assets by %s (undo with undoShiftId=%s)", shifted, offset, shiftID)
+	if len(errors) > 0 {
+		if len(errors) > 10 {
+			errors = errors[:10]
+		}
+		result["errors"] = errors
+	}
+	tombstones.Report(result)
+
+	return makeMCPResult(result)
+}
@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// Safety monitors for bulk delete runs. A checksum of the asset ID set ties
+// a live run to the dryRun it was approved from, and a failure-rate monitor
+// aborts runs that start failing en masse — an expired key, a permissions
+// change, or a server-side problem mid-run.
+
+// assetSetChecksum fingerprints an asset set by its sorted IDs, so the same
+// membership always yields the same checksum regardless of listing order
+func assetSetChecksum(assets []immich.Asset) string {
+	ids := make([]string, len(assets))
+	for i, asset := range assets {
+		ids[i] = asset.ID
+	}
+	sort.Strings(ids)
+
+	h := sha256.New()
+	for _, id := range ids {
+		h.Write([]byte(id))
+		h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))[:16]
+}
+
+// failureMonitorMinProcessed is how many assets must be processed before
+// the failure-rate monitor can trip, so a single small bad batch at the
+// start doesn't abort a run that would otherwise succeed
+const failureMonitorMinProcessed = 50
+
+// failureRateMonitor tracks the share of processed assets that failed and
+// trips once it exceeds maxPercent
+type failureRateMonitor struct {
+	maxPercent int
+	processed  int
+	failed     int
+}
+
+// record accounts for one batch's outcome
+func (m *failureRateMonitor) record(processed, failed int) {
+	m.processed += processed
+	m.failed += failed
+}
+
+// tripped reports whether the run should abort; a maxPercent of 0 disables
+// the monitor
+func (m *failureRateMonitor) tripped() bool {
+	if m.maxPercent <= 0 || m.processed < failureMonitorMinProcessed {
+		return false
+	}
+	return m.failed*100 > m.processed*m.maxPercent
+}
+
+// rate is the observed failure percentage, for abort messages
+func (m *failureRateMonitor) rate() int {
+	if m.processed == 0 {
+		return 0
+	}
+	return m.failed * 100 / m.processed
+}
@@ -0,0 +1,296 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// composeAssetDescription builds a human-readable description from an asset's
+// EXIF data, location, recognized people, and the albums containing it.
+// Returns "" when there is nothing worth saying.
+func composeAssetDescription(asset *immich.Asset, albumNames []string) string {
+	var parts []string
+
+	// Capture date
+	when := asset.FileCreatedAt
+	if asset.ExifInfo != nil && asset.ExifInfo.DateTimeOriginal != "" {
+		if parsed, err := time.Parse(time.RFC3339, asset.ExifInfo.DateTimeOriginal); err == nil {
+			when = parsed
+		}
+	}
+	subject := "Photo"
+	if asset.Type == "VIDEO" {
+		subject = "Video"
+	}
+	if !when.IsZero() {
+		parts = append(parts, fmt.Sprintf("%s taken on %s", subject, when.Format("January 2, 2006")))
+	} else {
+		parts = append(parts, subject)
+	}
+
+	// Location
+	if asset.ExifInfo != nil {
+		var place []string
+		if asset.ExifInfo.City != "" {
+			place = append(place, asset.ExifInfo.City)
+		}
+		if asset.ExifInfo.State != "" {
+			place = append(place, asset.ExifInfo.State)
+		}
+		if asset.ExifInfo.Country != "" {
+			place = append(place, asset.ExifInfo.Country)
+		}
+		if len(place) > 0 {
+			parts = append(parts, "in "+strings.Join(place, ", "))
+		}
+	}
+
+	// Recognized people
+	var people []string
+	for _, person := range asset.People {
+		if person.Name == "" || person.IsHidden {
+			continue
+		}
+		people = append(people, person.Name)
+	}
+	switch {
+	case len(people) == 1:
+		parts = append(parts, "with "+people[0])
+	case len(people) > 1:
+		parts = append(parts, "with "+strings.Join(people[:len(people)-1], ", ")+" and "+people[len(people)-1])
+	}
+
+	// Camera
+	if asset.ExifInfo != nil && asset.ExifInfo.Model != "" {
+		camera := asset.ExifInfo.Model
+		if asset.ExifInfo.Make != "" && !strings.Contains(camera, asset.ExifInfo.Make) {
+			camera = asset.ExifInfo.Make + " " + camera
+		}
+		parts = append(parts, "using a "+camera)
+	}
+
+	// Album context
+	if len(albumNames) > 0 {
+		quoted := make([]string, len(albumNames))
+		for i, name := range albumNames {
+			quoted[i] = "'" + name + "'"
+		}
+		label := "album"
+		if len(quoted) > 1 {
+			label = "albums"
+		}
+		parts = append(parts, fmt.Sprintf("(in %s %s)", label, strings.Join(quoted, ", ")))
+	}
+
+	// A bare "Photo" with no context is not worth writing back
+	if len(parts) < 2 {
+		return ""
+	}
+	return strings.Join(parts, " ")
+}
+
+// registerGenerateAssetDescriptions registers the tool that writes composed
+// descriptions to assets that are missing one.
+func registerGenerateAssetDescriptions(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "generateAssetDescriptions",
+		Description: "Compose descriptions from EXIF, location, people, and album context for assets missing descriptions and write them in bulk, making the library searchable by Immich's metadata search",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"assetIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Asset IDs to process (use either this or albumName)",
+				},
+				"albumName": map[string]interface{}{
+					"type":        "string",
+					"description": "Process every asset in this album instead of listing IDs",
+				},
+				"overwrite": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Replace existing descriptions instead of only filling in missing ones",
+					"default":     false,
+				},
+				"includeAlbumContext": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Mention the albums containing each asset (one extra API call per asset when assetIds is used)",
+					"default":     true,
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Preview the generated descriptions without writing anything",
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AssetIds            []string `json:"assetIds"`
+			AlbumName           string   `json:"albumName"`
+			Overwrite           bool     `json:"overwrite"`
+			IncludeAlbumContext bool     `json:"includeAlbumContext"`
+			DryRun              bool     `json:"dryRun"`
+		}
+
+		// Set defaults
+		params.IncludeAlbumContext = true
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if len(params.AssetIds) == 0 && params.AlbumName == "" {
+			return nil, fmt.Errorf("either assetIds or albumName is required")
+		}
+
+		// Resolve the album to a list of asset IDs if needed
+		assetIDs := params.AssetIds
+		sourceAlbum := ""
+		if params.AlbumName != "" {
+			albums, err := immichClient.ListAlbums(ctx, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list albums: %w", err)
+			}
+			var albumID string
+			for _, album := range albums {
+				if album.AlbumName == params.AlbumName {
+					albumID = album.ID
+					break
+				}
+			}
+			if albumID == "" {
+				return nil, fmt.Errorf("album '%s' not found", params.AlbumName)
+			}
+			assets, err := immichClient.GetAlbumAssets(ctx, albumID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get album assets: %w", err)
+			}
+			for _, asset := range assets {
+				assetIDs = append(assetIDs, asset.ID)
+			}
+			sourceAlbum = params.AlbumName
+		}
+
+		type descriptionSample struct {
+			AssetID     string `json:"assetId"`
+			FileName    string `json:"fileName"`
+			Description string `json:"description"`
+		}
+
+		tombstones := newTombstoneTracker()
+		errors := []string{}
+		samples := []descriptionSample{}
+		scanned := 0
+		alreadyDescribed := 0
+		generated := 0
+		written := 0
+		skippedEmpty := 0
+
+		for _, assetID := range assetIDs {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			asset, err := immichClient.GetAssetMetadata(ctx, assetID)
+			if err != nil {
+				// Assets trashed mid-operation are tombstoned, not failures
+				if tombstones.Note(assetID, err) {
+					continue
+				}
+				if len(errors) < 10 {
+					errors = append(errors, fmt.Sprintf("failed to get metadata for %s: %v", assetID, err))
+				}
+				continue
+			}
+			scanned++
+
+			if !params.Overwrite && asset.ExifInfo != nil && asset.ExifInfo.Description != "" {
+				alreadyDescribed++
+				continue
+			}
+
+			// Album context: the source album is already known; otherwise
+			// look up which albums contain this asset
+			var albumNames []string
+			if sourceAlbum != "" {
+				albumNames = []string{sourceAlbum}
+			} else if params.IncludeAlbumContext {
+				albums, err := immichClient.GetAlbumsForAsset(ctx, asset.ID)
+				if err == nil {
+					for _, album := range albums {
+						albumNames = append(albumNames, album.AlbumName)
+					}
+				}
+			}
+
+			description := composeAssetDescription(asset, albumNames)
+			if description == "" {
+				skippedEmpty++
+				continue
+			}
+			generated++
+
+			if len(samples) < 10 {
+				samples = append(samples, descriptionSample{
+					AssetID:     asset.ID,
+					FileName:    asset.OriginalFileName,
+					Description: description,
+				})
+			}
+
+			if params.DryRun {
+				continue
+			}
+
+			if err := immichClient.UpdateAssetMetadata(ctx, asset.ID, map[string]interface{}{
+				"description": description,
+			}); err != nil {
+				if tombstones.Note(asset.ID, err) {
+					continue
+				}
+				if len(errors) < 10 {
+					errors = append(errors, fmt.Sprintf("failed to update %s: %v", asset.ID, err))
+				}
+				continue
+			}
+			written++
+		}
+
+		result := map[string]interface{}{
+			"success":            true,
+			"assetsScanned":      scanned,
+			"alreadyDescribed":   alreadyDescribed,
+			"skippedNoMetadata":  skippedEmpty,
+			"generated":          generated,
+			"sampleDescriptions": samples,
+			"dryRun":             params.DryRun,
+		}
+
+		if params.DryRun {
+			result["message"] = fmt.Sprintf("DRY RUN: would write %d descriptions (%d assets already described)", generated, alreadyDescribed)
+		} else {
+			result["written"] = written
+			result["message"] = fmt.Sprintf("Wrote %d descriptions (%d assets already described)", written, alreadyDescribed)
+		}
+		tombstones.Report(result)
+		if len(errors) > 0 {
+			result["errors"] = errors
+		}
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
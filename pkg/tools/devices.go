@@ -0,0 +1,189 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// deviceInventoryEntry aggregates one source device's contribution to the
+// library.
+type deviceInventoryEntry struct {
+	DeviceID    string `json:"deviceId,omitempty"`
+	CameraMake  string `json:"cameraMake,omitempty"`
+	CameraModel string `json:"cameraModel,omitempty"`
+	AssetCount  int    `json:"assetCount"`
+	Images      int    `json:"images"`
+	Videos      int    `json:"videos"`
+	FirstAsset  string `json:"firstAsset,omitempty"`
+	LastAsset   string `json:"lastAsset,omitempty"`
+}
+
+// registerListDevices registers the source-device inventory tool.
+func registerListDevices(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "listDevices",
+		Description: "Aggregate distinct device IDs and camera make/model combinations with asset counts and date ranges, so you can audit which devices have contributed photos",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"groupBy": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"deviceId", "camera"},
+					"description": "Group by upload device ID or by EXIF camera make/model",
+					"default":     "deviceId",
+				},
+				"maxAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of assets to scan (0 for the whole library)",
+					"default":     0,
+				},
+				"asOf": asOfSchema(),
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			GroupBy   string `json:"groupBy"`
+			MaxAssets int    `json:"maxAssets"`
+			AsOf      string `json:"asOf"`
+		}
+
+		// Set defaults
+		params.GroupBy = "deviceId"
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if params.GroupBy != "deviceId" && params.GroupBy != "camera" {
+			return nil, fmt.Errorf("invalid groupBy: %s", params.GroupBy)
+		}
+
+		// An asOf watermark pins the scan to the library state an earlier
+		// analytics call observed
+		var filters immich.MetadataSearchFilters
+		if params.AsOf != "" {
+			if _, err := time.Parse(time.RFC3339, params.AsOf); err != nil {
+				return nil, fmt.Errorf("invalid asOf timestamp: %w", err)
+			}
+			filters.UpdatedBefore = params.AsOf
+		}
+
+		// Page through the library, aggregating as we go
+		devices := map[string]*deviceInventoryEntry{}
+		watermark := &watermarkTracker{}
+		page := 1
+		pageSize := 1000
+		scanned := 0
+
+		for params.MaxAssets == 0 || scanned < params.MaxAssets {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			assetPage, err := immichClient.GetAssetsFiltered(ctx, page, pageSize, filters)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get assets page %d: %w", page, err)
+			}
+
+			for _, asset := range assetPage.Assets {
+				if params.MaxAssets > 0 && scanned >= params.MaxAssets {
+					break
+				}
+				scanned++
+				watermark.observe(asset)
+				recordDeviceAsset(devices, params.GroupBy, asset)
+			}
+
+			if !assetPage.HasNextPage {
+				break
+			}
+			page++
+		}
+
+		// Largest contributors first
+		entries := make([]deviceInventoryEntry, 0, len(devices))
+		for _, entry := range devices {
+			entries = append(entries, *entry)
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].AssetCount > entries[j].AssetCount })
+
+		result := map[string]interface{}{
+			"success":       true,
+			"groupBy":       params.GroupBy,
+			"assetsScanned": scanned,
+			"deviceCount":   len(entries),
+			"devices":       entries,
+			"message":       fmt.Sprintf("Found %d distinct %s groups across %d assets", len(entries), params.GroupBy, scanned),
+		}
+		if params.AsOf != "" {
+			result["asOf"] = params.AsOf
+		}
+		watermark.attach(result)
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// recordDeviceAsset folds one asset into the device inventory keyed by the
+// requested grouping.
+func recordDeviceAsset(devices map[string]*deviceInventoryEntry, groupBy string, asset immich.Asset) {
+	var key string
+	entry := deviceInventoryEntry{}
+	if groupBy == "deviceId" {
+		key = asset.DeviceID
+		if key == "" {
+			key = "(unknown)"
+		}
+		entry.DeviceID = key
+	} else {
+		cameraMake, cameraModel := "", ""
+		if asset.ExifInfo != nil {
+			cameraMake = asset.ExifInfo.Make
+			cameraModel = asset.ExifInfo.Model
+		}
+		if cameraMake == "" && cameraModel == "" {
+			key = "(no camera metadata)"
+		} else {
+			key = strings.TrimSpace(cameraMake + " " + cameraModel)
+		}
+		entry.CameraMake = cameraMake
+		entry.CameraModel = cameraModel
+	}
+
+	existing, found := devices[key]
+	if !found {
+		devices[key] = &entry
+		existing = &entry
+	}
+
+	existing.AssetCount++
+	switch asset.Type {
+	case "IMAGE":
+		existing.Images++
+	case "VIDEO":
+		existing.Videos++
+	}
+
+	if !asset.FileCreatedAt.IsZero() {
+		formatted := asset.FileCreatedAt.Format(time.RFC3339)
+		if existing.FirstAsset == "" || formatted < existing.FirstAsset {
+			existing.FirstAsset = formatted
+		}
+		if existing.LastAsset == "" || formatted > existing.LastAsset {
+			existing.LastAsset = formatted
+		}
+	}
+}
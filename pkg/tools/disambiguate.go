@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// elicitChoice asks the connected client to pick one of several candidate
+// values via MCP elicitation. ok is false when the session doesn't support
+// elicitation or the user declined or cancelled, so callers must fall back
+// to returning the candidates instead of guessing.
+func elicitChoice(ctx context.Context, s *server.MCPServer, message string, candidates []string) (string, bool) {
+	result, err := s.RequestElicitation(ctx, mcp.ElicitationRequest{
+		Params: mcp.ElicitationParams{
+			Message: message,
+			RequestedSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"choice": map[string]interface{}{
+						"type": "string",
+						"enum": candidates,
+					},
+				},
+				"required": []string{"choice"},
+			},
+		},
+	})
+	if err != nil || result == nil || result.Action != mcp.ElicitationResponseActionAccept {
+		return "", false
+	}
+
+	content, ok := result.Content.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	choice, ok := content["choice"].(string)
+	if !ok {
+		return "", false
+	}
+
+	// Only accept one of the offered candidates
+	for _, candidate := range candidates {
+		if candidate == choice {
+			return choice, true
+		}
+	}
+	return "", false
+}
+
+// findAlbumInteractive resolves an album by name. An exact match wins
+// outright; when only similarly named albums exist the client is asked to
+// pick one via elicitation. When elicitation isn't available (or the user
+// declines) the candidate names come back so the tool can return them for
+// an explicit retry instead of guessing.
+func findAlbumInteractive(ctx context.Context, s *server.MCPServer, immichClient *immich.Client, name string) (album *immich.Album, candidates []string, err error) {
+	albums, err := immichClient.ListAlbums(ctx, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list albums: %w", err)
+	}
+
+	byName := map[string]immich.Album{}
+	lowered := strings.ToLower(name)
+	similar := []string{}
+	for _, a := range albums {
+		if a.AlbumName == name {
+			match := a
+			return &match, nil, nil
+		}
+		byName[a.AlbumName] = a
+		if strings.Contains(strings.ToLower(a.AlbumName), lowered) || strings.Contains(lowered, strings.ToLower(a.AlbumName)) {
+			similar = append(similar, a.AlbumName)
+		}
+	}
+
+	if len(similar) == 0 {
+		return nil, nil, fmt.Errorf("album '%s' not found", name)
+	}
+
+	message := fmt.Sprintf("No album is named exactly '%s'; which of these did you mean?", name)
+	if choice, ok := elicitChoice(ctx, s, message, similar); ok {
+		match := byName[choice]
+		return &match, nil, nil
+	}
+
+	return nil, similar, nil
+}
+
+// albumCandidatesResult builds the fallback result returned when an
+// ambiguous album name could not be resolved interactively
+func albumCandidatesResult(name string, candidates []string) (*mcp.CallToolResult, error) {
+	return makeMCPResult(map[string]interface{}{
+		"success":    false,
+		"ambiguous":  true,
+		"candidates": candidates,
+		"message":    fmt.Sprintf("No album is named exactly '%s'; call again with one of the candidate names", name),
+	})
+}
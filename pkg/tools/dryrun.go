@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// forceDryRun forces dry-run behavior for every destructive tool; set from
+// configuration
+var forceDryRun bool
+
+// SetForceDryRun enables global dry-run mode, under which destructive tools
+// only ever report what they would do.
+func SetForceDryRun(enabled bool) {
+	forceDryRun = enabled
+}
+
+// dryRunCtxKey marks a request as dry-run-forced via the X-Dry-Run header
+type dryRunCtxKey struct{}
+
+// WithForcedDryRun returns a context forcing dry-run behavior for this
+// request's tool calls, as selected via the X-Dry-Run header
+func WithForcedDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunCtxKey{}, true)
+}
+
+// dryRunForced reports whether this call must behave as a dry run, either
+// globally or per-request
+func dryRunForced(ctx context.Context) bool {
+	if forceDryRun {
+		return true
+	}
+	forced, _ := ctx.Value(dryRunCtxKey{}).(bool)
+	return forced
+}
+
+// dryRunIncapableTools names the destructive tools with no dry-run form;
+// under forced dry-run mode these refuse to run instead of mutating.
+var dryRunIncapableTools = map[string]bool{
+	"analyzePhotos":          true,
+	"createAlbum":            true,
+	"defineSmartAlbum":       true,
+	"deleteSavedSearch":      true,
+	"deleteSmartAlbum":       true,
+	"findEmptyAlbums":        true,
+	"lockAssets":             true,
+	"mergePeople":            true,
+	"moveAssetsToAlbum":      true,
+	"renameAlbum":            true,
+	"renamePerson":           true,
+	"repairAssets":           true,
+	"restoreRecycleSnapshot": true,
+	"restoreServerState":     true,
+	"rollbackSmartAlbum":     true,
+	"setLiveAlbumSchedule":   true,
+	"setPersonBirthDate":     true,
+	"undoOperation":          true,
+	"unlockAssets":           true,
+	"updateAssetMetadata":    true,
+}
+
+// DryRunMiddleware returns tool middleware that rewrites every destructive
+// call into its dry-run form when dry-run mode is forced, so operators can
+// audit an agent's plan before allowing real changes. Destructive tools
+// without a dry-run form are rejected outright.
+func DryRunMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name := request.Params.Name
+			if !dryRunForced(ctx) || !IsDestructiveTool(name) {
+				return next(ctx, request)
+			}
+			if dryRunIncapableTools[name] {
+				return nil, fmt.Errorf("tool '%s' refuses to run: dry-run mode is forced and it has no dry-run form", name)
+			}
+
+			args := argumentsAsMap(request.Params.Arguments)
+			if args == nil {
+				return nil, fmt.Errorf("tool '%s' refuses to run: dry-run mode is forced and its arguments cannot be rewritten", name)
+			}
+			if name == "organizeLibrary" {
+				// The plan/apply macro's dry-run form is the plan phase
+				args["phase"] = "plan"
+			} else {
+				args["dryRun"] = true
+			}
+			request.Params.Arguments = args
+
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/smartalbums"
+)
+
+// smartAlbumDryRunReport is the per-definition entry in the consolidated
+// dryRunAllSmartAlbums result.
+type smartAlbumDryRunReport struct {
+	Definition   string `json:"definition"`
+	AlbumName    string `json:"albumName"`
+	AlbumID      string `json:"albumId,omitempty"`
+	Matched      int    `json:"matched"`
+	InAlbum      int    `json:"inAlbum"`
+	WouldAdd     int    `json:"wouldAdd"`
+	WouldRemove  int    `json:"wouldRemove"`
+	PinnedKept   int    `json:"pinnedKept,omitempty"`
+	AlbumMissing bool   `json:"albumMissing,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// registerDryRunAllSmartAlbums registers the tool that evaluates every smart
+// album definition concurrently without modifying anything.
+func registerDryRunAllSmartAlbums(s *server.MCPServer, immichClient *immich.Client, store *smartalbums.Store) {
+	tool := mcp.Tool{
+		Name:        "dryRunAllSmartAlbums",
+		Description: "Evaluate every smart album definition concurrently and report potential adds and removals per album without changing anything — useful to sanity check automation after an Immich upgrade",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"concurrency": map[string]interface{}{
+					"type":        "integer",
+					"description": "How many definitions to evaluate in parallel",
+					"minimum":     1,
+					"maximum":     16,
+					"default":     4,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Concurrency int `json:"concurrency"`
+		}
+
+		// Set defaults
+		params.Concurrency = 4
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if params.Concurrency < 1 {
+			params.Concurrency = 1
+		}
+		if params.Concurrency > 16 {
+			params.Concurrency = 16
+		}
+
+		defs := store.List()
+		if len(defs) == 0 {
+			return makeMCPResult(map[string]interface{}{
+				"success": true,
+				"reports": []smartAlbumDryRunReport{},
+				"message": "No smart album definitions stored",
+			})
+		}
+
+		// Resolve album names to IDs once up front
+		albums, err := immichClient.ListAlbums(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list albums: %w", err)
+		}
+		albumsByName := make(map[string]string, len(albums))
+		for _, album := range albums {
+			albumsByName[album.AlbumName] = album.ID
+		}
+
+		reports := make([]smartAlbumDryRunReport, len(defs))
+		sem := make(chan struct{}, params.Concurrency)
+		var wg sync.WaitGroup
+
+		for i, def := range defs {
+			wg.Add(1)
+			go func(i int, def smartalbums.SmartAlbumDefinition) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				reports[i] = dryRunSmartAlbumDefinition(ctx, immichClient, store, def, albumsByName)
+			}(i, def)
+		}
+		wg.Wait()
+
+		totalAdds := 0
+		totalRemovals := 0
+		failed := 0
+		for _, report := range reports {
+			totalAdds += report.WouldAdd
+			totalRemovals += report.WouldRemove
+			if report.Error != "" {
+				failed++
+			}
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":       failed == 0,
+			"definitions":   len(defs),
+			"totalAdds":     totalAdds,
+			"totalRemovals": totalRemovals,
+			"failed":        failed,
+			"reports":       reports,
+			"message":       fmt.Sprintf("Dry run across %d definitions: %d potential adds, %d potential removals", len(defs), totalAdds, totalRemovals),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// dryRunSmartAlbumDefinition evaluates one definition's query against the
+// current album contents, respecting pinned assets.
+func dryRunSmartAlbumDefinition(ctx context.Context, immichClient *immich.Client, store *smartalbums.Store, def smartalbums.SmartAlbumDefinition, albumsByName map[string]string) smartAlbumDryRunReport {
+	report := smartAlbumDryRunReport{
+		Definition: def.Name,
+		AlbumName:  def.AlbumName,
+		AlbumID:    def.AlbumID,
+	}
+
+	query := def.Query
+	if def.MaxAssets > 0 {
+		query.Size = def.MaxAssets
+	}
+
+	matched := make(map[string]bool)
+	err := immichClient.SmartSearchStream(ctx, query, func(asset immich.Asset) error {
+		matched[asset.ID] = true
+		return nil
+	})
+	if err != nil {
+		report.Error = fmt.Sprintf("smart search failed: %v", err)
+		return report
+	}
+	report.Matched = len(matched)
+
+	albumID := def.AlbumID
+	if albumID == "" {
+		albumID = albumsByName[def.AlbumName]
+	}
+	if albumID == "" {
+		// Album doesn't exist yet: everything matched would be added
+		report.AlbumMissing = true
+		report.WouldAdd = len(matched)
+		return report
+	}
+	report.AlbumID = albumID
+
+	current, err := immichClient.GetAlbumAssets(ctx, albumID)
+	if err != nil {
+		report.Error = fmt.Sprintf("failed to get album assets: %v", err)
+		return report
+	}
+	report.InAlbum = len(current)
+
+	pinned := make(map[string]bool)
+	for _, id := range store.PinnedAssets(albumID) {
+		pinned[id] = true
+	}
+
+	inAlbum := make(map[string]bool, len(current))
+	for _, asset := range current {
+		inAlbum[asset.ID] = true
+		if !matched[asset.ID] {
+			// Pinned assets survive full-sync pruning
+			if pinned[asset.ID] {
+				report.PinnedKept++
+				continue
+			}
+			report.WouldRemove++
+		}
+	}
+	for id := range matched {
+		if !inAlbum[id] {
+			report.WouldAdd++
+		}
+	}
+
+	return report
+}
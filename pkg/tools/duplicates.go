@@ -0,0 +1,410 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/bulkops"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// duplicateGroupLimit caps how many groups a single call reports by default
+const duplicateGroupLimit = 50
+
+// duplicateGroup is one cluster of duplicates as the tools report it,
+// regardless of whether the server or the checksum fallback found it
+type duplicateGroup struct {
+	ID     string
+	Assets []immich.Asset
+}
+
+// registerFindDuplicates registers the duplicate detection tool
+func registerFindDuplicates(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "findDuplicates",
+		Description: "Find duplicate assets using Immich's duplicate detection, falling back to an exact checksum comparison across the library when the server has none; feed the groups to resolveDuplicates",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"method": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"auto", "server", "checksum"},
+					"description": "auto tries the server's duplicate clusters first and falls back to the checksum scan; the others force one source",
+					"default":     "auto",
+				},
+				"maxGroups": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum duplicate groups to return",
+					"default":     duplicateGroupLimit,
+				},
+				"maxAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum assets the checksum scan examines (0 for the whole library)",
+					"default":     0,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Method    string `json:"method"`
+			MaxGroups int    `json:"maxGroups"`
+			MaxAssets int    `json:"maxAssets"`
+		}
+
+		// Set defaults
+		params.Method = "auto"
+		params.MaxGroups = duplicateGroupLimit
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if params.MaxGroups <= 0 {
+			params.MaxGroups = duplicateGroupLimit
+		}
+
+		groups, method, err := findDuplicateGroups(ctx, immichClient, params.Method, params.MaxAssets)
+		if err != nil {
+			return nil, err
+		}
+
+		totalGroups := len(groups)
+		totalAssets := 0
+		for _, group := range groups {
+			totalAssets += len(group.Assets)
+		}
+		if len(groups) > params.MaxGroups {
+			groups = groups[:params.MaxGroups]
+		}
+
+		entries := make([]map[string]interface{}, 0, len(groups))
+		for _, group := range groups {
+			entries = append(entries, duplicateGroupEntry(group))
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":     true,
+			"method":      method,
+			"totalGroups": totalGroups,
+			"totalAssets": totalAssets,
+			"shown":       len(entries),
+			"groups":      entries,
+			"message":     fmt.Sprintf("Found %d duplicate groups (%d assets) via %s detection", totalGroups, totalAssets, method),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// registerResolveDuplicates registers the duplicate resolution tool
+func registerResolveDuplicates(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "resolveDuplicates",
+		Description: "Resolve duplicate groups by keeping the highest-resolution copy in each and trashing or stacking the rest; groups come from findDuplicates or are detected on the fly",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"groups": map[string]interface{}{
+					"type": "array",
+					"items": map[string]interface{}{
+						"type":  "array",
+						"items": map[string]interface{}{"type": "string"},
+					},
+					"description": "Duplicate groups as arrays of asset IDs (from findDuplicates); omit to detect and resolve automatically",
+				},
+				"action": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"trash", "stack"},
+					"description": "trash moves the losers to the trash (recoverable); stack keeps them grouped behind the best copy",
+					"default":     "trash",
+				},
+				"maxGroups": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum groups to resolve in one call when detecting automatically",
+					"default":     duplicateGroupLimit,
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Preview which copy each group would keep without modifying anything",
+					"default":     true,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Groups    [][]string `json:"groups"`
+			Action    string     `json:"action"`
+			MaxGroups int        `json:"maxGroups"`
+			DryRun    *bool      `json:"dryRun"`
+		}
+
+		// Set defaults
+		params.Action = "trash"
+		params.MaxGroups = duplicateGroupLimit
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if params.Action != "trash" && params.Action != "stack" {
+			return nil, fmt.Errorf("invalid action: %s (must be trash or stack)", params.Action)
+		}
+		dryRun := params.DryRun == nil || *params.DryRun
+
+		tombstones := newTombstoneTracker()
+		var groups []duplicateGroup
+		if len(params.Groups) > 0 {
+			groups = loadDuplicateGroups(ctx, immichClient, params.Groups, tombstones)
+		} else {
+			detected, _, err := findDuplicateGroups(ctx, immichClient, "auto", 0)
+			if err != nil {
+				return nil, err
+			}
+			if len(detected) > params.MaxGroups {
+				detected = detected[:params.MaxGroups]
+			}
+			groups = detected
+		}
+
+		previews := make([]map[string]interface{}, 0, len(groups))
+		var removeIDs []string
+		resolved := 0
+		stacked := 0
+		var errors []string
+
+		for _, group := range groups {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			if len(group.Assets) < 2 {
+				continue
+			}
+
+			keeper := pickDuplicateKeeper(group.Assets)
+			preview := duplicateGroupEntry(group)
+			preview["action"] = params.Action
+			previews = append(previews, preview)
+
+			losers := make([]string, 0, len(group.Assets)-1)
+			for _, asset := range group.Assets {
+				if asset.ID != keeper.ID {
+					losers = append(losers, asset.ID)
+				}
+			}
+
+			if dryRun {
+				continue
+			}
+
+			switch params.Action {
+			case "trash":
+				removeIDs = append(removeIDs, losers...)
+				resolved++
+			case "stack":
+				// The keeper goes first so it becomes the stack's primary
+				if _, err := immichClient.CreateStack(ctx, append([]string{keeper.ID}, losers...)); err != nil {
+					if len(errors) < 10 {
+						errors = append(errors, fmt.Sprintf("group %s: %v", group.ID, err))
+					}
+					continue
+				}
+				resolved++
+				stacked++
+			}
+		}
+
+		result := map[string]interface{}{
+			"groups":  len(previews),
+			"action":  params.Action,
+			"preview": previews,
+		}
+		tombstones.Report(result)
+
+		if dryRun {
+			result["dryRun"] = true
+			result["success"] = true
+			result["message"] = fmt.Sprintf("DRY RUN: %d duplicate groups would be resolved by %s", len(previews), params.Action)
+			return makeMCPResult(result)
+		}
+
+		if params.Action == "trash" && len(removeIDs) > 0 {
+			// Snapshot the removed IDs so the operation can be undone from
+			// the recycle store
+			snapshotID, err := recordRecycleSnapshot("", "", "resolveDuplicates", removeIDs)
+			if err != nil {
+				return nil, err
+			}
+			if snapshotID != "" {
+				result["recycleSnapshotId"] = snapshotID
+			}
+			if err := immichClient.DeleteAssets(ctx, removeIDs, false); err != nil {
+				return nil, fmt.Errorf("failed to trash duplicates: %w", err)
+			}
+			result["trashed"] = len(removeIDs)
+		}
+		if params.Action == "stack" {
+			result["stacked"] = stacked
+		}
+		if len(errors) > 0 {
+			result["errors"] = errors
+		}
+
+		result["resolved"] = resolved
+		result["success"] = true
+		result["message"] = fmt.Sprintf("Resolved %d duplicate groups by %s, keeping the best copy of each", resolved, params.Action)
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// findDuplicateGroups collects duplicate clusters from the requested source.
+// The auto method prefers the server's similarity-based clusters and falls
+// back to the exact checksum scan when the server reports none.
+func findDuplicateGroups(ctx context.Context, immichClient *immich.Client, method string, maxAssets int) ([]duplicateGroup, string, error) {
+	if method == "auto" || method == "server" {
+		serverGroups, err := immichClient.GetDuplicates(ctx)
+		if err != nil {
+			if method == "server" {
+				return nil, "", err
+			}
+		} else if len(serverGroups) > 0 || method == "server" {
+			groups := make([]duplicateGroup, 0, len(serverGroups))
+			for _, group := range serverGroups {
+				if len(group.Assets) < 2 {
+					continue
+				}
+				groups = append(groups, duplicateGroup{ID: group.DuplicateID, Assets: group.Assets})
+			}
+			return groups, "server", nil
+		}
+	}
+
+	// Exact-duplicate fallback: group the library by file checksum
+	byChecksum := map[string][]immich.Asset{}
+	_, err := bulkops.ScanAssets(ctx, immichClient, bulkops.ScanOptions{
+		Max: maxAssets,
+	}, func(asset immich.Asset) bool {
+		if asset.Checksum != "" {
+			byChecksum[asset.Checksum] = append(byChecksum[asset.Checksum], asset)
+		}
+		return false
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to scan library for duplicates: %w", err)
+	}
+
+	var groups []duplicateGroup
+	for checksum, assets := range byChecksum {
+		if len(assets) < 2 {
+			continue
+		}
+		groups = append(groups, duplicateGroup{ID: "checksum:" + checksum, Assets: assets})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].ID < groups[j].ID })
+	return groups, "checksum", nil
+}
+
+// loadDuplicateGroups hydrates caller-supplied ID groups into assets,
+// tombstoning IDs that can no longer be fetched
+func loadDuplicateGroups(ctx context.Context, immichClient *immich.Client, idGroups [][]string, tombstones *tombstoneTracker) []duplicateGroup {
+	groups := make([]duplicateGroup, 0, len(idGroups))
+	for i, ids := range idGroups {
+		group := duplicateGroup{ID: fmt.Sprintf("group-%d", i+1)}
+		for _, id := range ids {
+			asset, err := immichClient.GetAssetMetadata(ctx, id)
+			if err != nil {
+				tombstones.Note(id, err)
+				continue
+			}
+			group.Assets = append(group.Assets, *asset)
+		}
+		if len(group.Assets) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// pickDuplicateKeeper chooses the copy worth keeping: highest resolution,
+// then largest file, then the earliest upload as a stable tiebreak
+func pickDuplicateKeeper(assets []immich.Asset) immich.Asset {
+	keeper := assets[0]
+	for _, candidate := range assets[1:] {
+		cr, kr := assetResolution(candidate), assetResolution(keeper)
+		if cr != kr {
+			if cr > kr {
+				keeper = candidate
+			}
+			continue
+		}
+		cs, ks := assetSizeBytes(candidate), assetSizeBytes(keeper)
+		if cs != ks {
+			if cs > ks {
+				keeper = candidate
+			}
+			continue
+		}
+		if candidate.FileCreatedAt.Before(keeper.FileCreatedAt) {
+			keeper = candidate
+		}
+	}
+	return keeper
+}
+
+// duplicateGroupEntry renders one group with its suggested keeper for
+// preview output
+func duplicateGroupEntry(group duplicateGroup) map[string]interface{} {
+	keeper := pickDuplicateKeeper(group.Assets)
+	assets := make([]map[string]interface{}, 0, len(group.Assets))
+	for _, asset := range group.Assets {
+		entry := map[string]interface{}{
+			"id":       asset.ID,
+			"fileName": asset.OriginalFileName,
+			"type":     asset.Type,
+		}
+		if res := assetResolution(asset); res > 0 {
+			entry["resolution"] = fmt.Sprintf("%dx%d", asset.ExifInfo.ExifImageWidth, asset.ExifInfo.ExifImageHeight)
+		}
+		if size := assetSizeBytes(asset); size > 0 {
+			entry["sizeBytes"] = size
+		}
+		if asset.IsFavorite {
+			entry["favorite"] = true
+		}
+		assets = append(assets, entry)
+	}
+	return map[string]interface{}{
+		"groupId":       group.ID,
+		"count":         len(group.Assets),
+		"suggestedKeep": keeper.ID,
+		"assets":        assets,
+	}
+}
+
+// assetResolution is the pixel count from EXIF, 0 when unknown
+func assetResolution(asset immich.Asset) int {
+	if asset.ExifInfo == nil {
+		return 0
+	}
+	return asset.ExifInfo.ExifImageWidth * asset.ExifInfo.ExifImageHeight
+}
+
+// assetSizeBytes prefers the EXIF-reported size, falling back to the
+// asset's own size field
+func assetSizeBytes(asset immich.Asset) int64 {
+	if asset.ExifInfo != nil && asset.ExifInfo.FileSizeInByte > 0 {
+		return asset.ExifInfo.FileSizeInByte
+	}
+	return asset.FileSize
+}
@@ -0,0 +1,168 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/smartalbums"
+)
+
+// registerFindEmptyAlbums registers the tool that lists (and can delete)
+// leftover empty albums. Albums targeted by smart album definitions are
+// protected from deletion — they refill on the next refresh.
+func registerFindEmptyAlbums(s *server.MCPServer, immichClient *immich.Client, store *smartalbums.Store) {
+	tool := mcp.Tool{
+		Name:        "findEmptyAlbums",
+		Description: "List albums with zero assets (and optionally stale albums untouched for N months), with a guarded bulk delete for the empty leftovers that agent-driven organization accumulates",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"staleMonths": map[string]interface{}{
+					"type":        "integer",
+					"description": "Also list non-empty albums not updated in this many months (0 to skip)",
+					"default":     0,
+				},
+				"delete": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Delete the empty albums found (stale albums are only ever listed)",
+					"default":     false,
+				},
+				"maxDelete": map[string]interface{}{
+					"type":        "integer",
+					"description": "Refuse to delete more than this many albums in one call",
+					"default":     10,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			StaleMonths int  `json:"staleMonths"`
+			Delete      bool `json:"delete"`
+			MaxDelete   int  `json:"maxDelete"`
+		}
+
+		// Set defaults
+		params.MaxDelete = 10
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		albums, err := immichClient.ListAlbums(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list albums: %w", err)
+		}
+
+		// Albums a smart album definition targets refill on refresh and
+		// must not be cleaned up
+		protectedAlbums := map[string]bool{}
+		for _, def := range store.List() {
+			if def.AlbumID != "" {
+				protectedAlbums[def.AlbumID] = true
+			}
+			if def.AlbumName != "" {
+				protectedAlbums[def.AlbumName] = true
+			}
+		}
+
+		type albumEntry struct {
+			ID         string `json:"id"`
+			Name       string `json:"name"`
+			AssetCount int    `json:"assetCount,omitempty"`
+			UpdatedAt  string `json:"updatedAt"`
+			Shared     bool   `json:"shared,omitempty"`
+			Protected  bool   `json:"protected,omitempty"`
+		}
+
+		var empty []albumEntry
+		var stale []albumEntry
+		staleCutoff := time.Time{}
+		if params.StaleMonths > 0 {
+			staleCutoff = time.Now().AddDate(0, -params.StaleMonths, 0)
+		}
+
+		for _, album := range albums {
+			entry := albumEntry{
+				ID:         album.ID,
+				Name:       album.AlbumName,
+				AssetCount: album.AssetCount,
+				UpdatedAt:  album.UpdatedAt.Format("2006-01-02"),
+				Shared:     album.Shared,
+				Protected:  protectedAlbums[album.ID] || protectedAlbums[album.AlbumName],
+			}
+			if album.AssetCount == 0 {
+				empty = append(empty, entry)
+			} else if params.StaleMonths > 0 && album.UpdatedAt.Before(staleCutoff) {
+				stale = append(stale, entry)
+			}
+		}
+
+		result := map[string]interface{}{
+			"totalAlbums": len(albums),
+			"emptyAlbums": empty,
+			"emptyCount":  len(empty),
+		}
+		if params.StaleMonths > 0 {
+			result["staleAlbums"] = stale
+			result["staleCount"] = len(stale)
+		}
+
+		if !params.Delete {
+			result["success"] = true
+			result["message"] = fmt.Sprintf("Found %d empty albums (of %d total); pass delete=true to remove them", len(empty), len(albums))
+			return makeMCPResult(result)
+		}
+
+		// Guarded bulk delete: never shared, protected, or locked albums,
+		// and never more than maxDelete in one call
+		deletable := []albumEntry{}
+		skipped := 0
+		for _, entry := range empty {
+			if entry.Protected || entry.Shared || checkAlbumLock(entry.ID, entry.Name) != nil {
+				skipped++
+				continue
+			}
+			deletable = append(deletable, entry)
+		}
+		if len(deletable) > params.MaxDelete {
+			return nil, fmt.Errorf("%d empty albums are deletable but maxDelete is %d; raise maxDelete to confirm a bulk delete this large", len(deletable), params.MaxDelete)
+		}
+
+		deleted := 0
+		errors := []string{}
+		for _, entry := range deletable {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			if err := immichClient.DeleteAlbum(ctx, entry.ID); err != nil {
+				if len(errors) < 10 {
+					errors = append(errors, fmt.Sprintf("failed to delete '%s': %v", entry.Name, err))
+				}
+				continue
+			}
+			deleted++
+		}
+
+		result["deleted"] = deleted
+		result["skippedProtected"] = skipped
+		if len(errors) > 0 {
+			result["errors"] = errors
+		}
+		result["success"] = true
+		result["message"] = fmt.Sprintf("Deleted %d empty albums (%d skipped as shared, locked, or smart-album targets)", deleted, skipped)
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
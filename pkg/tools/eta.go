@@ -0,0 +1,48 @@
+package tools
+
+import (
+	"time"
+
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// defaultToolTimeout mirrors the server's default request_timeout; bulk
+// operations expected to run longer than this should go through the async
+// job path instead of a single tool call.
+const defaultToolTimeout = 30 * time.Second
+
+// estimateBulkETA predicts how long a bulk operation needing the given number
+// of API requests will take, based on the client rate limit and observed
+// historical throughput. Intended for inclusion in dryRun results.
+func estimateBulkETA(immichClient *immich.Client, estimatedRequests int) map[string]interface{} {
+	if estimatedRequests < 1 {
+		estimatedRequests = 1
+	}
+
+	// Per-request cost: observed average, floored by the rate limit
+	avgDuration, samples := immichClient.AverageRequestDuration()
+	if avgDuration == 0 {
+		// No history yet: assume a modest default
+		avgDuration = 200 * time.Millisecond
+	}
+	if limit := immichClient.RateLimitPerSecond(); limit > 0 {
+		minInterval := time.Duration(float64(time.Second) / limit)
+		if avgDuration < minInterval {
+			avgDuration = minInterval
+		}
+	}
+
+	estimate := time.Duration(estimatedRequests) * avgDuration
+
+	eta := map[string]interface{}{
+		"estimatedRequests":  estimatedRequests,
+		"estimatedDuration":  estimate.Round(time.Second).String(),
+		"avgRequestMillis":   avgDuration.Milliseconds(),
+		"throughputSamples":  samples,
+		"exceedsToolTimeout": estimate > defaultToolTimeout,
+	}
+	if estimate > defaultToolTimeout {
+		eta["warning"] = "estimated runtime exceeds the typical tool timeout; consider smaller batches or an async job"
+	}
+	return eta
+}
@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"context"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/events"
+)
+
+// eventBus receives automation events for the SSE /events stream; nil until
+// the server wires one in
+var eventBus *events.Bus
+
+// SetEventBus installs the bus tool handlers publish automation events to
+func SetEventBus(bus *events.Bus) {
+	eventBus = bus
+}
+
+// publishEvent emits an automation event; a no-op when no bus is configured
+func publishEvent(eventType string, data map[string]interface{}) {
+	if eventBus == nil {
+		return
+	}
+	eventBus.Publish(eventType, data)
+}
+
+// EventsMiddleware returns tool middleware that publishes a tool_completed
+// event (or tool_failed, with the error) for every call, so dashboards see
+// all automation activity without each tool emitting its own event.
+func EventsMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			began := time.Now()
+			result, err := next(ctx, request)
+
+			data := map[string]interface{}{
+				"tool":       request.Params.Name,
+				"durationMs": time.Since(began).Milliseconds(),
+			}
+			if err != nil {
+				data["error"] = err.Error()
+				publishEvent("tool_failed", data)
+			} else {
+				publishEvent("tool_completed", data)
+			}
+
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/mcp-immich/pkg/exportmeta"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// inlineExportLimit is the largest asset count a single call may return as
+// inline MCP binary content; bigger sets go to the export directory.
+const inlineExportLimit = 10
+
+// bigExportThreshold is the asset count above which the configured export
+// window applies.
+const bigExportThreshold = 100
+
+// exportDir is where archive exports are written; set from configuration
+var exportDir string
+
+// SetExportDir points archive exports at their target directory.
+func SetExportDir(dir string) {
+	exportDir = dir
+}
+
+// exportJob tracks one archive export from submission to completion
+type exportJob struct {
+	ID          string    `json:"id"`
+	Status      string    `json:"status"` // running, completed, failed
+	AssetCount  int       `json:"assetCount"`
+	Path        string    `json:"path,omitempty"`
+	SizeBytes   int64     `json:"sizeBytes,omitempty"`
+	Sidecars    int       `json:"sidecars,omitempty"`
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+var exportJobs = struct {
+	mu   sync.Mutex
+	jobs map[string]*exportJob
+}{jobs: map[string]*exportJob{}}
+
+// exportPhotos tool
+func registerExportPhotos(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "exportPhotos",
+		Description: "Export assets as a ZIP archive: small sets can be returned inline as binary content, larger sets are streamed to the export directory as a tracked job (see getExportStatus)",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"assetIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Assets to export",
+				},
+				"albumId": map[string]interface{}{
+					"type":        "string",
+					"description": "Export an entire album (alternative to assetIds)",
+				},
+				"inline": map[string]interface{}{
+					"type":        "boolean",
+					"description": fmt.Sprintf("Return the archive as inline binary content (at most %d assets)", inlineExportLimit),
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AssetIDs []string `json:"assetIds"`
+			AlbumID  string   `json:"albumId"`
+			Inline   bool     `json:"inline"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		assetIDs := params.AssetIDs
+		if len(assetIDs) == 0 && params.AlbumID != "" {
+			albumAssets, err := immichClient.GetAlbumAssets(ctx, params.AlbumID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get album assets: %w", err)
+			}
+			for _, asset := range albumAssets {
+				assetIDs = append(assetIDs, asset.ID)
+			}
+		}
+		if len(assetIDs) == 0 {
+			return nil, fmt.Errorf("either assetIds or albumId must be provided")
+		}
+
+		// Big exports respect the configured export window
+		if len(assetIDs) > bigExportThreshold {
+			if err := checkExportWindow(time.Now()); err != nil {
+				return nil, err
+			}
+		}
+
+		if params.Inline {
+			if len(assetIDs) > inlineExportLimit {
+				return nil, fmt.Errorf("inline exports are limited to %d assets (%d requested); omit inline to export to the export directory", inlineExportLimit, len(assetIDs))
+			}
+			return inlineExportResult(ctx, immichClient, assetIDs)
+		}
+
+		if exportDir == "" {
+			return nil, fmt.Errorf("no export directory configured; set export_dir or use inline for small sets")
+		}
+		if err := os.MkdirAll(exportDir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create export directory: %w", err)
+		}
+
+		job := &exportJob{
+			ID:         fmt.Sprintf("export-%d", time.Now().UnixNano()),
+			Status:     "running",
+			AssetCount: len(assetIDs),
+			StartedAt:  time.Now(),
+		}
+		job.Path = filepath.Join(exportDir, job.ID+".zip")
+		exportJobs.mu.Lock()
+		exportJobs.jobs[job.ID] = job
+		exportJobs.mu.Unlock()
+
+		// The archive streams in the background, detached from the request,
+		// so the tool call returns immediately with the job ID
+		go runExportJob(immichClient, job, assetIDs)
+
+		return makeMCPResult(map[string]interface{}{
+			"success":  true,
+			"exportId": job.ID,
+			"path":     job.Path,
+			"assets":   len(assetIDs),
+			"message":  fmt.Sprintf("Export %s started for %d assets; poll getExportStatus for completion", job.ID, len(assetIDs)),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// runExportJob streams the archive to disk and optionally writes XMP
+// sidecars with the exported curation metadata
+func runExportJob(immichClient *immich.Client, job *exportJob, assetIDs []string) {
+	ctx := context.Background()
+
+	finish := func(size int64, sidecars int, err error) {
+		exportJobs.mu.Lock()
+		defer exportJobs.mu.Unlock()
+		job.CompletedAt = time.Now()
+		job.SizeBytes = size
+		job.Sidecars = sidecars
+		if err != nil {
+			job.Status = "failed"
+			job.Error = err.Error()
+			log.Warn().Err(err).Str("export", job.ID).Msg("Export failed")
+			return
+		}
+		job.Status = "completed"
+	}
+
+	file, err := os.Create(job.Path)
+	if err != nil {
+		finish(0, 0, fmt.Errorf("failed to create archive: %w", err))
+		return
+	}
+	size, err := immichClient.DownloadArchive(ctx, assetIDs, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(job.Path)
+		finish(0, 0, fmt.Errorf("failed to download archive: %w", err))
+		return
+	}
+
+	sidecars := 0
+	if exportEmbedMetadata {
+		sidecars = writeExportSidecars(ctx, immichClient, job.Path, assetIDs)
+	}
+	finish(size, sidecars, nil)
+}
+
+// writeExportSidecars writes one XMP sidecar per exported asset with
+// curation metadata into a directory next to the archive, named after the
+// file each sidecar belongs to inside it
+func writeExportSidecars(ctx context.Context, immichClient *immich.Client, archivePath string, assetIDs []string) int {
+	sidecarDir := archivePath[:len(archivePath)-len(filepath.Ext(archivePath))] + ".metadata"
+	written := 0
+	for _, id := range assetIDs {
+		asset, err := immichClient.GetAssetMetadata(ctx, id)
+		if err != nil {
+			continue
+		}
+		meta := exportmeta.FromAsset(asset)
+		if meta.IsEmpty() {
+			continue
+		}
+		if written == 0 {
+			if err := os.MkdirAll(sidecarDir, 0o755); err != nil {
+				log.Warn().Err(err).Msg("Failed to create export sidecar directory")
+				return 0
+			}
+		}
+		if _, err := exportmeta.WriteSidecar(filepath.Join(sidecarDir, asset.OriginalFileName), meta); err != nil {
+			log.Warn().Err(err).Str("asset", id).Msg("Failed to write export sidecar")
+			continue
+		}
+		written++
+	}
+	return written
+}
+
+// inlineExportResult downloads the archive synchronously and embeds it in
+// the tool result as binary content
+func inlineExportResult(ctx context.Context, immichClient *immich.Client, assetIDs []string) (*mcp.CallToolResult, error) {
+	var buf bytes.Buffer
+	if _, err := immichClient.DownloadArchive(ctx, assetIDs, &buf); err != nil {
+		return nil, fmt.Errorf("failed to download archive: %w", err)
+	}
+
+	result, err := makeMCPResult(map[string]interface{}{
+		"success":   true,
+		"assets":    len(assetIDs),
+		"sizeBytes": buf.Len(),
+		"message":   fmt.Sprintf("Exported %d assets as an inline ZIP archive (%d bytes)", len(assetIDs), buf.Len()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Content = append(result.Content, mcp.NewEmbeddedResource(mcp.BlobResourceContents{
+		URI:      fmt.Sprintf("immich://exports/inline-%d.zip", time.Now().Unix()),
+		MIMEType: "application/zip",
+		Blob:     base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}))
+	return result, nil
+}
+
+// getExportStatus tool
+func registerGetExportStatus(s *server.MCPServer) {
+	tool := mcp.Tool{
+		Name:        "getExportStatus",
+		Description: "Report the status of archive export jobs started by exportPhotos",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"exportId": map[string]interface{}{
+					"type":        "string",
+					"description": "Export job ID; omit to list all jobs from this server run",
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			ExportID string `json:"exportId"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		exportJobs.mu.Lock()
+		defer exportJobs.mu.Unlock()
+
+		if params.ExportID != "" {
+			job, found := exportJobs.jobs[params.ExportID]
+			if !found {
+				return nil, fmt.Errorf("export '%s' not found", params.ExportID)
+			}
+			return makeMCPResult(map[string]interface{}{
+				"success": true,
+				"export":  job,
+				"message": fmt.Sprintf("Export %s is %s", job.ID, job.Status),
+			})
+		}
+
+		jobs := make([]*exportJob, 0, len(exportJobs.jobs))
+		for _, job := range exportJobs.jobs {
+			jobs = append(jobs, job)
+		}
+		sort.Slice(jobs, func(i, j int) bool { return jobs[i].StartedAt.After(jobs[j].StartedAt) })
+
+		return makeMCPResult(map[string]interface{}{
+			"success": true,
+			"count":   len(jobs),
+			"exports": jobs,
+			"message": fmt.Sprintf("%d export jobs this server run", len(jobs)),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
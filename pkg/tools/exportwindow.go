@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"fmt"
+	"time"
+)
+
+// exportEmbedMetadata controls whether exports write curation metadata as
+// XMP sidecars next to exported originals (see pkg/exportmeta); consumed by
+// the export pipeline together with checkExportWindow.
+var exportEmbedMetadata bool
+
+// SetExportMetadataEnabled toggles metadata write-back on export.
+func SetExportMetadataEnabled(enabled bool) {
+	exportEmbedMetadata = enabled
+}
+
+// exportWindow holds the configured scheduling window for big exports.
+// Both values are minutes past midnight; start == end means no window.
+var exportWindow struct {
+	configured bool
+	start      int
+	end        int
+}
+
+// SetExportWindow restricts big exports to the daily window between start
+// and end ("HH:MM"). Empty strings clear the window. Windows may wrap past
+// midnight (e.g. 23:00-04:00).
+func SetExportWindow(start, end string) error {
+	if start == "" && end == "" {
+		exportWindow.configured = false
+		return nil
+	}
+
+	startMin, err := parseClockMinutes(start)
+	if err != nil {
+		return fmt.Errorf("invalid export window start: %w", err)
+	}
+	endMin, err := parseClockMinutes(end)
+	if err != nil {
+		return fmt.Errorf("invalid export window end: %w", err)
+	}
+
+	exportWindow.configured = true
+	exportWindow.start = startMin
+	exportWindow.end = endMin
+	return nil
+}
+
+// checkExportWindow returns an error describing when the window next opens
+// if big exports are not currently allowed.
+func checkExportWindow(now time.Time) error {
+	if !exportWindow.configured {
+		return nil
+	}
+
+	minutes := now.Hour()*60 + now.Minute()
+	inWindow := false
+	if exportWindow.start <= exportWindow.end {
+		inWindow = minutes >= exportWindow.start && minutes < exportWindow.end
+	} else {
+		// Window wraps past midnight
+		inWindow = minutes >= exportWindow.start || minutes < exportWindow.end
+	}
+	if inWindow {
+		return nil
+	}
+
+	return fmt.Errorf("exports are restricted to the %02d:%02d-%02d:%02d window",
+		exportWindow.start/60, exportWindow.start%60, exportWindow.end/60, exportWindow.end%60)
+}
+
+// parseClockMinutes parses "HH:MM" into minutes past midnight.
+func parseClockMinutes(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("expected HH:MM, got %q", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
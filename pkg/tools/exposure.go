@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// exposedAssetFlag describes one sensitive asset reachable via a shared link.
+type exposedAssetFlag struct {
+	AssetID   string   `json:"assetId"`
+	FileName  string   `json:"fileName"`
+	LinkID    string   `json:"linkId"`
+	LinkType  string   `json:"linkType"`
+	AlbumName string   `json:"albumName,omitempty"`
+	Reasons   []string `json:"reasons"`
+}
+
+// registerAuditSharedExposure registers the shared-link privacy audit tool.
+func registerAuditSharedExposure(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "auditSharedExposure",
+		Description: "List every asset reachable via active shared links and flag sensitive exposures: locked or hidden assets, archived assets, and assets featuring specific people",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"sensitivePeople": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Person names whose appearance in a shared asset should be flagged",
+				},
+				"includeExpired": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Also audit links that have already expired",
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			SensitivePeople []string `json:"sensitivePeople"`
+			IncludeExpired  bool     `json:"includeExpired"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		sensitiveNames := map[string]bool{}
+		for _, name := range params.SensitivePeople {
+			sensitiveNames[name] = true
+		}
+
+		links, err := immichClient.ListSharedLinks(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list shared links: %w", err)
+		}
+
+		now := time.Now()
+		activeLinks := 0
+		exposedAssets := map[string]bool{}
+		flagged := []exposedAssetFlag{}
+		errors := []string{}
+
+		for _, link := range links {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			if !params.IncludeExpired && link.ExpiresAt != nil && link.ExpiresAt.Before(now) {
+				continue
+			}
+			activeLinks++
+
+			// Resolve what the link exposes
+			assets := link.Assets
+			albumName := ""
+			if link.Type == "ALBUM" && link.Album != nil {
+				albumName = link.Album.AlbumName
+				albumAssets, err := immichClient.GetAlbumAssets(ctx, link.Album.ID)
+				if err != nil {
+					if len(errors) < 10 {
+						errors = append(errors, fmt.Sprintf("failed to get assets for shared album '%s': %v", albumName, err))
+					}
+					continue
+				}
+				assets = albumAssets
+			}
+
+			for _, asset := range assets {
+				exposedAssets[asset.ID] = true
+
+				reasons := []string{}
+				switch asset.Visibility {
+				case "locked":
+					reasons = append(reasons, "locked asset is shared")
+				case "hidden":
+					reasons = append(reasons, "hidden asset is shared")
+				}
+				if asset.IsArchived {
+					reasons = append(reasons, "archived asset is shared")
+				}
+				for _, person := range asset.People {
+					if sensitiveNames[person.Name] {
+						reasons = append(reasons, fmt.Sprintf("features %s", person.Name))
+					}
+				}
+
+				if len(reasons) > 0 {
+					flagged = append(flagged, exposedAssetFlag{
+						AssetID:   asset.ID,
+						FileName:  asset.OriginalFileName,
+						LinkID:    link.ID,
+						LinkType:  link.Type,
+						AlbumName: albumName,
+						Reasons:   reasons,
+					})
+				}
+			}
+		}
+
+		result := map[string]interface{}{
+			"success":       len(errors) == 0,
+			"totalLinks":    len(links),
+			"auditedLinks":  activeLinks,
+			"exposedAssets": len(exposedAssets),
+			"flaggedCount":  len(flagged),
+			"flagged":       flagged,
+		}
+		if len(errors) > 0 {
+			result["errors"] = errors
+		}
+		if len(flagged) == 0 {
+			result["message"] = fmt.Sprintf("No sensitive exposures found across %d links (%d assets reachable)", activeLinks, len(exposedAssets))
+		} else {
+			result["message"] = fmt.Sprintf("Found %d sensitive exposures across %d links", len(flagged), activeLinks)
+		}
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
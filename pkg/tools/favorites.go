@@ -0,0 +1,201 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/bulkops"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// favoriteBatchSize is how many assets are favorited per bulk update call
+const favoriteBatchSize = 500
+
+// unmatchedReportLimit caps how many unmatched entries are listed inline
+const unmatchedReportLimit = 50
+
+// registerImportFavorites registers the tool that replays another photo
+// manager's starred list onto Immich favorites.
+func registerImportFavorites(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "importFavorites",
+		Description: "Mark Immich assets as favorites from a list of filenames and/or checksums exported from another photo manager, with a match-rate report and unmatched-items listing",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"filenames": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Original filenames to match (case-insensitive, directories ignored)",
+				},
+				"checksums": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "File checksums to match, as Immich reports them (base64 SHA-1)",
+				},
+				"maxAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Stop after this many assets have matched (0 for no cap)",
+					"default":     0,
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Report matches without favoriting anything",
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Filenames []string `json:"filenames"`
+			Checksums []string `json:"checksums"`
+			MaxAssets int      `json:"maxAssets"`
+			DryRun    bool     `json:"dryRun"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if len(params.Filenames) == 0 && len(params.Checksums) == 0 {
+			return nil, fmt.Errorf("at least one of filenames or checksums must be provided")
+		}
+
+		// Wanted sets, keyed for lookup during the scan. Filenames are
+		// matched on the base name so exports with paths still work
+		wantedNames := make(map[string]bool, len(params.Filenames))
+		for _, name := range params.Filenames {
+			if base := strings.ToLower(path.Base(strings.ReplaceAll(name, "\\", "/"))); base != "" && base != "." {
+				wantedNames[base] = false
+			}
+		}
+		wantedChecksums := make(map[string]bool, len(params.Checksums))
+		for _, sum := range params.Checksums {
+			if sum != "" {
+				wantedChecksums[sum] = false
+			}
+		}
+
+		// One pass over the library collects every matching asset and
+		// marks which wanted entries were seen
+		scanned, err := bulkops.ScanAssets(ctx, immichClient, bulkops.ScanOptions{
+			Max: params.MaxAssets,
+		}, func(asset immich.Asset) bool {
+			matched := false
+			if name := strings.ToLower(asset.OriginalFileName); name != "" {
+				if _, wanted := wantedNames[name]; wanted {
+					wantedNames[name] = true
+					matched = true
+				}
+			}
+			if asset.Checksum != "" {
+				if _, wanted := wantedChecksums[asset.Checksum]; wanted {
+					wantedChecksums[asset.Checksum] = true
+					matched = true
+				}
+			}
+			return matched
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan library: %w", err)
+		}
+
+		var toFavorite []string
+		alreadyFavorite := 0
+		for _, asset := range scanned.Assets {
+			if asset.IsFavorite {
+				alreadyFavorite++
+				continue
+			}
+			toFavorite = append(toFavorite, asset.ID)
+		}
+
+		matchedNames, unmatched := tallyWanted(wantedNames)
+		matchedChecksums, unmatchedChecksums := tallyWanted(wantedChecksums)
+		unmatched = append(unmatched, unmatchedChecksums...)
+
+		requested := len(wantedNames) + len(wantedChecksums)
+		matched := matchedNames + matchedChecksums
+		matchRate := 0
+		if requested > 0 {
+			matchRate = matched * 100 / requested
+		}
+
+		result := map[string]interface{}{
+			"requested":        requested,
+			"matched":          matched,
+			"matchRatePercent": matchRate,
+			"assetsScanned":    scanned.TotalProcessed,
+			"alreadyFavorite":  alreadyFavorite,
+			"toFavorite":       len(toFavorite),
+		}
+		if len(unmatched) > 0 {
+			if len(unmatched) > unmatchedReportLimit {
+				result["unmatchedTruncated"] = len(unmatched) - unmatchedReportLimit
+				unmatched = unmatched[:unmatchedReportLimit]
+			}
+			result["unmatched"] = unmatched
+		}
+
+		if params.DryRun {
+			result["dryRun"] = true
+			result["success"] = true
+			result["message"] = fmt.Sprintf("DRY RUN: %d of %d entries matched (%d%%); %d assets would be favorited",
+				matched, requested, matchRate, len(toFavorite))
+			return makeMCPResult(result)
+		}
+
+		favorited := 0
+		for start := 0; start < len(toFavorite); start += favoriteBatchSize {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			end := start + favoriteBatchSize
+			if end > len(toFavorite) {
+				end = len(toFavorite)
+			}
+			if err := immichClient.UpdateAssetsBulk(ctx, toFavorite[start:end], map[string]interface{}{"isFavorite": true}); err != nil {
+				result["favorited"] = favorited
+				result["success"] = false
+				result["message"] = fmt.Sprintf("Favorited %d of %d assets before a bulk update failed: %v", favorited, len(toFavorite), err)
+				return makeMCPResult(result)
+			}
+			favorited += end - start
+		}
+
+		result["favorited"] = favorited
+		result["success"] = true
+		result["message"] = fmt.Sprintf("%d of %d entries matched (%d%%); favorited %d assets (%d already favorite)",
+			matched, requested, matchRate, favorited, alreadyFavorite)
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// tallyWanted splits a wanted set into how many were seen and which were not
+func tallyWanted(wanted map[string]bool) (int, []string) {
+	matched := 0
+	var missing []string
+	for key, seen := range wanted {
+		if seen {
+			matched++
+		} else {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return matched, missing
+}
@@ -0,0 +1,250 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/bulkops"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// registerMirrorFolderStructureToAlbums registers the folder-to-album mirroring tool
+func registerMirrorFolderStructureToAlbums(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "mirrorFolderStructureToAlbums",
+		Description: "Create albums mirroring the folder structure of asset originalPath values (e.g. external library imports) and assign the contained assets, reproducing pre-Immich folder organization",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"basePath": map[string]interface{}{
+					"type":        "string",
+					"description": "Only consider assets whose originalPath starts with this prefix; folder names are taken relative to it (empty means the filesystem root)",
+				},
+				"depth": map[string]interface{}{
+					"type":        "integer",
+					"description": "How many folder levels below basePath form the album name (joined with '/')",
+					"default":     1,
+				},
+				"excludePatterns": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Glob patterns; folders whose relative path or any segment matches are skipped (e.g. '.*', 'tmp')",
+				},
+				"maxAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of assets to scan (0 for no limit)",
+					"default":     0,
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Report the albums that would be created without creating them",
+					"default":     true,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			BasePath        string   `json:"basePath"`
+			Depth           int      `json:"depth"`
+			ExcludePatterns []string `json:"excludePatterns"`
+			MaxAssets       int      `json:"maxAssets"`
+			DryRun          *bool    `json:"dryRun"`
+		}
+
+		// Set defaults
+		params.Depth = 1
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if params.Depth < 1 {
+			return nil, fmt.Errorf("depth must be at least 1")
+		}
+		for _, pattern := range params.ExcludePatterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid exclude pattern '%s': %w", pattern, err)
+			}
+		}
+		dryRun := params.DryRun == nil || *params.DryRun
+
+		basePath := strings.TrimSuffix(params.BasePath, "/")
+
+		// One pass over the library groups asset IDs by their folder key
+		byFolder := map[string][]string{}
+		outsideBase := 0
+		excluded := 0
+		atRoot := 0
+		scanResult, err := bulkops.ScanAssets(ctx, immichClient, bulkops.ScanOptions{
+			Max: params.MaxAssets,
+		}, func(asset immich.Asset) bool {
+			folder, status := folderKeyForPath(asset.OriginalPath, basePath, params.Depth, params.ExcludePatterns)
+			switch status {
+			case folderOutsideBase:
+				outsideBase++
+			case folderExcluded:
+				excluded++
+			case folderAtRoot:
+				atRoot++
+			default:
+				byFolder[folder] = append(byFolder[folder], asset.ID)
+			}
+			return false
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan assets: %w", err)
+		}
+
+		folders := make([]string, 0, len(byFolder))
+		for folder := range byFolder {
+			folders = append(folders, folder)
+		}
+		sort.Strings(folders)
+
+		albums, err := immichClient.ListAlbums(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list albums: %w", err)
+		}
+		albumsByName := map[string]string{}
+		for _, album := range albums {
+			albumsByName[album.AlbumName] = album.ID
+		}
+
+		if dryRun {
+			plan := make([]map[string]interface{}, 0, len(folders))
+			for _, folder := range folders {
+				_, exists := albumsByName[folder]
+				plan = append(plan, map[string]interface{}{
+					"album":  folder,
+					"assets": len(byFolder[folder]),
+					"exists": exists,
+				})
+			}
+			return makeMCPResult(map[string]interface{}{
+				"success":     true,
+				"dryRun":      true,
+				"scanned":     scanResult.TotalProcessed,
+				"folders":     len(folders),
+				"outsideBase": outsideBase,
+				"excluded":    excluded,
+				"atRoot":      atRoot,
+				"plan":        plan,
+				"message":     fmt.Sprintf("Would mirror %d folders into albums (dry run; set dryRun=false to apply)", len(folders)),
+			})
+		}
+
+		type folderResult struct {
+			Album   string `json:"album"`
+			Created bool   `json:"created"`
+			Added   int    `json:"added"`
+			Failed  int    `json:"failed"`
+			Error   string `json:"error,omitempty"`
+		}
+
+		results := []folderResult{}
+		created := 0
+		totalAdded := 0
+
+		for _, folder := range folders {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			entry := folderResult{Album: folder}
+
+			albumID, exists := albumsByName[folder]
+			if exists {
+				if err := checkAlbumLock(albumID, folder); err != nil {
+					entry.Error = err.Error()
+					results = append(results, entry)
+					continue
+				}
+			} else {
+				newAlbum, err := immichClient.CreateAlbum(ctx, immich.CreateAlbumParams{
+					Name:        folder,
+					Description: fmt.Sprintf("Mirrored from folder %s", path.Join(basePath, folder)),
+				})
+				if err != nil {
+					entry.Error = fmt.Sprintf("album creation failed: %v", err)
+					results = append(results, entry)
+					continue
+				}
+				albumID = newAlbum.ID
+				albumsByName[folder] = albumID
+				entry.Created = true
+				created++
+			}
+
+			bulkResult, err := immichClient.AddAssetsToAlbum(ctx, albumID, byFolder[folder])
+			if err != nil {
+				entry.Error = fmt.Sprintf("adding assets failed: %v", err)
+				results = append(results, entry)
+				continue
+			}
+			entry.Added = len(bulkResult.Success)
+			entry.Failed = len(bulkResult.Error)
+			totalAdded += entry.Added
+			results = append(results, entry)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":       true,
+			"scanned":       scanResult.TotalProcessed,
+			"albumsCreated": created,
+			"assetsAdded":   totalAdded,
+			"folders":       results,
+			"message":       fmt.Sprintf("Mirrored %d folders: created %d albums, added %d assets", len(results), created, totalAdded),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// Outcomes of mapping one asset path to a folder key
+const (
+	folderOK = iota
+	folderOutsideBase
+	folderExcluded
+	folderAtRoot
+)
+
+// folderKeyForPath maps an asset's originalPath to the album name mirroring
+// its folder, taking the first depth segments below basePath. Assets directly
+// in basePath have no folder to mirror and are reported as folderAtRoot.
+func folderKeyForPath(assetPath, basePath string, depth int, excludePatterns []string) (string, int) {
+	dir := path.Dir(assetPath)
+	if basePath != "" {
+		if dir != basePath && !strings.HasPrefix(dir, basePath+"/") {
+			return "", folderOutsideBase
+		}
+		dir = strings.TrimPrefix(dir, basePath)
+	}
+	segments := strings.Split(strings.Trim(dir, "/"), "/")
+	if len(segments) == 0 || segments[0] == "" {
+		return "", folderAtRoot
+	}
+	if len(segments) > depth {
+		segments = segments[:depth]
+	}
+	key := strings.Join(segments, "/")
+	for _, pattern := range excludePatterns {
+		if matched, _ := path.Match(pattern, key); matched {
+			return "", folderExcluded
+		}
+		for _, segment := range segments {
+			if matched, _ := path.Match(pattern, segment); matched {
+				return "", folderExcluded
+			}
+		}
+	}
+	return key, folderOK
+}
@@ -0,0 +1,150 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// timelineGap is one zero-asset stretch reported by findTimelineGaps.
+type timelineGap struct {
+	Start       string `json:"start"`
+	End         string `json:"end"`
+	Days        int    `json:"days"`
+	BeforeCount int    `json:"assetsBeforeGap"`
+	AfterCount  int    `json:"assetsAfterGap"`
+}
+
+// registerFindTimelineGaps registers the tool that surfaces long stretches
+// of the timeline with no assets, using bucket counts rather than full scans.
+func registerFindTimelineGaps(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "findTimelineGaps",
+		Description: "Report date ranges longer than a threshold with zero assets, overall or per person or album — useful for spotting missing imports from old phones or backup gaps",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"minGapDays": map[string]interface{}{
+					"type":        "integer",
+					"description": "Minimum gap length in days to report",
+					"default":     30,
+				},
+				"personId": map[string]interface{}{
+					"type":        "string",
+					"description": "Only consider assets featuring this person",
+				},
+				"albumId": map[string]interface{}{
+					"type":        "string",
+					"description": "Only consider assets in this album",
+				},
+				"maxGaps": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of gaps to return, longest first (0 for all)",
+					"default":     20,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			MinGapDays int    `json:"minGapDays"`
+			PersonID   string `json:"personId"`
+			AlbumID    string `json:"albumId"`
+			MaxGaps    int    `json:"maxGaps"`
+		}
+
+		// Set defaults
+		params.MinGapDays = 30
+		params.MaxGaps = 20
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if params.MinGapDays < 1 {
+			params.MinGapDays = 1
+		}
+
+		// Day buckets give exact gap boundaries without fetching any assets
+		buckets, err := immichClient.GetTimeBuckets(ctx, immich.BucketParams{
+			Size:     "day",
+			PersonID: params.PersonID,
+			AlbumID:  params.AlbumID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get time buckets: %w", err)
+		}
+
+		type dayBucket struct {
+			date  time.Time
+			count int
+		}
+		days := make([]dayBucket, 0, len(buckets.Buckets))
+		for _, bucket := range buckets.Buckets {
+			date, err := parseBucketDate(bucket.Date)
+			if err != nil {
+				continue
+			}
+			days = append(days, dayBucket{date: date, count: bucket.Count})
+		}
+		sort.Slice(days, func(i, j int) bool { return days[i].date.Before(days[j].date) })
+
+		gaps := []timelineGap{}
+		for i := 0; i+1 < len(days); i++ {
+			// Days with zero assets between two populated buckets
+			missing := int(days[i+1].date.Sub(days[i].date).Hours()/24) - 1
+			if missing >= params.MinGapDays {
+				gaps = append(gaps, timelineGap{
+					Start:       days[i].date.AddDate(0, 0, 1).Format("2006-01-02"),
+					End:         days[i+1].date.AddDate(0, 0, -1).Format("2006-01-02"),
+					Days:        missing,
+					BeforeCount: days[i].count,
+					AfterCount:  days[i+1].count,
+				})
+			}
+		}
+
+		// Longest gaps first
+		sort.Slice(gaps, func(i, j int) bool { return gaps[i].Days > gaps[j].Days })
+		totalGaps := len(gaps)
+		if params.MaxGaps > 0 && len(gaps) > params.MaxGaps {
+			gaps = gaps[:params.MaxGaps]
+		}
+
+		result := map[string]interface{}{
+			"success":        true,
+			"minGapDays":     params.MinGapDays,
+			"daysWithAssets": len(days),
+			"totalGaps":      totalGaps,
+			"gaps":           gaps,
+		}
+		if len(days) > 0 {
+			result["timelineStart"] = days[0].date.Format("2006-01-02")
+			result["timelineEnd"] = days[len(days)-1].date.Format("2006-01-02")
+		}
+		if totalGaps == 0 {
+			result["message"] = fmt.Sprintf("No gaps of %d days or more found", params.MinGapDays)
+		} else {
+			result["message"] = fmt.Sprintf("Found %d gaps of %d days or more", totalGaps, params.MinGapDays)
+		}
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// parseBucketDate handles both the full timestamp and plain date forms the
+// timeline API returns for bucket keys.
+func parseBucketDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/history"
+)
+
+// historySummaryLimit caps how much of a result is kept per history entry.
+const historySummaryLimit = 200
+
+// HistoryMiddleware returns tool middleware that records every call and a
+// summarized result into the history store.
+func HistoryMiddleware(store *history.Store) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			began := time.Now()
+			result, err := next(ctx, request)
+
+			entry := history.Entry{
+				Tool:       request.Params.Name,
+				CalledAt:   began,
+				DurationMs: time.Since(began).Milliseconds(),
+				Success:    err == nil,
+			}
+			if err != nil {
+				entry.Summary = err.Error()
+			} else {
+				entry.Summary = summarizeToolResult(result)
+			}
+			// History is best-effort; a failed write never fails the call
+			_ = store.Record(entry)
+
+			return result, err
+		}
+	}
+}
+
+// summarizeToolResult pulls the human-readable message out of a tool result,
+// falling back to truncated raw text.
+func summarizeToolResult(result *mcp.CallToolResult) string {
+	if result == nil || len(result.Content) == 0 {
+		return ""
+	}
+	text, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		return ""
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal([]byte(text.Text), &payload); err == nil {
+		if message, ok := payload["message"].(string); ok && message != "" {
+			return message
+		}
+	}
+
+	if len(text.Text) > historySummaryLimit {
+		return text.Text[:historySummaryLimit] + "..."
+	}
+	return text.Text
+}
+
+// RegisterHistoryResource exposes the recorded tool calls as an MCP resource
+// so a fresh session can recover what previous sessions did.
+func RegisterHistoryResource(s *server.MCPServer, store *history.Store) {
+	resource := mcp.NewResource(
+		"immich://history",
+		"Tool call history",
+		mcp.WithResourceDescription("Recent tool calls with summarized results, oldest first"),
+		mcp.WithMIMEType("application/json"),
+	)
+
+	s.AddResource(resource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		data, err := json.MarshalIndent(store.List(), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		}, nil
+	})
+}
@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// Detail levels for sample hydration. Listings carry partial metadata; the
+// hydrator trades API calls for richer samples only when the caller asks.
+const (
+	// DetailIDs returns bare asset IDs — the cheapest output for callers
+	// that feed the IDs into another tool.
+	DetailIDs = "ids"
+	// DetailSummary is the historical compact sample (cards or small JSON
+	// objects), built from the listing data alone.
+	DetailSummary = "summary"
+	// DetailFull fetches complete metadata per sampled asset, costing one
+	// API call each.
+	DetailFull = "full"
+)
+
+// resolveDetail picks the effective detail level, defaulting to summary.
+func resolveDetail(requested string) string {
+	switch requested {
+	case DetailIDs, DetailSummary, DetailFull:
+		return requested
+	}
+	return DetailSummary
+}
+
+// detailSchema is the reusable schema fragment for the sample detail
+// parameter.
+func detailSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"enum":        []string{DetailIDs, DetailSummary, DetailFull},
+		"description": "How much metadata to include per sampled asset: bare ids, a compact summary, or full metadata (one extra API call per asset)",
+		"default":     DetailSummary,
+	}
+}
+
+// hydrateAssetSamples renders up to limit assets at the requested detail
+// level. Full detail re-fetches each sampled asset; assets that fail to
+// hydrate fall back to their listing copy rather than dropping out of the
+// sample.
+func hydrateAssetSamples(ctx context.Context, immichClient *immich.Client, assets []immich.Asset, limit int, detail string) interface{} {
+	if limit > len(assets) {
+		limit = len(assets)
+	}
+
+	switch resolveDetail(detail) {
+	case DetailIDs:
+		ids := make([]string, 0, limit)
+		for i := 0; i < limit; i++ {
+			ids = append(ids, assets[i].ID)
+		}
+		return ids
+	case DetailFull:
+		full := make([]immich.Asset, 0, limit)
+		for i := 0; i < limit; i++ {
+			hydrated, err := immichClient.GetAssetMetadata(ctx, assets[i].ID)
+			if err != nil {
+				full = append(full, assets[i])
+				continue
+			}
+			full = append(full, *hydrated)
+		}
+		return full
+	default:
+		return formatAssetSamples(assets, limit)
+	}
+}
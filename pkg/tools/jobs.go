@@ -0,0 +1,194 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/jobs"
+)
+
+// jobManager runs bulk tools in the background; set from configuration
+var jobManager *jobs.Manager
+
+// SetJobManager wires the background job manager used by async bulk runs.
+func SetJobManager(m *jobs.Manager) {
+	jobManager = m
+}
+
+// asyncSchema is the shared schema for the async parameter on bulk tools.
+func asyncSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "boolean",
+		"description": "Run in the background and return a job ID immediately (poll getJobProgress for the result)",
+		"default":     false,
+	}
+}
+
+// startBackgroundJob submits run to the job manager and returns the job
+// handle as the tool result
+func startBackgroundJob(tool string, run jobs.RunFunc) (*mcp.CallToolResult, error) {
+	if jobManager == nil {
+		return nil, fmt.Errorf("background jobs are not enabled")
+	}
+
+	job := jobManager.Start(tool, run)
+	return makeMCPResult(map[string]interface{}{
+		"success": true,
+		"jobId":   job.ID,
+		"status":  job.Status,
+		"message": fmt.Sprintf("Job %s started for %s; poll getJobProgress for the result", job.ID, tool),
+	})
+}
+
+// RegisterJobTools registers tools for inspecting and cancelling background
+// jobs started by bulk tools with async set
+func RegisterJobTools(s *server.MCPServer) {
+	registerListJobs(s)
+	registerGetJobProgress(s)
+	registerCancelJob(s)
+}
+
+// listJobs tool
+func registerListJobs(s *server.MCPServer) {
+	tool := mcp.Tool{
+		Name:        "listJobs",
+		Description: "List background jobs started by bulk tools, newest first",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"status": map[string]interface{}{
+					"type":        "string",
+					"description": "Only list jobs with this status",
+					"enum":        []string{"running", "completed", "failed", "cancelled"},
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Status string `json:"status"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if jobManager == nil {
+			return makeMCPResult(map[string]interface{}{
+				"success": true,
+				"count":   0,
+				"jobs":    []jobs.Job{},
+				"message": "Background jobs are not enabled",
+			})
+		}
+
+		all := jobManager.List()
+		matched := make([]jobs.Job, 0, len(all))
+		for _, job := range all {
+			if params.Status != "" && string(job.Status) != params.Status {
+				continue
+			}
+			matched = append(matched, job)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success": true,
+			"count":   len(matched),
+			"jobs":    matched,
+			"message": fmt.Sprintf("%d background jobs", len(matched)),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// getJobProgress tool
+func registerGetJobProgress(s *server.MCPServer) {
+	tool := mcp.Tool{
+		Name:        "getJobProgress",
+		Description: "Report the status, progress, and result of a background job",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"jobId": map[string]interface{}{
+					"type":        "string",
+					"description": "Job ID returned when the job was started",
+				},
+			},
+			Required: []string{"jobId"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			JobID string `json:"jobId"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if jobManager == nil {
+			return nil, fmt.Errorf("background jobs are not enabled")
+		}
+
+		job, found := jobManager.Get(params.JobID)
+		if !found {
+			return nil, fmt.Errorf("job '%s' not found", params.JobID)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success": true,
+			"job":     job,
+			"message": fmt.Sprintf("Job %s (%s) is %s", job.ID, job.Tool, job.Status),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// cancelJob tool
+func registerCancelJob(s *server.MCPServer) {
+	tool := mcp.Tool{
+		Name:        "cancelJob",
+		Description: "Cancel a running background job; work already done is not rolled back",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"jobId": map[string]interface{}{
+					"type":        "string",
+					"description": "Job ID to cancel",
+				},
+			},
+			Required: []string{"jobId"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			JobID string `json:"jobId"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if jobManager == nil {
+			return nil, fmt.Errorf("background jobs are not enabled")
+		}
+
+		job, err := jobManager.Cancel(params.JobID)
+		if err != nil {
+			return nil, err
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success": true,
+			"job":     job,
+			"message": fmt.Sprintf("Job %s cancelled", job.ID),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
@@ -0,0 +1,15 @@
+package tools
+
+import (
+	"github.com/yourusername/mcp-immich/pkg/journal"
+)
+
+// operationJournal records multi-step operations (add-to-target then
+// remove-from-source) so interruptions can be recovered on next start; nil
+// disables journaling
+var operationJournal *journal.Journal
+
+// SetOperationJournal installs the journal multi-step tools record into
+func SetOperationJournal(j *journal.Journal) {
+	operationJournal = j
+}
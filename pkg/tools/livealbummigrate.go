@@ -0,0 +1,191 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/smartalbums"
+)
+
+// liveAlbumMarkerPrefix introduces a description-encoded live album: a
+// description line of the form `live: {<smart search params JSON>}`. This
+// predates the smart album store; migrateLiveAlbums converts such albums
+// into stored definitions so edits to the description can't break them.
+const liveAlbumMarkerPrefix = "live:"
+
+// parseLiveAlbumMarker extracts a live-album marker from an album
+// description. It returns the parsed query, the optional maxAssets cap, and
+// the description with the marker line removed.
+func parseLiveAlbumMarker(description string) (query immich.SmartSearchParams, maxAssets int, remainder string, found bool, err error) {
+	var kept []string
+	for _, line := range strings.Split(description, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if found || !strings.HasPrefix(strings.ToLower(trimmed), liveAlbumMarkerPrefix) {
+			kept = append(kept, line)
+			continue
+		}
+
+		payload := strings.TrimSpace(trimmed[len(liveAlbumMarkerPrefix):])
+		if err = json.Unmarshal([]byte(payload), &query); err != nil {
+			err = fmt.Errorf("invalid live-album marker: %w", err)
+			return
+		}
+		var extra struct {
+			MaxAssets int `json:"maxAssets"`
+		}
+		_ = json.Unmarshal([]byte(payload), &extra)
+		maxAssets = extra.MaxAssets
+		found = true
+	}
+	remainder = strings.TrimSpace(strings.Join(kept, "\n"))
+	return
+}
+
+// migrateLiveAlbums tool
+func registerMigrateLiveAlbums(s *server.MCPServer, immichClient *immich.Client, store *smartalbums.Store) {
+	tool := mcp.Tool{
+		Name:        "migrateLiveAlbums",
+		Description: "Convert description-encoded live albums (a description line like `live: {\"query\": \"sunset\"}`) into smart album definitions in the persistent store, optionally cleaning the fragile marker out of the description",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"albumId": map[string]interface{}{
+					"type":        "string",
+					"description": "Migrate only this album (default: scan all albums)",
+				},
+				"cleanDescriptions": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Remove the marker line from migrated album descriptions so the config lives only in the store",
+					"default":     true,
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Report what would be migrated without changing the store or any album",
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AlbumID           string `json:"albumId"`
+			CleanDescriptions bool   `json:"cleanDescriptions"`
+			DryRun            bool   `json:"dryRun"`
+		}
+
+		// Set defaults
+		params.CleanDescriptions = true
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		var albums []immich.Album
+		if params.AlbumID != "" {
+			album, err := immichClient.GetAlbumInfo(ctx, params.AlbumID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get album: %w", err)
+			}
+			albums = []immich.Album{*album}
+		} else {
+			var err error
+			albums, err = immichClient.ListAlbums(ctx, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list albums: %w", err)
+			}
+		}
+
+		// Albums already targeted by a definition are managed; migrating them
+		// again would clobber the stored query with the stale marker
+		managed := map[string]bool{}
+		for _, def := range store.List() {
+			if def.AlbumID != "" {
+				managed[def.AlbumID] = true
+			}
+		}
+
+		type migrationEntry struct {
+			Album  string `json:"album"`
+			Status string `json:"status"` // migrated, already-managed, invalid-marker
+			Detail string `json:"detail,omitempty"`
+		}
+
+		entries := []migrationEntry{}
+		migrated := 0
+		cleaned := 0
+
+		for _, album := range albums {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			query, maxAssets, remainder, found, err := parseLiveAlbumMarker(album.Description)
+			if !found && err == nil {
+				continue
+			}
+
+			entry := migrationEntry{Album: album.AlbumName}
+			if err != nil {
+				entry.Status = "invalid-marker"
+				entry.Detail = err.Error()
+				entries = append(entries, entry)
+				continue
+			}
+			if managed[album.ID] {
+				entry.Status = "already-managed"
+				entry.Detail = "a smart album definition already targets this album; remove the marker by hand"
+				entries = append(entries, entry)
+				continue
+			}
+
+			entry.Status = "migrated"
+			if !params.DryRun {
+				if _, err := store.Define(smartalbums.SmartAlbumDefinition{
+					Name:      album.AlbumName,
+					AlbumID:   album.ID,
+					AlbumName: album.AlbumName,
+					Query:     query,
+					MaxAssets: maxAssets,
+				}); err != nil {
+					return nil, fmt.Errorf("failed to store definition for album '%s': %w", album.AlbumName, err)
+				}
+				if params.CleanDescriptions {
+					if _, err := immichClient.UpdateAlbum(ctx, album.ID, map[string]interface{}{"description": remainder}); err != nil {
+						entry.Detail = fmt.Sprintf("definition stored, but cleaning the description failed: %v", err)
+					} else {
+						cleaned++
+					}
+				}
+			}
+			migrated++
+			entries = append(entries, entry)
+		}
+
+		message := fmt.Sprintf("Migrated %d description-encoded live albums to the smart album store", migrated)
+		if params.DryRun {
+			message = fmt.Sprintf("Dry run: %d description-encoded live albums would be migrated", migrated)
+		} else if cleaned > 0 {
+			message += fmt.Sprintf(" (%d descriptions cleaned)", cleaned)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":  true,
+			"dryRun":   params.DryRun,
+			"scanned":  len(albums),
+			"migrated": migrated,
+			"entries":  entries,
+			"message":  message,
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/smartalbums"
+)
+
+// registerPreviewLiveAlbumChange registers the query-change impact preview tool
+func registerPreviewLiveAlbumChange(s *server.MCPServer, immichClient *immich.Client, store *smartalbums.Store) {
+	tool := mcp.Tool{
+		Name:        "previewLiveAlbumChange",
+		Description: "Preview how an album's membership would change under a proposed smart-search query (kept/added/removed with samples) before updating a smart album definition to it",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"albumId": map[string]interface{}{
+					"type":        "string",
+					"description": "Album ID to preview against",
+				},
+				"albumName": map[string]interface{}{
+					"type":        "string",
+					"description": "Album name (alternative to albumId)",
+				},
+				"query": map[string]interface{}{
+					"type":        "object",
+					"description": "Proposed smart search parameters (same fields as smartSearchAdvanced)",
+				},
+				"maxAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Cap on query matches, mirroring the definition's maxAssets (0 for no cap)",
+					"default":     0,
+				},
+			},
+			Required: []string{"query"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AlbumID   string                   `json:"albumId"`
+			AlbumName string                   `json:"albumName"`
+			Query     immich.SmartSearchParams `json:"query"`
+			MaxAssets int                      `json:"maxAssets"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		albumID := params.AlbumID
+		albumName := params.AlbumName
+		if albumID == "" {
+			if albumName == "" {
+				return nil, fmt.Errorf("either albumId or albumName must be provided")
+			}
+			album, candidates, err := findAlbumInteractive(ctx, s, immichClient, albumName)
+			if err != nil {
+				return nil, err
+			}
+			if album == nil {
+				return albumCandidatesResult(albumName, candidates)
+			}
+			albumID = album.ID
+			albumName = album.AlbumName
+		}
+
+		albumAssets, err := immichClient.GetAlbumAssets(ctx, albumID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get album assets: %w", err)
+		}
+		current := make(map[string]bool, len(albumAssets))
+		for _, asset := range albumAssets {
+			current[asset.ID] = true
+		}
+
+		// Stream the proposed query with the same cap a live refresh would use
+		query := params.Query
+		if params.MaxAssets > 0 {
+			query.Size = params.MaxAssets
+		}
+		matched := map[string]bool{}
+		kept := []string{}
+		added := []string{}
+		var sampleAdded []immich.Asset
+		err = immichClient.SmartSearchStream(ctx, query, func(asset immich.Asset) error {
+			matched[asset.ID] = true
+			if current[asset.ID] {
+				kept = append(kept, asset.ID)
+			} else {
+				added = append(added, asset.ID)
+				if len(sampleAdded) < 10 {
+					sampleAdded = append(sampleAdded, asset)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("smart search failed: %w", err)
+		}
+
+		removed := []string{}
+		var sampleRemoved []immich.Asset
+		for _, asset := range albumAssets {
+			if !matched[asset.ID] {
+				removed = append(removed, asset.ID)
+				if len(sampleRemoved) < 10 {
+					sampleRemoved = append(sampleRemoved, asset)
+				}
+			}
+		}
+		sort.Strings(removed)
+
+		result := map[string]interface{}{
+			"success":        true,
+			"albumId":        albumID,
+			"albumName":      albumName,
+			"currentAssets":  len(albumAssets),
+			"matched":        len(matched),
+			"kept":           len(kept),
+			"added":          len(added),
+			"removed":        len(removed),
+			"sampleAdded":    formatAssetSamples(sampleAdded, 10),
+			"sampleRemoved":  formatAssetSamples(sampleRemoved, 10),
+			"removedSamples": sampleIDs(removed),
+		}
+
+		// Flag the definition the change would rewrite, if one targets this album
+		for _, def := range store.List() {
+			if def.AlbumID == albumID || (def.AlbumID == "" && def.AlbumName == albumName) {
+				result["definition"] = def.Name
+				break
+			}
+		}
+
+		message := fmt.Sprintf("Proposed query keeps %d of %d album assets, adds %d, and drops %d",
+			len(kept), len(albumAssets), len(added), len(removed))
+		if len(removed) > 0 {
+			message += "; a refresh never removes assets, but a full-sync update would"
+		}
+		result["message"] = message
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/smartalbums"
+)
+
+// setLiveAlbumSchedule tool
+func registerSetLiveAlbumSchedule(s *server.MCPServer, store *smartalbums.Store) {
+	tool := mcp.Tool{
+		Name:        "setLiveAlbumSchedule",
+		Description: "Set, change or clear a smart album definition's own refresh schedule: a five-field cron expression, a minute interval, or back to the scheduler's global default",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Definition name",
+				},
+				"id": map[string]interface{}{
+					"type":        "string",
+					"description": "Definition ID (alternative to name)",
+				},
+				"cron": map[string]interface{}{
+					"type":        "string",
+					"description": "Five-field cron expression (e.g. '0 3 * * *' for daily at 03:00, '*/30 * * * *' for every half hour)",
+				},
+				"intervalMinutes": map[string]interface{}{
+					"type":        "integer",
+					"description": "Refresh every this many minutes (alternative to cron)",
+				},
+				"clear": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Remove the definition's own schedule so the global default applies again",
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Name            string `json:"name"`
+			ID              string `json:"id"`
+			Cron            string `json:"cron"`
+			IntervalMinutes int    `json:"intervalMinutes"`
+			Clear           bool   `json:"clear"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		def, err := lookupSmartAlbum(store, params.ID, params.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		var schedule string
+		switch {
+		case params.Clear:
+			if params.Cron != "" || params.IntervalMinutes > 0 {
+				return nil, fmt.Errorf("clear cannot be combined with cron or intervalMinutes")
+			}
+			def.RefreshCron = ""
+			def.RefreshIntervalMinutes = 0
+			schedule = "the global default"
+		case params.Cron != "":
+			if params.IntervalMinutes > 0 {
+				return nil, fmt.Errorf("cron and intervalMinutes are mutually exclusive")
+			}
+			if _, err := smartalbums.ParseCron(params.Cron); err != nil {
+				return nil, fmt.Errorf("invalid cron expression: %w", err)
+			}
+			def.RefreshCron = params.Cron
+			def.RefreshIntervalMinutes = 0
+			schedule = fmt.Sprintf("cron '%s'", params.Cron)
+		case params.IntervalMinutes > 0:
+			def.RefreshCron = ""
+			def.RefreshIntervalMinutes = params.IntervalMinutes
+			schedule = fmt.Sprintf("every %d minutes", params.IntervalMinutes)
+		default:
+			return nil, fmt.Errorf("provide cron, intervalMinutes, or clear")
+		}
+
+		if err := store.Update(*def); err != nil {
+			return nil, fmt.Errorf("failed to update definition: %w", err)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":    true,
+			"definition": def.Name,
+			"cron":       def.RefreshCron,
+			"interval":   def.RefreshIntervalMinutes,
+			"message":    fmt.Sprintf("Smart album '%s' now refreshes on %s", def.Name, schedule),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
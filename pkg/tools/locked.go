@@ -0,0 +1,134 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// excludeLockedAssets drops locked/private assets from a result set unless
+// the caller explicitly opted in with includeLocked.
+func excludeLockedAssets(assets []immich.Asset, includeLocked bool) []immich.Asset {
+	if includeLocked {
+		return assets
+	}
+	filtered := assets[:0]
+	for _, asset := range assets {
+		if asset.Visibility == "locked" {
+			continue
+		}
+		filtered = append(filtered, asset)
+	}
+	return filtered
+}
+
+// registerLockAssets registers the tool pair moving assets into and out of
+// Immich's locked/private visibility.
+func registerLockAssets(s *server.MCPServer, immichClient *immich.Client) {
+	lockTool := mcp.Tool{
+		Name:        "lockAssets",
+		Description: "Move assets into Immich's locked/private space. Locked assets are excluded from all other tools' results unless includeLocked is passed. Requires confirm=true.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"assetIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Asset IDs to lock",
+				},
+				"confirm": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Must be true to perform the operation (extra confirmation for privacy-sensitive moves)",
+					"default":     false,
+				},
+			},
+			Required: []string{"assetIds", "confirm"},
+		},
+	}
+
+	unlockTool := mcp.Tool{
+		Name:        "unlockAssets",
+		Description: "Move assets out of the locked/private space back to the timeline. Requires confirm=true.",
+		InputSchema: lockTool.InputSchema,
+	}
+
+	makeHandler := func(lock bool) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				AssetIds []string `json:"assetIds"`
+				Confirm  bool     `json:"confirm"`
+			}
+
+			if err := decodeArgs(request, &params); err != nil {
+				return nil, err
+			}
+
+			if len(params.AssetIds) == 0 {
+				return nil, fmt.Errorf("assetIds is required")
+			}
+			if !params.Confirm {
+				return nil, fmt.Errorf("this operation changes asset privacy; set confirm=true to proceed")
+			}
+
+			visibility := "locked"
+			if !lock {
+				visibility = "timeline"
+			}
+
+			updated := 0
+			failed := 0
+			var errors []string
+			tombstones := newTombstoneTracker()
+
+			for _, id := range params.AssetIds {
+				// Check for context cancellation
+				select {
+				case <-ctx.Done():
+					return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+				default:
+				}
+
+				err := immichClient.UpdateAssetMetadata(ctx, id, map[string]interface{}{
+					"visibility": visibility,
+				})
+				if err != nil {
+					// Assets trashed mid-operation are tombstoned, not failures
+					if tombstones.Note(id, err) {
+						continue
+					}
+					failed++
+					errors = append(errors, fmt.Sprintf("%s: %v", id, err))
+					continue
+				}
+				updated++
+			}
+
+			verb := "locked"
+			if !lock {
+				verb = "unlocked"
+			}
+
+			result := map[string]interface{}{
+				"success": failed == 0,
+				"updated": updated,
+				"failed":  failed,
+				"message": fmt.Sprintf("%s %d assets", verb, updated),
+			}
+			if len(errors) > 0 {
+				if len(errors) > 10 {
+					errors = errors[:10]
+				}
+				result["errors"] = errors
+			}
+			tombstones.Report(result)
+
+			return makeMCPResult(result)
+		}
+	}
+
+	s.AddTool(lockTool, makeHandler(true))
+	s.AddTool(unlockTool, makeHandler(false))
+}
@@ -0,0 +1,165 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/patrickmn/go-cache"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// maintenanceScanRecord captures one maintenance scan's findings for a
+// single check type so later runs can be diffed against it.
+type maintenanceScanRecord struct {
+	CheckType string    `json:"checkType"`
+	RunAt     time.Time `json:"runAt"`
+	AssetIDs  []string  `json:"assetIds"`
+}
+
+// Check types recorded by the maintenance scan tools
+const (
+	maintenanceCheckBrokenThumbnails = "brokenThumbnails"
+	maintenanceCheckSmallImages      = "smallImages"
+	maintenanceCheckLargeMovies      = "largeMovies"
+)
+
+func maintenanceScanKey(checkType, slot string) string {
+	return "maintenanceScan:" + checkType + ":" + slot
+}
+
+// recordMaintenanceScan stores a scan's findings, demoting the previous run
+// so getMaintenanceTrends can report run-to-run changes.
+func recordMaintenanceScan(cacheStore *cache.Cache, checkType string, assets []immich.Asset) {
+	assetIDs := make([]string, len(assets))
+	for i, asset := range assets {
+		assetIDs[i] = asset.ID
+	}
+
+	if latest, found := cacheStore.Get(maintenanceScanKey(checkType, "latest")); found {
+		cacheStore.Set(maintenanceScanKey(checkType, "previous"), latest, cache.NoExpiration)
+	}
+	cacheStore.Set(maintenanceScanKey(checkType, "latest"), maintenanceScanRecord{
+		CheckType: checkType,
+		RunAt:     time.Now(),
+		AssetIDs:  assetIDs,
+	}, cache.NoExpiration)
+}
+
+// invalidateAssetCaches drops cached query results after a bulk move so the
+// next query reflects the new album memberships. Maintenance scan records
+// are kept — they power trend reporting across runs.
+func invalidateAssetCaches(cacheStore *cache.Cache) {
+	for key := range cacheStore.Items() {
+		if strings.HasPrefix(key, "maintenanceScan:") {
+			continue
+		}
+		cacheStore.Delete(key)
+	}
+}
+
+// registerGetMaintenanceTrends registers the tool comparing the two most
+// recent maintenance scans per check type.
+func registerGetMaintenanceTrends(s *server.MCPServer, cacheStore *cache.Cache) {
+	tool := mcp.Tool{
+		Name:        "getMaintenanceTrends",
+		Description: "Compare the two most recent maintenance scans per check type, reporting newly broken assets, newly fixed ones, and the net change in library health",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"checkType": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{maintenanceCheckBrokenThumbnails, maintenanceCheckSmallImages, maintenanceCheckLargeMovies},
+					"description": "Limit the report to one check type (default: all recorded types)",
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			CheckType string `json:"checkType"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		checkTypes := []string{maintenanceCheckBrokenThumbnails, maintenanceCheckSmallImages, maintenanceCheckLargeMovies}
+		if params.CheckType != "" {
+			checkTypes = []string{params.CheckType}
+		}
+
+		trends := []map[string]interface{}{}
+		for _, checkType := range checkTypes {
+			latestVal, found := cacheStore.Get(maintenanceScanKey(checkType, "latest"))
+			if !found {
+				continue
+			}
+			latest, ok := latestVal.(maintenanceScanRecord)
+			if !ok {
+				continue
+			}
+
+			trend := map[string]interface{}{
+				"checkType":   checkType,
+				"latestRunAt": latest.RunAt,
+				"latestCount": len(latest.AssetIDs),
+				"hasPrevious": false,
+			}
+
+			if previousVal, found := cacheStore.Get(maintenanceScanKey(checkType, "previous")); found {
+				if previous, ok := previousVal.(maintenanceScanRecord); ok {
+					previousSet := make(map[string]bool, len(previous.AssetIDs))
+					for _, id := range previous.AssetIDs {
+						previousSet[id] = true
+					}
+					latestSet := make(map[string]bool, len(latest.AssetIDs))
+					for _, id := range latest.AssetIDs {
+						latestSet[id] = true
+					}
+
+					var newlyBroken, fixed []string
+					for _, id := range latest.AssetIDs {
+						if !previousSet[id] {
+							newlyBroken = append(newlyBroken, id)
+						}
+					}
+					for _, id := range previous.AssetIDs {
+						if !latestSet[id] {
+							fixed = append(fixed, id)
+						}
+					}
+
+					trend["hasPrevious"] = true
+					trend["previousRunAt"] = previous.RunAt
+					trend["previousCount"] = len(previous.AssetIDs)
+					trend["newlyBroken"] = len(newlyBroken)
+					trend["fixed"] = len(fixed)
+					trend["netChange"] = len(latest.AssetIDs) - len(previous.AssetIDs)
+					trend["newlyBrokenIds"] = newlyBroken
+					trend["fixedIds"] = fixed
+				}
+			}
+
+			trends = append(trends, trend)
+		}
+
+		if len(trends) == 0 {
+			return makeMCPResult(map[string]interface{}{
+				"success": true,
+				"trends":  trends,
+				"message": "No maintenance scans recorded yet; run a scan tool (e.g. moveBrokenThumbnailsToAlbum with dryRun) first",
+			})
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success": true,
+			"trends":  trends,
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
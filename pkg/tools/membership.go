@@ -0,0 +1,206 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// registerGetMembershipMatrix registers the multi-album overlap analysis tool
+func registerGetMembershipMatrix(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "getMembershipMatrix",
+		Description: "For a set of albums, report assets that belong to multiple of them and albums that are strict subsets of others — supports cleanup of redundant albums",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"albumNames": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Albums to compare by name (all albums if omitted)",
+				},
+				"albumIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Albums to compare by ID (alternative to albumNames)",
+				},
+				"maxSharedAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of multi-album assets to list individually",
+					"default":     50,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AlbumNames      []string `json:"albumNames"`
+			AlbumIds        []string `json:"albumIds"`
+			MaxSharedAssets int      `json:"maxSharedAssets"`
+		}
+
+		// Set defaults
+		params.MaxSharedAssets = 50
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		albums, err := immichClient.ListAlbums(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list albums: %w", err)
+		}
+
+		// Select the albums to compare
+		wantedNames := map[string]bool{}
+		for _, name := range params.AlbumNames {
+			wantedNames[name] = true
+		}
+		wantedIDs := map[string]bool{}
+		for _, id := range params.AlbumIds {
+			wantedIDs[id] = true
+		}
+
+		var selected []immich.Album
+		for _, album := range albums {
+			if len(wantedNames) == 0 && len(wantedIDs) == 0 {
+				selected = append(selected, album)
+			} else if wantedNames[album.AlbumName] || wantedIDs[album.ID] {
+				selected = append(selected, album)
+			}
+		}
+
+		if len(selected) < 2 {
+			return nil, fmt.Errorf("need at least two albums to compare (matched %d)", len(selected))
+		}
+
+		// Load membership per album
+		membership := map[string]map[string]bool{} // albumID -> set of asset IDs
+		assetAlbums := map[string][]string{}       // assetID -> album names
+		assetNames := map[string]string{}          // assetID -> filename
+		albumNameByID := map[string]string{}
+
+		for _, album := range selected {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			assets, err := immichClient.GetAlbumAssets(ctx, album.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get assets for album '%s': %w", album.AlbumName, err)
+			}
+
+			set := make(map[string]bool, len(assets))
+			for _, asset := range assets {
+				set[asset.ID] = true
+				assetAlbums[asset.ID] = append(assetAlbums[asset.ID], album.AlbumName)
+				assetNames[asset.ID] = asset.OriginalFileName
+			}
+			membership[album.ID] = set
+			albumNameByID[album.ID] = album.AlbumName
+		}
+
+		// Assets in multiple albums
+		type sharedAsset struct {
+			ID       string   `json:"id"`
+			FileName string   `json:"fileName"`
+			Albums   []string `json:"albums"`
+		}
+		var shared []sharedAsset
+		for assetID, names := range assetAlbums {
+			if len(names) > 1 {
+				sort.Strings(names)
+				shared = append(shared, sharedAsset{
+					ID:       assetID,
+					FileName: assetNames[assetID],
+					Albums:   names,
+				})
+			}
+		}
+		sort.Slice(shared, func(i, j int) bool {
+			if len(shared[i].Albums) != len(shared[j].Albums) {
+				return len(shared[i].Albums) > len(shared[j].Albums)
+			}
+			return shared[i].ID < shared[j].ID
+		})
+
+		sharedCount := len(shared)
+		if len(shared) > params.MaxSharedAssets {
+			shared = shared[:params.MaxSharedAssets]
+		}
+
+		// Pairwise overlap and subset relationships
+		type overlapEntry struct {
+			AlbumA        string  `json:"albumA"`
+			AlbumB        string  `json:"albumB"`
+			SharedAssets  int     `json:"sharedAssets"`
+			JaccardIndex  float64 `json:"jaccardIndex"`
+			SubsetOfOther string  `json:"subsetOfOther,omitempty"`
+		}
+
+		var overlaps []overlapEntry
+		var subsets []string
+
+		for i := 0; i < len(selected); i++ {
+			for j := i + 1; j < len(selected); j++ {
+				setA := membership[selected[i].ID]
+				setB := membership[selected[j].ID]
+				nameA := albumNameByID[selected[i].ID]
+				nameB := albumNameByID[selected[j].ID]
+
+				intersection := 0
+				for id := range setA {
+					if setB[id] {
+						intersection++
+					}
+				}
+				if intersection == 0 {
+					continue
+				}
+
+				union := len(setA) + len(setB) - intersection
+				entry := overlapEntry{
+					AlbumA:       nameA,
+					AlbumB:       nameB,
+					SharedAssets: intersection,
+					JaccardIndex: float64(intersection) / float64(union),
+				}
+
+				// Strict subset: every asset of one album is in the other, and sizes differ
+				if intersection == len(setA) && len(setA) < len(setB) {
+					entry.SubsetOfOther = nameA
+					subsets = append(subsets, fmt.Sprintf("'%s' is a strict subset of '%s'", nameA, nameB))
+				} else if intersection == len(setB) && len(setB) < len(setA) {
+					entry.SubsetOfOther = nameB
+					subsets = append(subsets, fmt.Sprintf("'%s' is a strict subset of '%s'", nameB, nameA))
+				}
+
+				overlaps = append(overlaps, entry)
+			}
+		}
+
+		sort.Slice(overlaps, func(i, j int) bool {
+			return overlaps[i].SharedAssets > overlaps[j].SharedAssets
+		})
+
+		return makeMCPResult(map[string]interface{}{
+			"success":          true,
+			"albumsCompared":   len(selected),
+			"multiAlbumAssets": sharedCount,
+			"sampleShared":     shared,
+			"overlaps":         overlaps,
+			"subsetAlbums":     subsets,
+			"message":          fmt.Sprintf("Compared %d albums: %d assets appear in more than one, %d strict subset relationships", len(selected), sharedCount, len(subsets)),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
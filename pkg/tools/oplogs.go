@@ -0,0 +1,203 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/mcp-immich/pkg/oplog"
+)
+
+// Operation log settings shared by all tool handlers; zero values leave
+// operation logging disabled
+var (
+	operationLogDir       string
+	operationLogRetention int
+)
+
+// InitOperationLogs points the tool handlers at the operation log directory
+// and sets how many log files to retain
+func InitOperationLogs(dir string, retention int) {
+	operationLogDir = dir
+	operationLogRetention = retention
+}
+
+// beginOperationLog opens a log for one run of the named operation, pruning
+// old logs past the retention limit. It returns nil — a valid, discarding
+// writer — when operation logging is disabled or the log cannot be created,
+// so callers log unconditionally and close via defer.
+func beginOperationLog(operation string) *oplog.Writer {
+	if operationLogDir == "" {
+		return nil
+	}
+	if _, err := oplog.Prune(operationLogDir, operationLogRetention); err != nil {
+		log.Warn().Err(err).Msg("Failed to prune operation logs")
+	}
+	writer, err := oplog.Open(operationLogDir, operation)
+	if err != nil {
+		log.Warn().Err(err).Str("operation", operation).Msg("Failed to open operation log")
+		return nil
+	}
+	return writer
+}
+
+// RegisterOperationLogTools registers the operation log browsing tools
+func RegisterOperationLogTools(s *server.MCPServer) {
+	registerListOperationLogs(s)
+	registerGetOperationLog(s)
+}
+
+func registerListOperationLogs(s *server.MCPServer) {
+	tool := mcp.Tool{
+		Name:        "listOperationLogs",
+		Description: "List the structured operation logs written by bulk runs, newest first",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"operation": map[string]interface{}{
+					"type":        "string",
+					"description": "Only list logs for this operation",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum logs to return",
+					"default":     50,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Operation string `json:"operation"`
+			Limit     int    `json:"limit"`
+		}
+
+		// Set defaults
+		params.Limit = 50
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if operationLogDir == "" {
+			return makeMCPResult(map[string]interface{}{
+				"success": false,
+				"message": "Operation logging is disabled; set operation_log_dir in the configuration",
+			})
+		}
+
+		infos, err := oplog.List(operationLogDir)
+		if err != nil {
+			return nil, err
+		}
+
+		total := 0
+		entries := []oplog.Info{}
+		for _, info := range infos {
+			if params.Operation != "" && info.Operation != params.Operation {
+				continue
+			}
+			total++
+			if params.Limit <= 0 || len(entries) < params.Limit {
+				entries = append(entries, info)
+			}
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success": true,
+			"total":   total,
+			"shown":   len(entries),
+			"logs":    entries,
+			"message": fmt.Sprintf("%d operation logs in %s", total, operationLogDir),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+func registerGetOperationLog(s *server.MCPServer) {
+	tool := mcp.Tool{
+		Name:        "getOperationLog",
+		Description: "Read one operation log's records: the start record, per-batch progress, and the end summary",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Log file name from listOperationLogs",
+				},
+				"events": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"all", "summary"},
+					"description": "all returns every record; summary skips per-batch progress",
+					"default":     "all",
+				},
+			},
+			Required: []string{"name"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Name   string `json:"name"`
+			Events string `json:"events"`
+		}
+
+		// Set defaults
+		params.Events = "all"
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if params.Name == "" {
+			return nil, fmt.Errorf("name is required")
+		}
+		if operationLogDir == "" {
+			return makeMCPResult(map[string]interface{}{
+				"success": false,
+				"message": "Operation logging is disabled; set operation_log_dir in the configuration",
+			})
+		}
+
+		records, err := oplog.Read(operationLogDir, params.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		total := len(records)
+		if params.Events == "summary" {
+			kept := records[:0]
+			for _, record := range records {
+				if record.Event != "progress" {
+					kept = append(kept, record)
+				}
+			}
+			records = kept
+		}
+
+		result := map[string]interface{}{
+			"success": true,
+			"name":    params.Name,
+			"total":   total,
+			"shown":   len(records),
+			"records": records,
+		}
+		// An end record means the run finished; its absence means a crash or
+		// an operation still in flight
+		complete := false
+		for _, record := range records {
+			if record.Event == "end" {
+				complete = true
+				break
+			}
+		}
+		result["complete"] = complete
+		result["message"] = fmt.Sprintf("%d records in %s", total, params.Name)
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
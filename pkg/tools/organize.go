@@ -0,0 +1,326 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/patrickmn/go-cache"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// organizePlanCategory is one maintenance category inside an organize plan
+type organizePlanCategory struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Destination string   `json:"destination"`
+	AssetIDs    []string `json:"-"`
+	Count       int      `json:"count"`
+	SampleFiles []string `json:"sampleFiles,omitempty"`
+}
+
+// organizePlan is a reviewed-then-applied library organization plan
+type organizePlan struct {
+	PlanID     string                 `json:"planId"`
+	CreatedAt  time.Time              `json:"createdAt"`
+	Scanned    int                    `json:"scannedAssets"`
+	Categories []organizePlanCategory `json:"categories"`
+}
+
+var screenshotPattern = regexp.MustCompile(`(?i)^(screenshot|screen[ _-]?shot|scrnshot)`)
+
+// registerOrganizeLibrary registers the plan/apply macro tool that chains the
+// individual maintenance checks into a single reviewed operation.
+func registerOrganizeLibrary(s *server.MCPServer, immichClient *immich.Client, cacheStore *cache.Cache) {
+	tool := mcp.Tool{
+		Name:        "organizeLibrary",
+		Description: "Scan the library for maintenance categories (broken thumbnails, small images, screenshots, large movies) and produce a plan for review, then apply an approved plan in one run",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"phase": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"plan", "apply"},
+					"description": "'plan' scans and returns a proposal; 'apply' executes a previously returned plan",
+					"default":     "plan",
+				},
+				"planId": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the plan to apply (required for phase=apply)",
+				},
+				"categories": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Restrict the plan/apply to these categories (brokenThumbnails, smallImages, screenshots, largeMovies)",
+				},
+				"maxAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of assets to scan (0 for unlimited)",
+					"default":     0,
+				},
+				"smallImageMaxDimension": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum dimension in pixels to classify an image as small",
+					"default":     400,
+				},
+				"largeMovieMinMinutes": map[string]interface{}{
+					"type":        "integer",
+					"description": "Minimum duration in minutes to classify a video as a large movie",
+					"default":     20,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Phase                  string   `json:"phase"`
+			PlanID                 string   `json:"planId"`
+			Categories             []string `json:"categories"`
+			MaxAssets              int      `json:"maxAssets"`
+			SmallImageMaxDimension int      `json:"smallImageMaxDimension"`
+			LargeMovieMinMinutes   int      `json:"largeMovieMinMinutes"`
+		}
+
+		// Set defaults
+		params.Phase = "plan"
+		params.SmallImageMaxDimension = 400
+		params.LargeMovieMinMinutes = 20
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		switch params.Phase {
+		case "plan":
+			return buildOrganizePlan(ctx, immichClient, cacheStore, params.Categories, params.MaxAssets,
+				params.SmallImageMaxDimension, params.LargeMovieMinMinutes)
+		case "apply":
+			if params.PlanID == "" {
+				return nil, fmt.Errorf("planId is required for phase=apply")
+			}
+			return applyOrganizePlan(ctx, immichClient, cacheStore, params.PlanID, params.Categories)
+		default:
+			return nil, fmt.Errorf("invalid phase: %s", params.Phase)
+		}
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// buildOrganizePlan scans the library once and classifies assets into maintenance categories
+func buildOrganizePlan(ctx context.Context, immichClient *immich.Client, cacheStore *cache.Cache, only []string, maxAssets, smallMaxDim, largeMinMinutes int) (*mcp.CallToolResult, error) {
+	wanted := map[string]bool{}
+	for _, c := range only {
+		wanted[c] = true
+	}
+	include := func(name string) bool {
+		return len(wanted) == 0 || wanted[name]
+	}
+
+	categories := map[string]*organizePlanCategory{
+		"brokenThumbnails": {
+			Name:        "brokenThumbnails",
+			Description: "Images with no thumbhash (broken thumbnails)",
+			Destination: "Broken Thumbnails",
+		},
+		"smallImages": {
+			Name:        "smallImages",
+			Description: fmt.Sprintf("Images %dx%d pixels or smaller", smallMaxDim, smallMaxDim),
+			Destination: "Small Images",
+		},
+		"screenshots": {
+			Name:        "screenshots",
+			Description: "Images whose filename indicates a screenshot",
+			Destination: "Screenshots",
+		},
+		"largeMovies": {
+			Name:        "largeMovies",
+			Description: fmt.Sprintf("Videos longer than %d minutes", largeMinMinutes),
+			Destination: "Large Movies",
+		},
+	}
+
+	addToCategory := func(name string, asset immich.Asset) {
+		cat := categories[name]
+		cat.AssetIDs = append(cat.AssetIDs, asset.ID)
+		cat.Count++
+		if len(cat.SampleFiles) < 5 {
+			cat.SampleFiles = append(cat.SampleFiles, asset.OriginalFileName)
+		}
+	}
+
+	page := 1
+	pageSize := 1000
+	scanned := 0
+
+	for {
+		// Check for context cancellation
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+		default:
+		}
+
+		assetPage, err := immichClient.GetAllAssets(ctx, page, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get assets page %d: %w", page, err)
+		}
+
+		for _, asset := range assetPage.Assets {
+			scanned++
+
+			switch asset.Type {
+			case "IMAGE":
+				if include("brokenThumbnails") && asset.Thumbhash == "" {
+					addToCategory("brokenThumbnails", asset)
+				}
+				if include("smallImages") && asset.ExifInfo != nil {
+					width := asset.ExifInfo.ExifImageWidth
+					height := asset.ExifInfo.ExifImageHeight
+					if width > 0 && height > 0 && width <= smallMaxDim && height <= smallMaxDim {
+						addToCategory("smallImages", asset)
+					}
+				}
+				if include("screenshots") && screenshotPattern.MatchString(asset.OriginalFileName) {
+					addToCategory("screenshots", asset)
+				}
+			case "VIDEO":
+				if include("largeMovies") && asset.Duration != nil {
+					if parseDuration(*asset.Duration) >= largeMinMinutes*60 {
+						addToCategory("largeMovies", asset)
+					}
+				}
+			}
+		}
+
+		if maxAssets > 0 && scanned >= maxAssets {
+			break
+		}
+		if !assetPage.HasNextPage {
+			break
+		}
+		page++
+	}
+
+	plan := organizePlan{
+		PlanID:    fmt.Sprintf("plan-%d", time.Now().UnixNano()),
+		CreatedAt: time.Now(),
+		Scanned:   scanned,
+	}
+	for _, name := range []string{"brokenThumbnails", "smallImages", "screenshots", "largeMovies"} {
+		if include(name) {
+			plan.Categories = append(plan.Categories, *categories[name])
+		}
+	}
+
+	// Keep the full plan (including asset IDs) server-side for the apply phase
+	cacheStore.Set("organizePlan:"+plan.PlanID, plan, 1*time.Hour)
+
+	return makeMCPResult(map[string]interface{}{
+		"success":       true,
+		"planId":        plan.PlanID,
+		"scannedAssets": plan.Scanned,
+		"categories":    plan.Categories,
+		"message":       fmt.Sprintf("Plan %s ready; review and call organizeLibrary with phase=apply to execute (valid for 1 hour)", plan.PlanID),
+	})
+}
+
+// applyOrganizePlan executes a previously built plan, moving each category into its destination album
+func applyOrganizePlan(ctx context.Context, immichClient *immich.Client, cacheStore *cache.Cache, planID string, only []string) (*mcp.CallToolResult, error) {
+	cached, found := cacheStore.Get("organizePlan:" + planID)
+	if !found {
+		return nil, fmt.Errorf("plan '%s' not found or expired; run phase=plan again", planID)
+	}
+	plan := cached.(organizePlan)
+
+	wanted := map[string]bool{}
+	for _, c := range only {
+		wanted[c] = true
+	}
+
+	albums, err := immichClient.ListAlbums(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list albums: %w", err)
+	}
+	albumsByName := map[string]string{}
+	for _, album := range albums {
+		albumsByName[album.AlbumName] = album.ID
+	}
+
+	type categoryResult struct {
+		Name        string `json:"name"`
+		Destination string `json:"destination"`
+		Moved       int    `json:"moved"`
+		Failed      int    `json:"failed"`
+		Error       string `json:"error,omitempty"`
+	}
+
+	results := []categoryResult{}
+	totalMoved := 0
+
+	for _, cat := range plan.Categories {
+		if len(wanted) > 0 && !wanted[cat.Name] {
+			continue
+		}
+		if cat.Count == 0 {
+			continue
+		}
+
+		// Check for context cancellation
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+		default:
+		}
+
+		catResult := categoryResult{Name: cat.Name, Destination: cat.Destination}
+
+		albumID, found := albumsByName[cat.Destination]
+		if !found {
+			newAlbum, err := immichClient.CreateAlbum(ctx, immich.CreateAlbumParams{
+				Name:        cat.Destination,
+				Description: cat.Description,
+			})
+			if err != nil {
+				catResult.Error = fmt.Sprintf("album creation failed: %v", err)
+				results = append(results, catResult)
+				continue
+			}
+			albumID = newAlbum.ID
+			albumsByName[cat.Destination] = albumID
+		}
+
+		if err := checkAlbumLock(albumID, cat.Destination); err != nil {
+			catResult.Error = err.Error()
+			results = append(results, catResult)
+			continue
+		}
+
+		bulkResult, err := immichClient.AddAssetsToAlbum(ctx, albumID, cat.AssetIDs)
+		if err != nil {
+			catResult.Error = fmt.Sprintf("adding assets failed: %v", err)
+			results = append(results, catResult)
+			continue
+		}
+
+		catResult.Moved = len(bulkResult.Success)
+		catResult.Failed = len(bulkResult.Error)
+		totalMoved += catResult.Moved
+		results = append(results, catResult)
+	}
+
+	// A plan is single-use: drop it once applied
+	cacheStore.Delete("organizePlan:" + planID)
+
+	return makeMCPResult(map[string]interface{}{
+		"success":    true,
+		"planId":     planID,
+		"categories": results,
+		"totalMoved": totalMoved,
+		"message":    fmt.Sprintf("Applied plan %s: moved %d assets across %d categories", planID, totalMoved, len(results)),
+	})
+}
@@ -0,0 +1,323 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// listPeople tool
+func registerListPeople(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "listPeople",
+		Description: "List the people Immich's face recognition has detected, with their IDs for renamePerson and mergePeople",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"page": map[string]interface{}{
+					"type":        "integer",
+					"description": "Page number",
+					"default":     1,
+				},
+				"size": map[string]interface{}{
+					"type":        "integer",
+					"description": "People per page",
+					"default":     100,
+				},
+				"withHidden": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include people hidden from the UI",
+					"default":     false,
+				},
+				"nameContains": map[string]interface{}{
+					"type":        "string",
+					"description": "Only people whose name contains this substring (case-insensitive); unnamed people match the empty string only",
+				},
+				"unnamedOnly": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Only people without a name, i.e. clusters awaiting review",
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Page         int    `json:"page"`
+			Size         int    `json:"size"`
+			WithHidden   bool   `json:"withHidden"`
+			NameContains string `json:"nameContains"`
+			UnnamedOnly  bool   `json:"unnamedOnly"`
+		}
+
+		// Set defaults
+		params.Page = 1
+		params.Size = 100
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if params.Page <= 0 {
+			params.Page = 1
+		}
+		if params.Size <= 0 {
+			params.Size = 100
+		}
+
+		pageResult, err := immichClient.ListPeople(ctx, params.Page, params.Size, params.WithHidden)
+		if err != nil {
+			return nil, err
+		}
+
+		people := []map[string]interface{}{}
+		for _, person := range pageResult.People {
+			if params.UnnamedOnly && person.Name != "" {
+				continue
+			}
+			if params.NameContains != "" && !strings.Contains(strings.ToLower(person.Name), strings.ToLower(params.NameContains)) {
+				continue
+			}
+			entry := map[string]interface{}{
+				"id":   person.ID,
+				"name": person.Name,
+			}
+			if person.BirthDate != "" {
+				entry["birthDate"] = person.BirthDate
+			}
+			if person.IsHidden {
+				entry["isHidden"] = true
+			}
+			people = append(people, entry)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success": true,
+			"people":  people,
+			"shown":   len(people),
+			"total":   pageResult.Total,
+			"hidden":  pageResult.Hidden,
+			"page":    params.Page,
+			"message": fmt.Sprintf("Showing %d of %d detected people", len(people), pageResult.Total),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// renamePerson tool
+func registerRenamePerson(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "renamePerson",
+		Description: "Set or change the name of a detected person",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"personId": map[string]interface{}{
+					"type":        "string",
+					"description": "Person ID (from listPeople)",
+				},
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "New name for the person",
+				},
+			},
+			Required: []string{"personId", "name"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			PersonID string `json:"personId"`
+			Name     string `json:"name"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if params.PersonID == "" || params.Name == "" {
+			return nil, fmt.Errorf("personId and name are required")
+		}
+
+		before, err := immichClient.GetPerson(ctx, params.PersonID)
+		if err != nil {
+			return nil, err
+		}
+
+		person, err := immichClient.UpdatePerson(ctx, params.PersonID, map[string]interface{}{
+			"name": params.Name,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		message := fmt.Sprintf("Person named '%s'", person.Name)
+		if before.Name != "" && before.Name != person.Name {
+			message = fmt.Sprintf("Person renamed '%s' -> '%s'", before.Name, person.Name)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":  true,
+			"personId": person.ID,
+			"name":     person.Name,
+			"oldName":  before.Name,
+			"message":  message,
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// setPersonBirthDate tool
+func registerSetPersonBirthDate(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "setPersonBirthDate",
+		Description: "Set a detected person's birth date, which Immich uses to show their age on photos",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"personId": map[string]interface{}{
+					"type":        "string",
+					"description": "Person ID (from listPeople)",
+				},
+				"birthDate": map[string]interface{}{
+					"type":        "string",
+					"description": "Birth date as YYYY-MM-DD (empty string clears it)",
+				},
+			},
+			Required: []string{"personId", "birthDate"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			PersonID  string `json:"personId"`
+			BirthDate string `json:"birthDate"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if params.PersonID == "" {
+			return nil, fmt.Errorf("personId is required")
+		}
+		if params.BirthDate != "" {
+			if _, err := time.Parse("2006-01-02", params.BirthDate); err != nil {
+				return nil, fmt.Errorf("invalid birthDate (expected YYYY-MM-DD): %w", err)
+			}
+		}
+
+		// An empty date clears the field
+		var birthDate interface{}
+		if params.BirthDate != "" {
+			birthDate = params.BirthDate
+		}
+		person, err := immichClient.UpdatePerson(ctx, params.PersonID, map[string]interface{}{
+			"birthDate": birthDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		message := fmt.Sprintf("Birth date for '%s' set to %s", person.Name, params.BirthDate)
+		if params.BirthDate == "" {
+			message = fmt.Sprintf("Birth date for '%s' cleared", person.Name)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":   true,
+			"personId":  person.ID,
+			"name":      person.Name,
+			"birthDate": person.BirthDate,
+			"message":   message,
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// mergePeople tool
+func registerMergePeople(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "mergePeople",
+		Description: "Merge duplicate person clusters into one: all faces from the source people are reassigned to the target person and the sources are removed",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"targetId": map[string]interface{}{
+					"type":        "string",
+					"description": "Person to keep; usually the one with the correct name",
+				},
+				"sourceIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Duplicate person IDs to merge into the target",
+				},
+			},
+			Required: []string{"targetId", "sourceIds"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			TargetID  string   `json:"targetId"`
+			SourceIds []string `json:"sourceIds"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if params.TargetID == "" || len(params.SourceIds) == 0 {
+			return nil, fmt.Errorf("targetId and sourceIds are required")
+		}
+		for _, id := range params.SourceIds {
+			if id == params.TargetID {
+				return nil, fmt.Errorf("targetId must not appear in sourceIds")
+			}
+		}
+
+		target, err := immichClient.GetPerson(ctx, params.TargetID)
+		if err != nil {
+			return nil, err
+		}
+
+		items, err := immichClient.MergePeople(ctx, params.TargetID, params.SourceIds)
+		if err != nil {
+			return nil, err
+		}
+
+		merged := 0
+		failed := []map[string]interface{}{}
+		for _, item := range items {
+			if item.Success {
+				merged++
+				continue
+			}
+			failure := map[string]interface{}{"id": item.ID}
+			if item.Error != "" {
+				failure["error"] = item.Error
+			}
+			failed = append(failed, failure)
+		}
+
+		result := map[string]interface{}{
+			"success":    len(failed) == 0,
+			"targetId":   target.ID,
+			"targetName": target.Name,
+			"merged":     merged,
+			"message":    fmt.Sprintf("Merged %d of %d people into '%s'", merged, len(params.SourceIds), target.Name),
+		}
+		if len(failed) > 0 {
+			result["failed"] = failed
+		}
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
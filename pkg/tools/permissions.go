@@ -0,0 +1,120 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// probeUUID is a syntactically valid but nonexistent ID used for write
+// probes: an authorized key gets a 400/404 back, an unauthorized one a 403.
+const probeUUID = "00000000-0000-0000-0000-000000000000"
+
+// capabilityTools maps each probed capability to the MCP tools that need it.
+var capabilityTools = map[string][]string{
+	"read": {
+		"queryPhotos", "getAllAssets", "getPhotoMetadata", "smartSearchAdvanced",
+		"findTimelineGaps", "listDevices",
+	},
+	"albumRead": {
+		"getAllAlbums", "getMembershipMatrix",
+	},
+	"albumWrite": {
+		"moveBrokenThumbnailsToAlbum", "moveSmallImagesToAlbum", "moveLargeMoviesToAlbum",
+		"movePhotosBySearch", "movePersonalVideosFromAlbum", "refreshSmartAlbum", "renameAlbum",
+	},
+	"delete": {
+		"deleteAlbumContents",
+	},
+	"admin": {
+		"checkPermissions (admin detail)",
+	},
+	"library": {
+		"listLibraries", "moveToLibrary",
+	},
+}
+
+// registerCheckPermissions registers the API-key capability preflight tool.
+func registerCheckPermissions(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "checkPermissions",
+		Description: "Probe the configured Immich API key's capabilities (read, album write, delete, admin, library management) via harmless requests and report which MCP tools will and won't work",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		capabilities := map[string]bool{}
+
+		// Read: fetch a single asset page
+		_, err := immichClient.GetAllAssets(ctx, 1, 1)
+		capabilities["read"] = probeAllowed(err)
+
+		// Album read: list albums
+		_, err = immichClient.ListAlbums(ctx, false)
+		capabilities["albumRead"] = probeAllowed(err)
+
+		// Album write: update a nonexistent album; 404 proves authorization
+		_, err = immichClient.UpdateAlbum(ctx, probeUUID, map[string]interface{}{})
+		capabilities["albumWrite"] = probeAllowed(err)
+
+		// Delete: trash a nonexistent asset ID
+		err = immichClient.DeleteAssets(ctx, []string{probeUUID}, false)
+		capabilities["delete"] = probeAllowed(err)
+
+		// Admin: list users
+		_, err = immichClient.ListUsers(ctx)
+		capabilities["admin"] = probeAllowed(err)
+
+		// Library management: list libraries
+		_, err = immichClient.ListLibraries(ctx)
+		capabilities["library"] = probeAllowed(err)
+
+		workingTools := []string{}
+		blockedTools := []string{}
+		for capability, toolNames := range capabilityTools {
+			if capabilities[capability] {
+				workingTools = append(workingTools, toolNames...)
+			} else {
+				blockedTools = append(blockedTools, toolNames...)
+			}
+		}
+
+		granted := 0
+		for _, allowed := range capabilities {
+			if allowed {
+				granted++
+			}
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":      true,
+			"capabilities": capabilities,
+			"workingTools": workingTools,
+			"blockedTools": blockedTools,
+			"message":      fmt.Sprintf("API key has %d of %d probed capabilities", granted, len(capabilities)),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// probeAllowed interprets a probe call's outcome: only authentication and
+// authorization failures mean the capability is missing. Not-found or
+// validation errors prove the key got past the permission check.
+func probeAllowed(err error) bool {
+	if err == nil {
+		return true
+	}
+	var apiErr *immich.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status != 401 && apiErr.Status != 403
+	}
+	return false
+}
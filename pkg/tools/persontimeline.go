@@ -0,0 +1,199 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// timelineSampleLimit is how many representative assets each period lists
+const timelineSampleLimit = 3
+
+// registerGetPersonTimeline registers the person appearance timeline tool
+func registerGetPersonTimeline(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "getPersonTimeline",
+		Description: "Summarize how often a person appears over time: per-year or per-month asset counts with first/last seen dates and representative assets per period",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"personId": map[string]interface{}{
+					"type":        "string",
+					"description": "Person ID (from listPeople)",
+				},
+				"personName": map[string]interface{}{
+					"type":        "string",
+					"description": "Person name (alternative to personId; must match a named person)",
+				},
+				"groupBy": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"year", "month"},
+					"description": "Period granularity",
+					"default":     "year",
+				},
+				"maxAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum appearances to count (0 for the stream's default cap)",
+					"default":     0,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			PersonID   string `json:"personId"`
+			PersonName string `json:"personName"`
+			GroupBy    string `json:"groupBy"`
+			MaxAssets  int    `json:"maxAssets"`
+		}
+
+		// Set defaults
+		params.GroupBy = "year"
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if params.GroupBy != "year" && params.GroupBy != "month" {
+			return nil, fmt.Errorf("invalid groupBy: %s (must be year or month)", params.GroupBy)
+		}
+
+		person, candidates, err := resolvePerson(ctx, immichClient, params.PersonID, params.PersonName)
+		if err != nil {
+			return nil, err
+		}
+		if person == nil {
+			return makeMCPResult(map[string]interface{}{
+				"success":    false,
+				"candidates": candidates,
+				"message":    fmt.Sprintf("No person named '%s'; did you mean one of the candidates?", params.PersonName),
+			})
+		}
+
+		layout := "2006"
+		if params.GroupBy == "month" {
+			layout = "2006-01"
+		}
+
+		// Stream the person's assets with the filter pushed to the server
+		type periodStats struct {
+			count   int
+			samples []map[string]interface{}
+		}
+		periods := map[string]*periodStats{}
+		total := 0
+		var earliest, latest immich.Asset
+		err = immichClient.SmartSearchStream(ctx, immich.SmartSearchParams{
+			PersonIds: []string{person.ID},
+			Size:      params.MaxAssets,
+		}, func(asset immich.Asset) error {
+			total++
+			taken := assetCaptureTime(asset)
+			key := "unknown"
+			if !taken.IsZero() {
+				key = taken.Format(layout)
+				if earliest.ID == "" || taken.Before(assetCaptureTime(earliest)) {
+					earliest = asset
+				}
+				if latest.ID == "" || taken.After(assetCaptureTime(latest)) {
+					latest = asset
+				}
+			}
+			stats := periods[key]
+			if stats == nil {
+				stats = &periodStats{}
+				periods[key] = stats
+			}
+			stats.count++
+			if len(stats.samples) < timelineSampleLimit {
+				stats.samples = append(stats.samples, map[string]interface{}{
+					"id":       asset.ID,
+					"fileName": asset.OriginalFileName,
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to stream person assets: %w", err)
+		}
+
+		keys := make([]string, 0, len(periods))
+		for key := range periods {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		timeline := make([]map[string]interface{}, 0, len(keys))
+		for _, key := range keys {
+			timeline = append(timeline, map[string]interface{}{
+				"period":  key,
+				"count":   periods[key].count,
+				"samples": periods[key].samples,
+			})
+		}
+
+		result := map[string]interface{}{
+			"success":    true,
+			"personId":   person.ID,
+			"personName": person.Name,
+			"groupBy":    params.GroupBy,
+			"total":      total,
+			"periods":    len(timeline),
+			"timeline":   timeline,
+		}
+		if earliest.ID != "" {
+			result["firstSeen"] = map[string]interface{}{
+				"at":       assetCaptureTime(earliest).Format("2006-01-02"),
+				"id":       earliest.ID,
+				"fileName": earliest.OriginalFileName,
+			}
+			result["lastSeen"] = map[string]interface{}{
+				"at":       assetCaptureTime(latest).Format("2006-01-02"),
+				"id":       latest.ID,
+				"fileName": latest.OriginalFileName,
+			}
+		}
+		result["message"] = fmt.Sprintf("'%s' appears in %d assets across %d %ss", person.Name, total, len(timeline), params.GroupBy)
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// resolvePerson finds a person by ID or by name. An inexact name returns the
+// near-matching names as candidates instead of guessing.
+func resolvePerson(ctx context.Context, immichClient *immich.Client, personID, personName string) (*immich.Person, []string, error) {
+	if personID != "" {
+		person, err := immichClient.GetPerson(ctx, personID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return person, nil, nil
+	}
+	if personName == "" {
+		return nil, nil, fmt.Errorf("either personId or personName must be provided")
+	}
+
+	page, err := immichClient.ListPeople(ctx, 1, 1000, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var candidates []string
+	for i := range page.People {
+		person := &page.People[i]
+		if strings.EqualFold(person.Name, personName) {
+			return person, nil, nil
+		}
+		if person.Name != "" && strings.Contains(strings.ToLower(person.Name), strings.ToLower(personName)) {
+			candidates = append(candidates, person.Name)
+		}
+	}
+	return nil, candidates, nil
+}
@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/smartalbums"
+)
+
+// registerPinAssetsInAlbum registers the tool pair managing the pin
+// registry: pinned assets are manually curated additions that automated
+// album syncs must never prune.
+func registerPinAssetsInAlbum(s *server.MCPServer, immichClient *immich.Client, store *smartalbums.Store) {
+	pinTool := mcp.Tool{
+		Name:        "pinAssetsInAlbum",
+		Description: "Pin assets in an album so automated smart/live album updates never remove them. Pins protect manually curated additions from full-sync pruning.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"albumName": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the album the pins apply to",
+				},
+				"albumId": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the album (if known, otherwise will search by name)",
+				},
+				"assetIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Asset IDs to pin",
+				},
+			},
+			Required: []string{"assetIds"},
+		},
+	}
+
+	unpinTool := mcp.Tool{
+		Name:        "unpinAssetsInAlbum",
+		Description: "Remove pins from assets in an album, making them eligible for automated pruning again.",
+		InputSchema: pinTool.InputSchema,
+	}
+
+	makeHandler := func(pin bool) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				AlbumName string   `json:"albumName"`
+				AlbumID   string   `json:"albumId"`
+				AssetIds  []string `json:"assetIds"`
+			}
+
+			if err := decodeArgs(request, &params); err != nil {
+				return nil, err
+			}
+
+			if len(params.AssetIds) == 0 {
+				return nil, fmt.Errorf("assetIds is required")
+			}
+
+			// Find album if not provided by ID
+			albumID := params.AlbumID
+			albumName := params.AlbumName
+			if albumID == "" {
+				if albumName == "" {
+					return nil, fmt.Errorf("either albumName or albumId must be provided")
+				}
+				albums, err := immichClient.ListAlbums(ctx, false)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list albums: %w", err)
+				}
+				for _, album := range albums {
+					if album.AlbumName == albumName {
+						albumID = album.ID
+						break
+					}
+				}
+				if albumID == "" {
+					return nil, fmt.Errorf("album '%s' not found", albumName)
+				}
+			}
+
+			var changed int
+			var err error
+			verb := "pinned"
+			if pin {
+				changed, err = store.Pin(albumID, params.AssetIds)
+			} else {
+				verb = "unpinned"
+				changed, err = store.Unpin(albumID, params.AssetIds)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to update pin registry: %w", err)
+			}
+
+			return makeMCPResult(map[string]interface{}{
+				"success":     true,
+				"albumID":     albumID,
+				"changed":     changed,
+				"totalPinned": len(store.PinnedAssets(albumID)),
+				"message":     fmt.Sprintf("%s %d assets in album", verb, changed),
+			})
+		}
+	}
+
+	s.AddTool(pinTool, makeHandler(true))
+	s.AddTool(unpinTool, makeHandler(false))
+}
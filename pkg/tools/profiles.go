@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// profileCtxKey carries the client's parameter profile name in the request
+// context
+type profileCtxKey struct{}
+
+// parameterProfiles are the configured named presets of tool parameter
+// defaults; defaultProfileName is applied when a client selects none.
+var (
+	parameterProfiles  map[string]map[string]interface{}
+	defaultProfileName string
+)
+
+// SetParameterProfiles installs the configured parameter presets
+func SetParameterProfiles(profiles map[string]map[string]interface{}, defaultProfile string) {
+	parameterProfiles = profiles
+	defaultProfileName = defaultProfile
+}
+
+// WithProfile returns a context carrying the client's chosen parameter
+// profile, as selected via the X-MCP-Profile header
+func WithProfile(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, profileCtxKey{}, name)
+}
+
+// profileFromContext returns the profile selected for this request, falling
+// back to the configured default
+func profileFromContext(ctx context.Context) string {
+	if name, ok := ctx.Value(profileCtxKey{}).(string); ok && name != "" {
+		return name
+	}
+	return defaultProfileName
+}
+
+// ProfileMiddleware returns tool middleware that fills in the selected
+// profile's preset values for any parameters the caller did not set
+// explicitly. Preset keys a tool does not declare are ignored by its
+// parameter decoding.
+func ProfileMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			preset := parameterProfiles[profileFromContext(ctx)]
+			if len(preset) == 0 {
+				return next(ctx, request)
+			}
+
+			args := argumentsAsMap(request.Params.Arguments)
+			if args == nil {
+				return next(ctx, request)
+			}
+			for key, value := range preset {
+				if _, set := args[key]; !set {
+					args[key] = value
+				}
+			}
+			request.Params.Arguments = args
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// argumentsAsMap normalizes tool arguments into a key/value map, returning
+// nil for argument shapes presets cannot be merged into
+func argumentsAsMap(arguments interface{}) map[string]interface{} {
+	switch args := arguments.(type) {
+	case nil:
+		return map[string]interface{}{}
+	case map[string]interface{}:
+		return args
+	case []byte:
+		parsed := map[string]interface{}{}
+		if err := json.Unmarshal(args, &parsed); err != nil {
+			return nil
+		}
+		return parsed
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// destructiveTools names every tool that mutates the Immich library or
+// deletes server-side state. New mutating tools must be added here so
+// read-only mode keeps covering them.
+var destructiveTools = map[string]bool{
+	"analyzePhotos":                 true,
+	"createAlbum":                   true,
+	"createAlbumsFromCalendar":      true,
+	"defineSmartAlbum":              true,
+	"deleteAlbumContents":           true,
+	"deleteSavedSearch":             true,
+	"deleteSmartAlbum":              true,
+	"findEmptyAlbums":               true,
+	"generateAssetDescriptions":     true,
+	"hideAlbumFromTimeline":         true,
+	"importFavorites":               true,
+	"importSmartAlbums":             true,
+	"lockAssets":                    true,
+	"materializeSmartTags":          true,
+	"mergePeople":                   true,
+	"migrateLiveAlbums":             true,
+	"mirrorFolderStructureToAlbums": true,
+	"moveAssetsToAlbum":             true,
+	"moveBrokenThumbnailsToAlbum":   true,
+	"moveLargeMoviesToAlbum":        true,
+	"movePersonalVideosFromAlbum":   true,
+	"movePhotosBySearch":            true,
+	"moveSmallImagesToAlbum":        true,
+	"normalizeAlbumOrder":           true,
+	"organizeLibrary":               true,
+	"reconcileSmartAlbums":          true,
+	"refreshSmartAlbum":             true,
+	"renameAlbum":                   true,
+	"renamePerson":                  true,
+	"repairAssets":                  true,
+	"resolveDuplicates":             true,
+	"restoreRecycleSnapshot":        true,
+	"restoreServerState":            true,
+	"reviewRecentUploads":           true,
+	"rollbackSmartAlbum":            true,
+	"scrubMetadataForSharing":       true,
+	"setLiveAlbumSchedule":          true,
+	"setPersonBirthDate":            true,
+	"shiftAssetDates":               true,
+	"tagAlbumContents":              true,
+	"triageNonPhotos":               true,
+	"undoOperation":                 true,
+	"unlockAssets":                  true,
+	"updateAssetMetadata":           true,
+}
+
+// IsDestructiveTool reports whether the named tool mutates library or
+// server state.
+func IsDestructiveTool(name string) bool {
+	return destructiveTools[name]
+}
+
+// ReadOnlyMiddleware returns tool middleware that rejects destructive tools
+// with a policy error, so the server can be exposed to untrusted agents.
+// Dry runs are rejected too: a read-only server makes no mutation promises
+// to validate against.
+func ReadOnlyMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if IsDestructiveTool(request.Params.Name) {
+				return nil, fmt.Errorf("tool '%s' is disabled: the server is running in read-only mode", request.Params.Name)
+			}
+			return next(ctx, request)
+		}
+	}
+}
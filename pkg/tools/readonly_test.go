@@ -0,0 +1,189 @@
+package tools
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+)
+
+// mutatingClientMethods names every immich.Client method that writes to the
+// Immich server. A tool whose handler can reach one of these must be listed
+// in destructiveTools, or read-only mode, forced dry-run, and the audit log
+// all silently miss it.
+var mutatingClientMethods = map[string]bool{
+	"AddAssetsToAlbum":      true,
+	"AnalyzeAssets":         true,
+	"CreateAlbum":           true,
+	"CreateStack":           true,
+	"DeleteAlbum":           true,
+	"DeleteAssets":          true,
+	"MergePeople":           true,
+	"MoveAssetsToLibrary":   true,
+	"RemoveAssetsFromAlbum": true,
+	"RepairAssets":          true,
+	"RestoreAssets":         true,
+	"RunAssetJobs":          true,
+	"TagAssets":             true,
+	"UpdateAlbum":           true,
+	"UpdateAssetMetadata":   true,
+	"UpdateAssetsBulk":      true,
+	"UpdatePerson":          true,
+	"UpdateSharedLink":      true,
+	"UpsertTags":            true,
+}
+
+// mutatingQualifiedCalls names cross-package calls that mutate the library
+// on the tools' behalf.
+var mutatingQualifiedCalls = map[string]bool{
+	"bulkops.Run": true,
+}
+
+// probeOnlyTools use write endpoints solely against probeUUID, which never
+// identifies a real resource, so they mutate nothing despite the calls.
+var probeOnlyTools = map[string]bool{
+	"checkPermissions": true,
+}
+
+// packageFuncs parses this package's sources (tests excluded) and reports,
+// per function: the tool names it declares, the package-local functions it
+// calls, and whether it calls a mutating client method directly.
+type funcInfo struct {
+	toolNames []string
+	calls     map[string]bool
+	mutates   bool
+}
+
+func parsePackageFuncs(t *testing.T) map[string]*funcInfo {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", nil, 0)
+	if err != nil {
+		t.Fatalf("failed to parse package: %v", err)
+	}
+
+	funcs := make(map[string]*funcInfo)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Body == nil {
+					continue
+				}
+				info := &funcInfo{calls: make(map[string]bool)}
+				ast.Inspect(fn.Body, func(n ast.Node) bool {
+					switch node := n.(type) {
+					case *ast.CompositeLit:
+						if name, ok := toolLiteralName(node); ok {
+							info.toolNames = append(info.toolNames, name)
+						}
+					case *ast.CallExpr:
+						switch fun := node.Fun.(type) {
+						case *ast.Ident:
+							info.calls[fun.Name] = true
+						case *ast.SelectorExpr:
+							if mutatingClientMethods[fun.Sel.Name] {
+								info.mutates = true
+							}
+							if x, ok := fun.X.(*ast.Ident); ok {
+								if mutatingQualifiedCalls[x.Name+"."+fun.Sel.Name] {
+									info.mutates = true
+								}
+							}
+						}
+					}
+					return true
+				})
+				funcs[fn.Name.Name] = info
+			}
+		}
+	}
+	return funcs
+}
+
+// toolLiteralName extracts the Name field of an mcp.Tool composite literal.
+func toolLiteralName(lit *ast.CompositeLit) (string, bool) {
+	sel, ok := lit.Type.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Tool" {
+		return "", false
+	}
+	if x, ok := sel.X.(*ast.Ident); !ok || x.Name != "mcp" {
+		return "", false
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); !ok || key.Name != "Name" {
+			continue
+		}
+		if basic, ok := kv.Value.(*ast.BasicLit); ok {
+			if name, err := strconv.Unquote(basic.Value); err == nil {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// reachesMutation reports whether fn, or any package-local function reachable
+// from it, calls a mutating client method.
+func reachesMutation(funcs map[string]*funcInfo, fn string, seen map[string]bool) bool {
+	if seen[fn] {
+		return false
+	}
+	seen[fn] = true
+	info, ok := funcs[fn]
+	if !ok {
+		return false
+	}
+	if info.mutates {
+		return true
+	}
+	for callee := range info.calls {
+		if reachesMutation(funcs, callee, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestDestructiveToolsCoversMutatingTools cross-checks every registered tool
+// that can reach a mutating client method against the destructiveTools map,
+// so a new mutating tool cannot ship outside the read-only, dry-run, and
+// audit gates.
+func TestDestructiveToolsCoversMutatingTools(t *testing.T) {
+	funcs := parsePackageFuncs(t)
+
+	registered := make(map[string]bool)
+	for fnName, info := range funcs {
+		for _, toolName := range info.toolNames {
+			registered[toolName] = true
+			if probeOnlyTools[toolName] {
+				continue
+			}
+			if reachesMutation(funcs, fnName, make(map[string]bool)) && !destructiveTools[toolName] {
+				t.Errorf("tool '%s' can reach a mutating client method but is missing from destructiveTools", toolName)
+			}
+		}
+	}
+
+	if len(registered) == 0 {
+		t.Fatal("no registered tools found; the parser is broken")
+	}
+
+	// Stale or misspelled entries would silently gate nothing
+	for name := range destructiveTools {
+		if !registered[name] {
+			t.Errorf("destructiveTools lists '%s', which is not a registered tool", name)
+		}
+	}
+	for name := range dryRunIncapableTools {
+		if !destructiveTools[name] {
+			t.Errorf("dryRunIncapableTools lists '%s', which is not in destructiveTools", name)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/patrickmn/go-cache"
+	"github.com/yourusername/mcp-immich/pkg/bulkops"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// registerReviewRecentUploads registers the tool that summarizes what was
+// added to the library recently and can route unfiled assets into a staging
+// album.
+func registerReviewRecentUploads(s *server.MCPServer, immichClient *immich.Client, cacheStore *cache.Cache) {
+	tool := mcp.Tool{
+		Name:        "reviewRecentUploads",
+		Description: "Summarize assets added in the last N days (counts by device, type, and album status), highlight items not yet in any album, and optionally batch-route them into a staging album",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"days": map[string]interface{}{
+					"type":        "integer",
+					"description": "Review assets added within this many days",
+					"default":     7,
+				},
+				"stageUnfiled": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Add assets that are in no album to the staging album",
+					"default":     false,
+				},
+				"stagingAlbum": map[string]interface{}{
+					"type":        "string",
+					"description": "Album to route unfiled assets into",
+					"default":     "Staging",
+				},
+				"maxAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum recent assets to review (0 for all)",
+					"default":     0,
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Report what would be staged without modifying anything",
+					"default":     false,
+				},
+				"aggregateOnly": aggregateOnlySchema(),
+				"detail":        detailSchema(),
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Days          int    `json:"days"`
+			StageUnfiled  bool   `json:"stageUnfiled"`
+			StagingAlbum  string `json:"stagingAlbum"`
+			MaxAssets     int    `json:"maxAssets"`
+			DryRun        bool   `json:"dryRun"`
+			AggregateOnly bool   `json:"aggregateOnly"`
+			Detail        string `json:"detail"`
+		}
+
+		// Set defaults
+		params.Days = 7
+		params.StagingAlbum = "Staging"
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if params.Days <= 0 {
+			params.Days = 7
+		}
+		cutoff := time.Now().AddDate(0, 0, -params.Days).Format(time.RFC3339)
+
+		// First pass: everything added since the cutoff, for the summary
+		byType := map[string]int{}
+		byDevice := map[string]int{}
+		recent, err := bulkops.ScanAssets(ctx, immichClient, bulkops.ScanOptions{
+			Max:     params.MaxAssets,
+			Filters: immich.MetadataSearchFilters{CreatedAfter: cutoff},
+		}, func(asset immich.Asset) bool {
+			byType[asset.Type]++
+			device := asset.DeviceID
+			if device == "" {
+				device = "(unknown)"
+			}
+			byDevice[device]++
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recent assets: %w", err)
+		}
+
+		// Second pass: the subset in no album, pushed down to the server
+		notInAlbum := true
+		unfiled, err := bulkops.ScanAssets(ctx, immichClient, bulkops.ScanOptions{
+			Max: params.MaxAssets,
+			Filters: immich.MetadataSearchFilters{
+				CreatedAfter: cutoff,
+				IsNotInAlbum: &notInAlbum,
+			},
+		}, func(asset immich.Asset) bool {
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan unfiled assets: %w", err)
+		}
+
+		result := map[string]interface{}{
+			"days":          params.Days,
+			"since":         cutoff,
+			"recentAssets":  len(recent.Assets),
+			"byType":        byType,
+			"byDevice":      byDevice,
+			"inAlbums":      len(recent.Assets) - len(unfiled.Assets),
+			"notInAnyAlbum": len(unfiled.Assets),
+		}
+		if params.AggregateOnly {
+			result["aggregateOnly"] = true
+			result["aggregate"] = aggregateAssets(recent.Assets)
+		} else {
+			result["sampleUnfiled"] = hydrateAssetSamples(ctx, immichClient, unfiled.Assets, 10, params.Detail)
+		}
+
+		if !params.StageUnfiled || len(unfiled.Assets) == 0 {
+			result["success"] = true
+			result["message"] = fmt.Sprintf("%d assets added in the last %d days; %d not yet in any album",
+				len(recent.Assets), params.Days, len(unfiled.Assets))
+			return makeMCPResult(result)
+		}
+
+		// Route the unfiled assets into the staging album
+		opLog := beginOperationLog("reviewRecentUploads")
+		defer opLog.Close()
+		moveResult, err := bulkops.MoveMatchingAssets(ctx, immichClient,
+			func(ctx context.Context) ([]immich.Asset, error) { return unfiled.Assets, nil },
+			bulkops.Target{
+				AlbumName:        params.StagingAlbum,
+				AlbumDescription: "Recently added assets awaiting review",
+				CreateAlbum:      true,
+			},
+			bulkops.Options{
+				DryRun:          params.DryRun,
+				InvalidateCache: func() { invalidateAssetCaches(cacheStore) },
+				Guard:           checkAlbumLock,
+				Log:             opLog,
+				Undo:            undoJournal,
+			})
+		if err != nil {
+			return nil, err
+		}
+
+		result["stagingAlbum"] = params.StagingAlbum
+		if params.DryRun {
+			result["dryRun"] = true
+			result["success"] = true
+			result["message"] = fmt.Sprintf("DRY RUN: would stage %d unfiled assets into '%s'", len(unfiled.Assets), params.StagingAlbum)
+			return makeMCPResult(result)
+		}
+
+		result["stagingAlbumId"] = moveResult.AlbumID
+		result["albumCreated"] = moveResult.AlbumCreated
+		result["staged"] = moveResult.Moved
+		result["failed"] = moveResult.Failed
+		if len(moveResult.Errors) > 0 {
+			result["errors"] = moveResult.Errors
+		}
+		result["success"] = true
+		result["message"] = fmt.Sprintf("%d assets added in the last %d days; staged %d unfiled assets into '%s'",
+			len(recent.Assets), params.Days, moveResult.Moved, params.StagingAlbum)
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// RegisterRecordedWritesTool registers the tool that inspects the writes
+// captured in record-only mode. The server registers it only when
+// write_mode is record-only.
+func RegisterRecordedWritesTool(s *server.MCPServer, recorder *immich.WriteRecorder) {
+	tool := mcp.Tool{
+		Name:        "listRecordedWrites",
+		Description: "Inspect the mutating Immich API calls captured in record-only write mode instead of being executed, and optionally clear the recording",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Return at most this many of the latest recorded calls (0 for all)",
+					"default":     50,
+				},
+				"clear": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Discard the recorded calls after reporting them",
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Limit int  `json:"limit"`
+			Clear bool `json:"clear"`
+		}
+
+		// Set defaults
+		params.Limit = 50
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		writes := recorder.Writes()
+		shown := writes
+		if params.Limit > 0 && len(shown) > params.Limit {
+			shown = shown[len(shown)-params.Limit:]
+		}
+
+		result := map[string]interface{}{
+			"writeMode": "record-only",
+			"total":     len(writes),
+			"shown":     len(shown),
+			"writes":    shown,
+		}
+
+		if params.Clear {
+			result["cleared"] = recorder.Clear()
+		}
+
+		result["success"] = true
+		result["message"] = fmt.Sprintf("%d mutating calls recorded instead of being sent to Immich", len(writes))
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
@@ -0,0 +1,223 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/recycle"
+)
+
+// recycleStore is consulted by destructive tools to snapshot removed asset
+// IDs before deleting; nil disables snapshotting
+var recycleStore *recycle.Store
+
+// SetRecycleStore installs the store destructive tools snapshot into
+func SetRecycleStore(store *recycle.Store) {
+	recycleStore = store
+}
+
+// recordRecycleSnapshot snapshots the asset IDs a destructive operation is
+// about to remove. Returns the snapshot ID, or "" when no store is
+// configured. Snapshot failures are returned so callers can surface them —
+// a destructive operation should not proceed silently without its safety
+// net.
+func recordRecycleSnapshot(albumID, albumName, operation string, assetIDs []string) (string, error) {
+	if recycleStore == nil {
+		return "", nil
+	}
+	snapshot, err := recycleStore.Record(albumID, albumName, operation, assetIDs)
+	if err != nil {
+		return "", fmt.Errorf("failed to record recycle snapshot: %w", err)
+	}
+	return snapshot.ID, nil
+}
+
+// RegisterRecycleTools registers tools for browsing and restoring recycle
+// snapshots
+func RegisterRecycleTools(s *server.MCPServer, immichClient *immich.Client, store *recycle.Store) {
+	SetRecycleStore(store)
+	registerListRecycleSnapshots(s, store)
+	registerRestoreRecycleSnapshot(s, immichClient, store)
+}
+
+// listRecycleSnapshots tool
+func registerListRecycleSnapshots(s *server.MCPServer, store *recycle.Store) {
+	tool := mcp.Tool{
+		Name:        "listRecycleSnapshots",
+		Description: "List the recycle snapshots recorded before destructive operations, newest first",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		type snapshotSummary struct {
+			ID         string `json:"id"`
+			AlbumName  string `json:"albumName"`
+			AlbumID    string `json:"albumId"`
+			Operation  string `json:"operation"`
+			AssetCount int    `json:"assetCount"`
+			CreatedAt  string `json:"createdAt"`
+		}
+
+		snapshots := store.List()
+		summaries := make([]snapshotSummary, 0, len(snapshots))
+		for _, snapshot := range snapshots {
+			summaries = append(summaries, snapshotSummary{
+				ID:         snapshot.ID,
+				AlbumName:  snapshot.AlbumName,
+				AlbumID:    snapshot.AlbumID,
+				Operation:  snapshot.Operation,
+				AssetCount: len(snapshot.AssetIDs),
+				CreatedAt:  snapshot.CreatedAt.Format("2006-01-02 15:04:05"),
+			})
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":   true,
+			"snapshots": summaries,
+			"message":   fmt.Sprintf("%d recycle snapshots available", len(summaries)),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// restoreRecycleSnapshot tool
+func registerRestoreRecycleSnapshot(s *server.MCPServer, immichClient *immich.Client, store *recycle.Store) {
+	tool := mcp.Tool{
+		Name:        "restoreRecycleSnapshot",
+		Description: "Restore the assets recorded in a recycle snapshot: bring them back from the Immich trash and re-add them to their album",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"snapshotId": map[string]interface{}{
+					"type":        "string",
+					"description": "Snapshot to restore (from listRecycleSnapshots)",
+				},
+				"targetAlbumName": map[string]interface{}{
+					"type":        "string",
+					"description": "Restore into this album instead of the snapshot's original album",
+				},
+				"restoreFromTrash": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Restore the assets from the Immich trash before re-adding them",
+					"default":     true,
+				},
+				"keepSnapshot": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Keep the snapshot after a successful restore instead of deleting it",
+					"default":     false,
+				},
+			},
+			Required: []string{"snapshotId"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			SnapshotID       string `json:"snapshotId"`
+			TargetAlbumName  string `json:"targetAlbumName"`
+			RestoreFromTrash bool   `json:"restoreFromTrash"`
+			KeepSnapshot     bool   `json:"keepSnapshot"`
+		}
+
+		// Set defaults
+		params.RestoreFromTrash = true
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		snapshot, found := store.Get(params.SnapshotID)
+		if !found {
+			return nil, fmt.Errorf("recycle snapshot '%s' not found", params.SnapshotID)
+		}
+		if len(snapshot.AssetIDs) == 0 {
+			return nil, fmt.Errorf("recycle snapshot '%s' records no assets", params.SnapshotID)
+		}
+
+		result := map[string]interface{}{
+			"snapshotId": snapshot.ID,
+			"assetCount": len(snapshot.AssetIDs),
+		}
+
+		// Assets deleted to trash must be restored before they can be
+		// re-added to an album
+		if params.RestoreFromTrash {
+			if err := immichClient.RestoreAssets(ctx, snapshot.AssetIDs); err != nil {
+				return nil, fmt.Errorf("failed to restore assets from trash: %w", err)
+			}
+			result["restoredFromTrash"] = true
+		}
+
+		// Resolve the target album: the original by ID when it still
+		// exists, otherwise find or recreate by name
+		albumName := snapshot.AlbumName
+		if params.TargetAlbumName != "" {
+			albumName = params.TargetAlbumName
+		}
+
+		albumID := ""
+		if params.TargetAlbumName == "" && snapshot.AlbumID != "" {
+			if _, err := immichClient.GetAlbumAssets(ctx, snapshot.AlbumID); err == nil {
+				albumID = snapshot.AlbumID
+			}
+		}
+		if albumID == "" && albumName != "" {
+			albums, err := immichClient.ListAlbums(ctx, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list albums: %w", err)
+			}
+			for _, album := range albums {
+				if album.AlbumName == albumName {
+					albumID = album.ID
+					break
+				}
+			}
+			if albumID == "" {
+				newAlbum, err := immichClient.CreateAlbum(ctx, immich.CreateAlbumParams{
+					Name: albumName,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to create album: %w", err)
+				}
+				albumID = newAlbum.ID
+				result["albumCreated"] = true
+			}
+		}
+		if albumID == "" {
+			return nil, fmt.Errorf("snapshot has no album to restore into; provide targetAlbumName")
+		}
+
+		bulkResult, err := immichClient.AddAssetsToAlbum(ctx, albumID, snapshot.AssetIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add assets to album: %w", err)
+		}
+
+		result["albumId"] = albumID
+		result["albumName"] = albumName
+		result["restored"] = len(bulkResult.Success)
+		result["failed"] = len(bulkResult.Error)
+
+		// A restored snapshot has served its purpose
+		if !params.KeepSnapshot {
+			if err := store.Delete(snapshot.ID); err != nil {
+				result["snapshotDeleteError"] = err.Error()
+			} else {
+				result["snapshotDeleted"] = true
+			}
+		}
+
+		result["success"] = true
+		result["message"] = fmt.Sprintf("Restored %d of %d assets into album '%s'", len(bulkResult.Success), len(snapshot.AssetIDs), albumName)
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
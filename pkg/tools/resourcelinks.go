@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// resourceLinkThreshold is the result size above which search tools return
+// MCP resource links (one per asset) instead of inlining full asset JSON,
+// so capable clients can fetch details lazily without flooding the context.
+const resourceLinkThreshold = 50
+
+// assetResourceURI returns the URI under which an asset's metadata can be
+// read as an MCP resource.
+func assetResourceURI(assetID string) string {
+	return "immich://assets/" + assetID
+}
+
+// makeAssetListResult builds a tool result for a list of matched assets.
+// Small result sets (and callers that pass asResourceLinks=false) are
+// inlined under the given field name as before; larger sets return a
+// compact summary plus one resource link per asset.
+func makeAssetListResult(summary map[string]interface{}, field string, assets []immich.Asset, asResourceLinks bool) (*mcp.CallToolResult, error) {
+	if !asResourceLinks && len(assets) <= resourceLinkThreshold {
+		summary[field] = assets
+		return makeMCPResult(summary)
+	}
+
+	summary["resultMode"] = "resourceLinks"
+	summary["matched"] = len(assets)
+	result, err := makeMCPResult(summary)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, asset := range assets {
+		description := asset.Type
+		if asset.ExifInfo != nil && asset.ExifInfo.DateTimeOriginal != "" {
+			description = fmt.Sprintf("%s taken %s", asset.Type, asset.ExifInfo.DateTimeOriginal)
+		}
+		result.Content = append(result.Content, mcp.NewResourceLink(
+			assetResourceURI(asset.ID),
+			asset.OriginalFileName,
+			description,
+			"application/json",
+		))
+	}
+
+	return result, nil
+}
+
+// RegisterAssetResources registers the resource template that resolves the
+// immich://assets/{id} links emitted by search tools.
+func RegisterAssetResources(s *server.MCPServer, immichClient *immich.Client) {
+	template := mcp.NewResourceTemplate(
+		"immich://assets/{id}",
+		"Immich asset metadata",
+		mcp.WithTemplateDescription("Full metadata for a single Immich asset, including EXIF and people"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	handler := func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		assetID := strings.TrimPrefix(request.Params.URI, "immich://assets/")
+		if assetID == "" || strings.Contains(assetID, "/") {
+			return nil, fmt.Errorf("invalid asset resource URI: %s", request.Params.URI)
+		}
+
+		asset, err := immichClient.GetAssetMetadata(ctx, assetID)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := json.Marshal(asset)
+		if err != nil {
+			return nil, err
+		}
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(content),
+			},
+		}, nil
+	}
+
+	s.AddResourceTemplate(template, handler)
+}
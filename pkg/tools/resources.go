@@ -0,0 +1,243 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// resourcePageSize is the default page size for paginated listing resources.
+const resourcePageSize = 100
+
+// albumResourceURI returns the URI under which an album can be read as an
+// MCP resource.
+func albumResourceURI(albumID string) string {
+	return "immich://albums/" + albumID
+}
+
+// personResourceURI returns the URI under which a person can be read as an
+// MCP resource.
+func personResourceURI(personID string) string {
+	return "immich://people/" + personID
+}
+
+// RegisterLibraryResources registers the browsable MCP resources: paginated
+// album, asset, and people listings plus per-entity templates, so clients
+// can explore the library without tool calls. The immich://assets/{id}
+// template is registered separately by RegisterAssetResources.
+func RegisterLibraryResources(s *server.MCPServer, immichClient *immich.Client) {
+	registerAlbumListResource(s, immichClient)
+	registerAlbumResource(s, immichClient)
+	registerAssetListResource(s, immichClient)
+	registerPeopleListResource(s, immichClient)
+	registerPersonResource(s, immichClient)
+}
+
+// resourcePage extracts page and size query parameters from a resource URI,
+// falling back to page 1 and the default page size.
+func resourcePage(uri string) (page, size int) {
+	page, size = 1, resourcePageSize
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return page, size
+	}
+	query := parsed.Query()
+	if n, err := strconv.Atoi(query.Get("page")); err == nil && n > 0 {
+		page = n
+	}
+	if n, err := strconv.Atoi(query.Get("size")); err == nil && n > 0 {
+		size = n
+	}
+	return page, size
+}
+
+// jsonResourceContents marshals v as the JSON contents of a resource read.
+func jsonResourceContents(uri string, v interface{}) ([]mcp.ResourceContents, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
+
+// resourcePathID extracts the trailing ID from a resource URI under prefix,
+// rejecting empty or nested paths.
+func resourcePathID(uri, prefix string) (string, error) {
+	id := strings.TrimPrefix(uri, prefix)
+	if id == "" || strings.Contains(id, "/") {
+		return "", fmt.Errorf("invalid resource URI: %s", uri)
+	}
+	// Strip any query component so IDs resolve cleanly
+	if at := strings.IndexByte(id, '?'); at >= 0 {
+		id = id[:at]
+	}
+	return id, nil
+}
+
+// immich://albums — compact album listing
+func registerAlbumListResource(s *server.MCPServer, immichClient *immich.Client) {
+	resource := mcp.NewResource(
+		"immich://albums",
+		"Immich albums",
+		mcp.WithResourceDescription("All albums with IDs, names, and asset counts; read immich://albums/{id} for details"),
+		mcp.WithMIMEType("application/json"),
+	)
+
+	s.AddResource(resource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		albums, err := immichClient.ListAlbums(ctx, false)
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]map[string]interface{}, 0, len(albums))
+		for _, album := range albums {
+			entries = append(entries, map[string]interface{}{
+				"id":         album.ID,
+				"name":       album.AlbumName,
+				"assetCount": album.AssetCount,
+				"shared":     album.Shared,
+				"uri":        albumResourceURI(album.ID),
+			})
+		}
+		return jsonResourceContents(request.Params.URI, map[string]interface{}{
+			"count":  len(entries),
+			"albums": entries,
+		})
+	})
+}
+
+// immich://albums/{id} — single album with its assets
+func registerAlbumResource(s *server.MCPServer, immichClient *immich.Client) {
+	template := mcp.NewResourceTemplate(
+		"immich://albums/{id}",
+		"Immich album",
+		mcp.WithTemplateDescription("A single album's metadata and contained assets"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	handler := func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		albumID, err := resourcePathID(request.Params.URI, "immich://albums/")
+		if err != nil {
+			return nil, err
+		}
+
+		album, err := immichClient.GetAlbumInfo(ctx, albumID)
+		if err != nil {
+			return nil, err
+		}
+		return jsonResourceContents(request.Params.URI, album)
+	}
+
+	s.AddResourceTemplate(template, handler)
+}
+
+// immich://assets{?page,size} — paginated asset listing
+func registerAssetListResource(s *server.MCPServer, immichClient *immich.Client) {
+	template := mcp.NewResourceTemplate(
+		"immich://assets{?page,size}",
+		"Immich assets",
+		mcp.WithTemplateDescription("Paginated asset listing; read immich://assets/{id} for full metadata"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	handler := func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		page, size := resourcePage(request.Params.URI)
+		assetPage, err := immichClient.GetAssetsFiltered(ctx, page, size, immich.MetadataSearchFilters{})
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]map[string]interface{}, 0, len(assetPage.Assets))
+		for _, asset := range assetPage.Assets {
+			entries = append(entries, map[string]interface{}{
+				"id":       asset.ID,
+				"fileName": asset.OriginalFileName,
+				"type":     asset.Type,
+				"uri":      assetResourceURI(asset.ID),
+			})
+		}
+		return jsonResourceContents(request.Params.URI, map[string]interface{}{
+			"page":        page,
+			"pageSize":    size,
+			"count":       len(entries),
+			"hasNextPage": assetPage.HasNextPage,
+			"assets":      entries,
+		})
+	}
+
+	s.AddResourceTemplate(template, handler)
+}
+
+// immich://people{?page,size} — paginated people listing
+func registerPeopleListResource(s *server.MCPServer, immichClient *immich.Client) {
+	template := mcp.NewResourceTemplate(
+		"immich://people{?page,size}",
+		"Immich people",
+		mcp.WithTemplateDescription("Paginated listing of recognized people; read immich://people/{id} for details"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	handler := func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		page, size := resourcePage(request.Params.URI)
+		peoplePage, err := immichClient.ListPeople(ctx, page, size, false)
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]map[string]interface{}, 0, len(peoplePage.People))
+		for _, person := range peoplePage.People {
+			entries = append(entries, map[string]interface{}{
+				"id":   person.ID,
+				"name": person.Name,
+				"uri":  personResourceURI(person.ID),
+			})
+		}
+		return jsonResourceContents(request.Params.URI, map[string]interface{}{
+			"page":     page,
+			"pageSize": size,
+			"count":    len(entries),
+			"total":    peoplePage.Total,
+			"people":   entries,
+		})
+	}
+
+	s.AddResourceTemplate(template, handler)
+}
+
+// immich://people/{id} — single person
+func registerPersonResource(s *server.MCPServer, immichClient *immich.Client) {
+	template := mcp.NewResourceTemplate(
+		"immich://people/{id}",
+		"Immich person",
+		mcp.WithTemplateDescription("A single recognized person's metadata"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	handler := func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		personID, err := resourcePathID(request.Params.URI, "immich://people/")
+		if err != nil {
+			return nil, err
+		}
+
+		person, err := immichClient.GetPerson(ctx, personID)
+		if err != nil {
+			return nil, err
+		}
+		return jsonResourceContents(request.Params.URI, person)
+	}
+
+	s.AddResourceTemplate(template, handler)
+}
@@ -0,0 +1,255 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/savedsearches"
+)
+
+// RegisterSavedSearchTools registers tools backed by the saved search store
+func RegisterSavedSearchTools(s *server.MCPServer, immichClient *immich.Client, store *savedsearches.Store) {
+	registerSaveSearch(s, store)
+	registerListSavedSearches(s, store)
+	registerRunSavedSearch(s, immichClient, store)
+	registerDeleteSavedSearch(s, store)
+}
+
+// saveSearch tool
+func registerSaveSearch(s *server.MCPServer, store *savedsearches.Store) {
+	tool := mcp.Tool{
+		Name:        "saveSearch",
+		Description: "Persist a named smart-search query for reuse across sessions. Saved searches can be run via runSavedSearch or referenced by name from defineSmartAlbum.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Unique search name",
+				},
+				"description": map[string]interface{}{
+					"type":        "string",
+					"description": "Human-readable description of what the search finds",
+				},
+				"query": map[string]interface{}{
+					"type":        "object",
+					"description": "Smart search parameters (same fields as smartSearchAdvanced)",
+				},
+			},
+			Required: []string{"name", "query"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Name        string                   `json:"name"`
+			Description string                   `json:"description"`
+			Query       immich.SmartSearchParams `json:"query"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if params.Name == "" {
+			return nil, fmt.Errorf("name is required")
+		}
+
+		search, err := store.Save(savedsearches.SavedSearch{
+			Name:        params.Name,
+			Description: params.Description,
+			Query:       params.Query,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to store search: %w", err)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success": true,
+			"search":  search,
+			"message": fmt.Sprintf("Search '%s' saved; run it with runSavedSearch", search.Name),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// listSavedSearches tool
+func registerListSavedSearches(s *server.MCPServer, store *savedsearches.Store) {
+	tool := mcp.Tool{
+		Name:        "listSavedSearches",
+		Description: "List all saved searches with their queries and last-run stats",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		searches := store.List()
+		return makeMCPResult(map[string]interface{}{
+			"success":  true,
+			"count":    len(searches),
+			"searches": searches,
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// runSavedSearch tool
+func registerRunSavedSearch(s *server.MCPServer, immichClient *immich.Client, store *savedsearches.Store) {
+	tool := mcp.Tool{
+		Name:        "runSavedSearch",
+		Description: "Execute a saved search by name or ID and return the matching assets",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Saved search name",
+				},
+				"id": map[string]interface{}{
+					"type":        "string",
+					"description": "Saved search ID (alternative to name)",
+				},
+				"size": map[string]interface{}{
+					"type":        "integer",
+					"description": "Override the saved query's result limit",
+					"default":     0,
+				},
+				"asResourceLinks": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Return matches as MCP resource links instead of inline JSON (automatic for large result sets)",
+					"default":     false,
+				},
+				"aggregateOnly": aggregateOnlySchema(),
+				"verbosity":     verbositySchema(),
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Name            string `json:"name"`
+			ID              string `json:"id"`
+			Size            int    `json:"size"`
+			AsResourceLinks bool   `json:"asResourceLinks"`
+			AggregateOnly   bool   `json:"aggregateOnly"`
+			Verbosity       string `json:"verbosity"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		search, err := lookupSavedSearch(store, params.ID, params.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		query := search.Query
+		if params.Size > 0 {
+			query.Size = params.Size
+		}
+
+		matches, err := immichClient.SmartSearchAdvanced(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("smart search failed: %w", err)
+		}
+
+		if err := store.RecordRun(search.ID, len(matches)); err != nil {
+			return nil, fmt.Errorf("failed to record run: %w", err)
+		}
+
+		if params.AggregateOnly {
+			return makeMCPResult(map[string]interface{}{
+				"success":       true,
+				"search":        search.Name,
+				"matched":       len(matches),
+				"aggregateOnly": true,
+				"aggregate":     aggregateAssets(matches),
+			})
+		}
+
+		matches = trimAssetsForVerbosity(matches, resolveVerbosity(params.Verbosity))
+
+		return makeAssetListResult(map[string]interface{}{
+			"success": true,
+			"search":  search.Name,
+			"matched": len(matches),
+		}, "assets", matches, params.AsResourceLinks)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// deleteSavedSearch tool
+func registerDeleteSavedSearch(s *server.MCPServer, store *savedsearches.Store) {
+	tool := mcp.Tool{
+		Name:        "deleteSavedSearch",
+		Description: "Delete a saved search by name or ID",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Saved search name",
+				},
+				"id": map[string]interface{}{
+					"type":        "string",
+					"description": "Saved search ID (alternative to name)",
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Name string `json:"name"`
+			ID   string `json:"id"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		search, err := lookupSavedSearch(store, params.ID, params.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := store.Delete(search.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete search: %w", err)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success": true,
+			"message": fmt.Sprintf("Saved search '%s' deleted", search.Name),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// lookupSavedSearch resolves a saved search by ID or name
+func lookupSavedSearch(store *savedsearches.Store, id, name string) (*savedsearches.SavedSearch, error) {
+	if id != "" {
+		search, found := store.Get(id)
+		if !found {
+			return nil, fmt.Errorf("saved search '%s' not found", id)
+		}
+		return search, nil
+	}
+	if name != "" {
+		search, found := store.GetByName(name)
+		if !found {
+			return nil, fmt.Errorf("saved search '%s' not found", name)
+		}
+		return search, nil
+	}
+	return nil, fmt.Errorf("either name or id must be provided")
+}
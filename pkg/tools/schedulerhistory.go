@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/smartalbums"
+)
+
+// RegisterSchedulerTools registers the tools that inspect the background
+// smart album scheduler.
+func RegisterSchedulerTools(s *server.MCPServer, scheduler *smartalbums.Scheduler) {
+	registerListLiveAlbumUpdateHistory(s, scheduler)
+}
+
+// listLiveAlbumUpdateHistory tool
+func registerListLiveAlbumUpdateHistory(s *server.MCPServer, scheduler *smartalbums.Scheduler) {
+	tool := mcp.Tool{
+		Name:        "listLiveAlbumUpdateHistory",
+		Description: "List recent background scheduler cycles that refreshed smart albums, with per-album added/removed counts, errors and cycle duration",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of cycles to return, newest first",
+					"default":     10,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Limit int `json:"limit"`
+		}
+
+		// Set defaults
+		params.Limit = 10
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		records := scheduler.Cycles(params.Limit)
+		cycles := make([]map[string]interface{}, 0, len(records))
+		for _, record := range records {
+			albums := make([]map[string]interface{}, 0, len(record.Albums))
+			for _, album := range record.Albums {
+				entry := map[string]interface{}{
+					"definition": album.Definition,
+					"added":      album.Added,
+				}
+				if album.Removed > 0 {
+					entry["removed"] = album.Removed
+				}
+				if album.Error != "" {
+					entry["error"] = album.Error
+				}
+				albums = append(albums, entry)
+			}
+			cycles = append(cycles, map[string]interface{}{
+				"startedAt":  record.StartedAt.Format(time.RFC3339),
+				"durationMs": record.DurationMs,
+				"refreshed":  record.Refreshed,
+				"failed":     record.Failed,
+				"albums":     albums,
+			})
+		}
+
+		refreshed, failures, assetsAdded := scheduler.Counters()
+
+		return makeMCPResult(map[string]interface{}{
+			"success":     true,
+			"count":       len(cycles),
+			"cycles":      cycles,
+			"refreshed":   refreshed,
+			"failures":    failures,
+			"assetsAdded": assetsAdded,
+			"message":     fmt.Sprintf("%d recorded scheduler cycles (%d refreshes, %d failures, %d assets added since start)", len(cycles), refreshed, failures, assetsAdded),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
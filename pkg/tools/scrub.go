@@ -0,0 +1,260 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// registerScrubMetadataForSharing registers the privacy scrubber applied to
+// assets before they go out through shared links or exports. Shared links
+// are flipped to hide EXIF entirely; GPS coordinates can additionally be
+// cleared from the asset records themselves. Embedded EXIF inside the
+// original files (including camera serial numbers) is untouched — scrubbed
+// copies come from the export path, which never embeds metadata unless
+// export_embed_metadata is enabled.
+func registerScrubMetadataForSharing(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "scrubMetadataForSharing",
+		Description: "Prepare assets for sharing by hiding EXIF on the shared links that expose them and optionally clearing GPS coordinates from the asset records",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"assetIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Specific asset IDs to scrub",
+				},
+				"albumName": map[string]interface{}{
+					"type":        "string",
+					"description": "Scrub every asset in this album instead of listing IDs",
+				},
+				"hideMetadataOnLinks": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Turn off showMetadata on active shared links exposing any of the assets",
+					"default":     true,
+				},
+				"clearGps": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Also clear latitude/longitude from the asset records (affects all viewers, not just link visitors)",
+					"default":     false,
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Report what would be scrubbed without changing anything",
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AssetIDs            []string `json:"assetIds"`
+			AlbumName           string   `json:"albumName"`
+			HideMetadataOnLinks bool     `json:"hideMetadataOnLinks"`
+			ClearGps            bool     `json:"clearGps"`
+			DryRun              bool     `json:"dryRun"`
+		}
+
+		// Set defaults
+		params.HideMetadataOnLinks = true
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if len(params.AssetIDs) == 0 && params.AlbumName == "" {
+			return nil, fmt.Errorf("either assetIds or albumName must be provided")
+		}
+
+		// Resolve the target asset set
+		targetIDs := map[string]bool{}
+		var assets []immich.Asset
+		if params.AlbumName != "" {
+			// Disambiguate similar album names via elicitation rather than
+			// scrubbing the wrong album
+			album, candidates, err := findAlbumInteractive(ctx, s, immichClient, params.AlbumName)
+			if err != nil {
+				return nil, err
+			}
+			if album == nil {
+				return albumCandidatesResult(params.AlbumName, candidates)
+			}
+			albumAssets, err := immichClient.GetAlbumAssets(ctx, album.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get album assets: %w", err)
+			}
+			assets = albumAssets
+			for _, asset := range albumAssets {
+				targetIDs[asset.ID] = true
+			}
+		}
+		tombstones := newTombstoneTracker()
+		for _, id := range params.AssetIDs {
+			if targetIDs[id] {
+				continue
+			}
+			asset, err := immichClient.GetAssetMetadata(ctx, id)
+			if err != nil {
+				if immich.IsNotFound(err) {
+					tombstones.Note(id, err)
+					continue
+				}
+				return nil, fmt.Errorf("failed to get asset %s: %w", id, err)
+			}
+			assets = append(assets, *asset)
+			targetIDs[id] = true
+		}
+
+		// Which assets carry GPS coordinates
+		withGps := []string{}
+		for _, asset := range assets {
+			if asset.ExifInfo != nil && (asset.ExifInfo.Latitude != nil || asset.ExifInfo.Longitude != nil) {
+				withGps = append(withGps, asset.ID)
+			}
+		}
+
+		result := map[string]interface{}{
+			"assetsScanned": len(assets),
+			"assetsWithGps": len(withGps),
+		}
+
+		// Find active shared links that expose any target asset with EXIF
+		// still visible
+		type linkEntry struct {
+			ID        string `json:"id"`
+			Type      string `json:"type"`
+			AlbumName string `json:"albumName,omitempty"`
+		}
+		exposingLinks := []linkEntry{}
+		errors := []string{}
+		if params.HideMetadataOnLinks {
+			links, err := immichClient.ListSharedLinks(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list shared links: %w", err)
+			}
+			now := time.Now()
+			for _, link := range links {
+				if !link.ShowExif {
+					continue
+				}
+				if link.ExpiresAt != nil && link.ExpiresAt.Before(now) {
+					continue
+				}
+
+				exposes := false
+				albumName := ""
+				if link.Type == "ALBUM" && link.Album != nil {
+					albumName = link.Album.AlbumName
+					albumAssets, err := immichClient.GetAlbumAssets(ctx, link.Album.ID)
+					if err != nil {
+						if len(errors) < 10 {
+							errors = append(errors, fmt.Sprintf("failed to get assets for shared album '%s': %v", albumName, err))
+						}
+						continue
+					}
+					for _, asset := range albumAssets {
+						if targetIDs[asset.ID] {
+							exposes = true
+							break
+						}
+					}
+				} else {
+					for _, asset := range link.Assets {
+						if targetIDs[asset.ID] {
+							exposes = true
+							break
+						}
+					}
+				}
+				if exposes {
+					exposingLinks = append(exposingLinks, linkEntry{ID: link.ID, Type: link.Type, AlbumName: albumName})
+				}
+			}
+		}
+		result["exposingLinks"] = exposingLinks
+
+		if params.DryRun {
+			result["dryRun"] = true
+			result["success"] = true
+			result["message"] = fmt.Sprintf("Dry run: would hide EXIF on %d shared links and clear GPS from %d assets", len(exposingLinks), gpsCount(params.ClearGps, withGps))
+			tombstones.Report(result)
+			return makeMCPResult(result)
+		}
+
+		// Flip the exposing links to hide EXIF
+		linksUpdated := 0
+		for _, link := range exposingLinks {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			if err := immichClient.UpdateSharedLink(ctx, link.ID, map[string]interface{}{"showMetadata": false}); err != nil {
+				if len(errors) < 10 {
+					errors = append(errors, fmt.Sprintf("failed to update shared link %s: %v", link.ID, err))
+				}
+				continue
+			}
+			linksUpdated++
+		}
+		result["linksUpdated"] = linksUpdated
+
+		// Clear GPS from the asset records when asked
+		gpsCleared := 0
+		if params.ClearGps {
+			for _, assetID := range withGps {
+				// Check for context cancellation
+				select {
+				case <-ctx.Done():
+					return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+				default:
+				}
+
+				err := immichClient.UpdateAssetMetadata(ctx, assetID, map[string]interface{}{
+					"latitude":  nil,
+					"longitude": nil,
+				})
+				if err != nil {
+					if immich.IsNotFound(err) {
+						tombstones.Note(assetID, err)
+						continue
+					}
+					if len(errors) < 10 {
+						errors = append(errors, fmt.Sprintf("failed to clear GPS on %s: %v", assetID, err))
+					}
+					continue
+				}
+				gpsCleared++
+			}
+		}
+		result["gpsCleared"] = gpsCleared
+
+		if len(errors) > 0 {
+			result["errors"] = errors
+		}
+		result["success"] = len(errors) == 0
+		result["message"] = fmt.Sprintf("Hid EXIF on %d shared links, cleared GPS from %d assets; originals keep their embedded EXIF (exports omit it unless export_embed_metadata is on)", linksUpdated, gpsCleared)
+		tombstones.Report(result)
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// gpsCount sizes the GPS part of the dry-run message: zero when GPS
+// clearing isn't requested
+func gpsCount(clearGps bool, withGps []string) int {
+	if !clearGps {
+		return 0
+	}
+	return len(withGps)
+}
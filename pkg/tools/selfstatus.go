@@ -0,0 +1,31 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterSelfStatusTool registers the getSelfStatus tool. The status
+// callback is provided by the server, which owns the component handles the
+// report is built from.
+func RegisterSelfStatusTool(s *server.MCPServer, status func() map[string]interface{}) {
+	tool := mcp.Tool{
+		Name:        "getSelfStatus",
+		Description: "Report this MCP server's own health: scheduler status, smart album store accessibility, cache stats, and observed Immich API latency",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result := status()
+		result["success"] = true
+		result["message"] = "Self status report"
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
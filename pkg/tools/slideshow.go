@@ -0,0 +1,198 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// slideshowEntry is one slide in an exported manifest
+type slideshowEntry struct {
+	AssetID     string  `json:"assetId"`
+	URL         string  `json:"url"`
+	Type        string  `json:"type"`
+	FileName    string  `json:"fileName"`
+	Caption     string  `json:"caption,omitempty"`
+	DurationSec float64 `json:"durationSeconds"`
+	Highlighted bool    `json:"highlighted,omitempty"`
+}
+
+// registerExportSlideshowManifest registers the slideshow manifest export tool
+func registerExportSlideshowManifest(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "exportSlideshowManifest",
+		Description: "Produce an ordered slideshow manifest for an album (asset URLs, durations, captions from descriptions), consumable by external slideshow/video tools",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"albumName": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the album to export",
+				},
+				"albumId": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the album (alternative to albumName)",
+				},
+				"slideDuration": map[string]interface{}{
+					"type":        "number",
+					"description": "Seconds per image slide",
+					"default":     5,
+				},
+				"shuffle": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Shuffle slide order instead of capture-date order",
+					"default":     false,
+				},
+				"shuffleSeed": map[string]interface{}{
+					"type":        "integer",
+					"description": "Seed for reproducible shuffling (0 uses a random seed)",
+					"default":     0,
+				},
+				"highlightPersonIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Person IDs whose photos get the highlight duration multiplier",
+				},
+				"highlightMultiplier": map[string]interface{}{
+					"type":        "number",
+					"description": "Duration multiplier for highlighted slides",
+					"default":     2,
+				},
+				"includeVideos": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include videos (using their own duration) in the manifest",
+					"default":     true,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AlbumName           string   `json:"albumName"`
+			AlbumID             string   `json:"albumId"`
+			SlideDuration       float64  `json:"slideDuration"`
+			Shuffle             bool     `json:"shuffle"`
+			ShuffleSeed         int64    `json:"shuffleSeed"`
+			HighlightPersonIds  []string `json:"highlightPersonIds"`
+			HighlightMultiplier float64  `json:"highlightMultiplier"`
+			IncludeVideos       *bool    `json:"includeVideos"`
+		}
+
+		// Set defaults
+		params.SlideDuration = 5
+		params.HighlightMultiplier = 2
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		includeVideos := params.IncludeVideos == nil || *params.IncludeVideos
+
+		// Resolve album
+		albumID := params.AlbumID
+		albumName := params.AlbumName
+		if albumID == "" {
+			if albumName == "" {
+				return nil, fmt.Errorf("either albumName or albumId must be provided")
+			}
+			albums, err := immichClient.ListAlbums(ctx, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list albums: %w", err)
+			}
+			for _, album := range albums {
+				if album.AlbumName == albumName {
+					albumID = album.ID
+					break
+				}
+			}
+			if albumID == "" {
+				return nil, fmt.Errorf("album '%s' not found", albumName)
+			}
+		}
+
+		assets, err := immichClient.GetAlbumAssets(ctx, albumID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get album assets: %w", err)
+		}
+
+		// Build the highlighted-asset set from person searches
+		highlighted := map[string]bool{}
+		for _, personID := range params.HighlightPersonIds {
+			personResults, err := immichClient.SearchByFace(ctx, immich.FaceSearchParams{PersonID: personID})
+			if err != nil {
+				continue
+			}
+			for _, asset := range personResults.Photos {
+				highlighted[asset.ID] = true
+			}
+		}
+
+		// Default ordering: capture date
+		sort.Slice(assets, func(i, j int) bool {
+			return assets[i].FileCreatedAt.Before(assets[j].FileCreatedAt)
+		})
+
+		if params.Shuffle {
+			seed := params.ShuffleSeed
+			if seed == 0 {
+				seed = rand.Int63()
+			}
+			rng := rand.New(rand.NewSource(seed))
+			rng.Shuffle(len(assets), func(i, j int) {
+				assets[i], assets[j] = assets[j], assets[i]
+			})
+		}
+
+		manifest := []slideshowEntry{}
+		totalDuration := 0.0
+
+		for _, asset := range assets {
+			if asset.Type == "VIDEO" && !includeVideos {
+				continue
+			}
+
+			entry := slideshowEntry{
+				AssetID:  asset.ID,
+				URL:      immichClient.AssetDownloadURL(asset.ID),
+				Type:     asset.Type,
+				FileName: asset.OriginalFileName,
+			}
+
+			if asset.ExifInfo != nil && asset.ExifInfo.Description != "" {
+				entry.Caption = asset.ExifInfo.Description
+			}
+
+			if asset.Type == "VIDEO" && asset.Duration != nil {
+				entry.DurationSec = float64(parseDuration(*asset.Duration))
+			} else {
+				entry.DurationSec = params.SlideDuration
+			}
+
+			if highlighted[asset.ID] {
+				entry.Highlighted = true
+				entry.DurationSec *= params.HighlightMultiplier
+			}
+
+			totalDuration += entry.DurationSec
+			manifest = append(manifest, entry)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":              true,
+			"albumId":              albumID,
+			"albumName":            albumName,
+			"slideCount":           len(manifest),
+			"totalDurationSeconds": totalDuration,
+			"shuffled":             params.Shuffle,
+			"slides":               manifest,
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
@@ -0,0 +1,257 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/smartalbums"
+)
+
+// diffSampleLimit caps how many asset IDs from each diff bucket are returned
+// inline; the complete lists go to the report file when one is requested.
+const diffSampleLimit = 20
+
+// smartAlbumDiff is the structured dry-run report for one definition: what a
+// refresh would add, what is already in place, what the asset cap would cut
+// off, and what is in the album but no longer matches. Whether the removals
+// happen depends on the definition's sync strategy — add-only refreshes leave
+// them in place; full-sync and archive-removed refreshes remove them (pinned
+// assets always excepted).
+type smartAlbumDiff struct {
+	Definition     string         `json:"definition"`
+	AlbumName      string         `json:"albumName"`
+	AlbumID        string         `json:"albumId,omitempty"`
+	GeneratedAt    time.Time      `json:"generatedAt"`
+	Matched        int            `json:"matched"`
+	Additions      []string       `json:"additions"`
+	AlreadyPresent []string       `json:"alreadyPresent"`
+	Removals       []string       `json:"removals"`
+	SkippedByCap   int            `json:"skippedByCap"`
+	FilterCounts   map[string]int `json:"filterCounts,omitempty"`
+}
+
+// buildSmartAlbumDiff runs a definition's query and compares the matches
+// against the current album membership. The returned sample holds the first
+// few matched assets for display.
+func buildSmartAlbumDiff(ctx context.Context, immichClient *immich.Client, def *smartalbums.SmartAlbumDefinition) (*smartAlbumDiff, []immich.Asset, error) {
+	query := def.Query
+	if def.MaxAssets > 0 {
+		query.Size = def.MaxAssets
+	}
+
+	// Stream the matches with the same cap a live refresh would use,
+	// retaining only IDs and a small sample
+	var matchedIDs []string
+	var sampleAssets []immich.Asset
+	err := immichClient.SmartSearchStream(ctx, query, func(asset immich.Asset) error {
+		matchedIDs = append(matchedIDs, asset.ID)
+		if len(sampleAssets) < 10 {
+			sampleAssets = append(sampleAssets, asset)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("smart search failed: %w", err)
+	}
+
+	diff := &smartAlbumDiff{
+		Definition:     def.Name,
+		AlbumName:      def.AlbumName,
+		GeneratedAt:    time.Now(),
+		Matched:        len(matchedIDs),
+		Additions:      []string{},
+		AlreadyPresent: []string{},
+		Removals:       []string{},
+	}
+
+	// When the cap bit, ask the server for the uncapped total so the report
+	// can say how many matches fell outside it
+	if def.MaxAssets > 0 && len(matchedIDs) >= def.MaxAssets {
+		if total, err := immichClient.SmartSearchTotal(ctx, def.Query); err == nil && total > len(matchedIDs) {
+			diff.SkippedByCap = total - len(matchedIDs)
+		}
+	}
+
+	// Resolve the target album; a definition that has never run live has no
+	// album yet, in which case every match is an addition
+	albumID := def.AlbumID
+	if albumID == "" {
+		albums, err := immichClient.ListAlbums(ctx, false)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list albums: %w", err)
+		}
+		for _, album := range albums {
+			if album.AlbumName == def.AlbumName {
+				albumID = album.ID
+				break
+			}
+		}
+	}
+
+	inAlbum := map[string]bool{}
+	if albumID != "" {
+		diff.AlbumID = albumID
+		albumAssets, err := immichClient.GetAlbumAssets(ctx, albumID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get album assets: %w", err)
+		}
+		for _, asset := range albumAssets {
+			inAlbum[asset.ID] = true
+		}
+	}
+
+	matched := make(map[string]bool, len(matchedIDs))
+	for _, id := range matchedIDs {
+		matched[id] = true
+		if inAlbum[id] {
+			diff.AlreadyPresent = append(diff.AlreadyPresent, id)
+		} else {
+			diff.Additions = append(diff.Additions, id)
+		}
+	}
+	for id := range inAlbum {
+		if !matched[id] {
+			diff.Removals = append(diff.Removals, id)
+		}
+	}
+	sort.Strings(diff.Removals)
+
+	diff.FilterCounts = smartAlbumFilterCounts(ctx, immichClient, def.Query)
+
+	return diff, sampleAssets, nil
+}
+
+// smartAlbumFilterCounts runs each active filter of the query on its own and
+// reports how many assets it matches, showing which filter narrows the result
+// the most. Queries with a single filter are skipped — the breakdown would
+// just repeat the total — and filters whose probe fails are omitted.
+func smartAlbumFilterCounts(ctx context.Context, immichClient *immich.Client, query immich.SmartSearchParams) map[string]int {
+	data, err := json.Marshal(query)
+	if err != nil {
+		return nil
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+	delete(raw, "page")
+	delete(raw, "size")
+	if len(raw) < 2 {
+		return nil
+	}
+
+	counts := map[string]int{}
+	for key, value := range raw {
+		var single immich.SmartSearchParams
+		if err := json.Unmarshal([]byte(fmt.Sprintf("{%q: %s}", key, value)), &single); err != nil {
+			continue
+		}
+		total, err := immichClient.SmartSearchTotal(ctx, single)
+		if err != nil {
+			continue
+		}
+		counts[key] = total
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+	return counts
+}
+
+// sampleIDs returns up to diffSampleLimit entries from a diff bucket
+func sampleIDs(ids []string) []string {
+	if len(ids) <= diffSampleLimit {
+		return ids
+	}
+	return ids[:diffSampleLimit]
+}
+
+// dryRunSmartAlbumDiffResult builds the refreshSmartAlbum dry-run result
+// around a structured diff, optionally writing the complete diff to a
+// server-side report file for albums too large to return inline.
+func dryRunSmartAlbumDiffResult(ctx context.Context, immichClient *immich.Client, store *smartalbums.Store, def *smartalbums.SmartAlbumDefinition, reportPath string) (*mcp.CallToolResult, error) {
+	diff, sampleAssets, err := buildSmartAlbumDiff(ctx, immichClient, def)
+	if err != nil {
+		return nil, err
+	}
+
+	// A live sync never removes pinned assets, so exclude them from the
+	// removals the preview reports
+	pinnedKept := 0
+	if len(diff.Removals) > 0 && diff.AlbumID != "" {
+		pinned := map[string]bool{}
+		for _, id := range store.PinnedAssets(diff.AlbumID) {
+			pinned[id] = true
+		}
+		kept := diff.Removals[:0]
+		for _, id := range diff.Removals {
+			if pinned[id] {
+				pinnedKept++
+				continue
+			}
+			kept = append(kept, id)
+		}
+		diff.Removals = kept
+	}
+
+	result := map[string]interface{}{
+		"definition":      def.Name,
+		"albumName":       def.AlbumName,
+		"dryRun":          true,
+		"matched":         diff.Matched,
+		"additions":       len(diff.Additions),
+		"alreadyPresent":  len(diff.AlreadyPresent),
+		"removals":        len(diff.Removals),
+		"skippedByCap":    diff.SkippedByCap,
+		"sampleAdditions": sampleIDs(diff.Additions),
+		"sampleRemovals":  sampleIDs(diff.Removals),
+		"sampleMatches":   formatAssetSamples(sampleAssets, 10),
+	}
+	if diff.AlbumID != "" {
+		result["albumId"] = diff.AlbumID
+	}
+	if pinnedKept > 0 {
+		result["pinnedKept"] = pinnedKept
+	}
+	if diff.FilterCounts != nil {
+		result["filterCounts"] = diff.FilterCounts
+	}
+
+	if reportPath != "" {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode diff report: %w", err)
+		}
+		if err := os.WriteFile(reportPath, data, 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write diff report: %w", err)
+		}
+		result["reportPath"] = reportPath
+		result["reportSizeBytes"] = len(data)
+	}
+
+	message := fmt.Sprintf("Dry run: %d assets match smart album '%s' — %d to add, %d already present",
+		diff.Matched, def.Name, len(diff.Additions), len(diff.AlreadyPresent))
+	if diff.SkippedByCap > 0 {
+		message += fmt.Sprintf("; %d further matches fall outside the %d-asset cap", diff.SkippedByCap, def.MaxAssets)
+	}
+	if len(diff.Removals) > 0 {
+		switch def.SyncStrategy {
+		case smartalbums.SyncFull:
+			message += fmt.Sprintf("; %d album assets no longer match and would be removed", len(diff.Removals))
+		case smartalbums.SyncArchiveRemoved:
+			message += fmt.Sprintf("; %d album assets no longer match and would be removed and archived", len(diff.Removals))
+		default:
+			message += fmt.Sprintf("; %d album assets no longer match (add-only: refresh leaves them in place)", len(diff.Removals))
+		}
+	}
+	result["success"] = true
+	result["message"] = message
+
+	return makeMCPResult(result)
+}
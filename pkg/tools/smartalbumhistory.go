@@ -0,0 +1,245 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/smartalbums"
+)
+
+// queryFields flattens a query into its non-empty JSON fields for diffing
+func queryFields(query immich.SmartSearchParams) map[string]string {
+	data, err := json.Marshal(query)
+	if err != nil {
+		return nil
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+	fields := make(map[string]string, len(raw))
+	for key, value := range raw {
+		fields[key] = string(value)
+	}
+	return fields
+}
+
+// diffQueries describes the field-level changes from one query to another
+func diffQueries(from, to immich.SmartSearchParams) []string {
+	fromFields := queryFields(from)
+	toFields := queryFields(to)
+
+	keys := make([]string, 0, len(fromFields)+len(toFields))
+	seen := map[string]bool{}
+	for key := range fromFields {
+		keys = append(keys, key)
+		seen[key] = true
+	}
+	for key := range toFields {
+		if !seen[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []string
+	for _, key := range keys {
+		oldValue, hadOld := fromFields[key]
+		newValue, hasNew := toFields[key]
+		switch {
+		case hadOld && !hasNew:
+			changes = append(changes, fmt.Sprintf("%s removed (was %s)", key, oldValue))
+		case !hadOld && hasNew:
+			changes = append(changes, fmt.Sprintf("%s added: %s", key, newValue))
+		case oldValue != newValue:
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", key, oldValue, newValue))
+		}
+	}
+	return changes
+}
+
+// getSmartAlbumHistory tool
+func registerGetSmartAlbumHistory(s *server.MCPServer, immichClient *immich.Client, store *smartalbums.Store) {
+	tool := mcp.Tool{
+		Name:        "getSmartAlbumHistory",
+		Description: "Show a smart album definition's history: query versions with field-level diffs, and the most recent refresh runs with match/add deltas",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Definition name",
+				},
+				"id": map[string]interface{}{
+					"type":        "string",
+					"description": "Definition ID (alternative to name)",
+				},
+				"runLimit": map[string]interface{}{
+					"type":        "integer",
+					"description": "How many recent refresh runs to include, newest first",
+					"default":     20,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Name     string `json:"name"`
+			ID       string `json:"id"`
+			RunLimit int    `json:"runLimit"`
+		}
+
+		// Set defaults
+		params.RunLimit = 20
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		def, err := lookupSmartAlbum(store, params.ID, params.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		type historyEntry struct {
+			Version int                      `json:"version"`
+			SavedAt string                   `json:"savedAt"`
+			Query   immich.SmartSearchParams `json:"query"`
+			// Changes describe how this version was edited into the next
+			// one (the current query, for the newest version)
+			Changes []string `json:"changes"`
+		}
+
+		entries := make([]historyEntry, 0, len(def.QueryHistory))
+		for i, version := range def.QueryHistory {
+			next := def.Query
+			if i+1 < len(def.QueryHistory) {
+				next = def.QueryHistory[i+1].Query
+			}
+			entries = append(entries, historyEntry{
+				Version: version.Version,
+				SavedAt: version.SavedAt.Format("2006-01-02 15:04:05"),
+				Query:   version.Query,
+				Changes: diffQueries(version.Query, next),
+			})
+		}
+
+		runs, err := smartAlbumRunHistory(store, def.ID, params.RunLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":      true,
+			"definition":   def.Name,
+			"currentQuery": def.Query,
+			"versions":     entries,
+			"runs":         runs,
+			"message":      fmt.Sprintf("Smart album '%s' has %d previous query versions and %d recorded runs", def.Name, len(entries), len(runs)),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// smartAlbumRunHistory formats the last refresh runs of a definition, newest
+// first, with each run's matched count delta against the run before it
+func smartAlbumRunHistory(store *smartalbums.Store, definitionID string, limit int) ([]map[string]interface{}, error) {
+	// Fetch one extra run so the oldest reported run still gets a delta
+	records, err := store.Runs(definitionID, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run history: %w", err)
+	}
+
+	runs := []map[string]interface{}{}
+	for i, record := range records {
+		if i >= limit {
+			break
+		}
+		entry := map[string]interface{}{
+			"startedAt":  record.StartedAt.Format("2006-01-02 15:04:05"),
+			"durationMs": record.FinishedAt.Sub(record.StartedAt).Milliseconds(),
+			"matched":    record.Matched,
+			"added":      record.Added,
+		}
+		if record.Error != "" {
+			entry["error"] = record.Error
+		}
+		// Records are newest first, so the previous run is the next record
+		if i+1 < len(records) {
+			entry["matchedDelta"] = record.Matched - records[i+1].Matched
+		}
+		runs = append(runs, entry)
+	}
+	return runs, nil
+}
+
+// rollbackSmartAlbum tool
+func registerRollbackSmartAlbum(s *server.MCPServer, immichClient *immich.Client, store *smartalbums.Store) {
+	tool := mcp.Tool{
+		Name:        "rollbackSmartAlbum",
+		Description: "Restore a previous query version of a smart album definition, undoing an edit that gutted or broke the album's matches",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Definition name",
+				},
+				"id": map[string]interface{}{
+					"type":        "string",
+					"description": "Definition ID (alternative to name)",
+				},
+				"version": map[string]interface{}{
+					"type":        "integer",
+					"description": "Query version to restore (from getSmartAlbumHistory)",
+				},
+			},
+			Required: []string{"version"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Name    string `json:"name"`
+			ID      string `json:"id"`
+			Version int    `json:"version"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if params.Version <= 0 {
+			return nil, fmt.Errorf("version is required")
+		}
+
+		def, err := lookupSmartAlbum(store, params.ID, params.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		previousQuery := def.Query
+		restored, err := store.RollbackQuery(def.ID, params.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":    true,
+			"definition": restored.Name,
+			"version":    params.Version,
+			"query":      restored.Query,
+			"changes":    diffQueries(previousQuery, restored.Query),
+			"message":    fmt.Sprintf("Smart album '%s' rolled back to query version %d; run refreshSmartAlbum to resync album '%s'", restored.Name, params.Version, restored.AlbumName),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
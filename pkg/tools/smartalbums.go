@@ -0,0 +1,823 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/savedsearches"
+	"github.com/yourusername/mcp-immich/pkg/smartalbums"
+	"github.com/yourusername/mcp-immich/pkg/undo"
+)
+
+// RegisterSmartAlbumTools registers tools backed by the smart album store
+func RegisterSmartAlbumTools(s *server.MCPServer, immichClient *immich.Client, store *smartalbums.Store, searches *savedsearches.Store) {
+	registerDefineSmartAlbum(s, immichClient, store, searches)
+	registerListSmartAlbums(s, store)
+	registerDeleteSmartAlbum(s, immichClient, store)
+	registerRefreshSmartAlbum(s, immichClient, store)
+	registerRenameAlbum(s, immichClient, store)
+	registerReconcileSmartAlbums(s, immichClient, store)
+	registerPinAssetsInAlbum(s, immichClient, store)
+	registerTagAlbumContents(s, immichClient, store)
+	registerDryRunAllSmartAlbums(s, immichClient, store)
+	registerPreviewLiveAlbumChange(s, immichClient, store)
+	registerGetSmartAlbumHistory(s, immichClient, store)
+	registerRollbackSmartAlbum(s, immichClient, store)
+	registerFindEmptyAlbums(s, immichClient, store)
+	registerExportSmartAlbums(s, store)
+	registerImportSmartAlbums(s, store)
+	registerMigrateLiveAlbums(s, immichClient, store)
+	registerSetLiveAlbumSchedule(s, store)
+}
+
+// reconcileSmartAlbums tool
+func registerReconcileSmartAlbums(s *server.MCPServer, immichClient *immich.Client, store *smartalbums.Store) {
+	tool := mcp.Tool{
+		Name:        "reconcileSmartAlbums",
+		Description: "Verify each smart album definition's target album still exists, repair albumId/albumName after manual renames, and flag or recreate orphaned albums",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"recreateMissing": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Recreate target albums that were deleted in Immich (otherwise they are only flagged as orphaned)",
+					"default":     false,
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Report inconsistencies without repairing the store",
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			RecreateMissing bool `json:"recreateMissing"`
+			DryRun          bool `json:"dryRun"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		albums, err := immichClient.ListAlbums(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list albums: %w", err)
+		}
+
+		albumsByID := map[string]immich.Album{}
+		albumsByName := map[string]immich.Album{}
+		for _, album := range albums {
+			albumsByID[album.ID] = album
+			albumsByName[album.AlbumName] = album
+		}
+
+		type reconcileEntry struct {
+			Definition string `json:"definition"`
+			Status     string `json:"status"` // ok, renamed, relinked, recreated, orphaned
+			Detail     string `json:"detail,omitempty"`
+		}
+
+		entries := []reconcileEntry{}
+		repaired := 0
+		orphaned := 0
+
+		for _, def := range store.List() {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			entry := reconcileEntry{Definition: def.Name}
+
+			if def.AlbumID != "" {
+				if album, found := albumsByID[def.AlbumID]; found {
+					if album.AlbumName == def.AlbumName {
+						entry.Status = "ok"
+						entries = append(entries, entry)
+						continue
+					}
+					// Album renamed in the Immich UI: adopt the new name
+					entry.Status = "renamed"
+					entry.Detail = fmt.Sprintf("album renamed '%s' -> '%s'", def.AlbumName, album.AlbumName)
+					if !params.DryRun {
+						def.AlbumName = album.AlbumName
+						if err := store.Update(def); err != nil {
+							entry.Detail += fmt.Sprintf(" (store update failed: %v)", err)
+						} else {
+							repaired++
+						}
+					}
+					entries = append(entries, entry)
+					continue
+				}
+			}
+
+			// No (valid) album ID: try to relink by name
+			if album, found := albumsByName[def.AlbumName]; found {
+				entry.Status = "relinked"
+				entry.Detail = fmt.Sprintf("albumId repaired to %s", album.ID)
+				if !params.DryRun {
+					def.AlbumID = album.ID
+					if err := store.Update(def); err != nil {
+						entry.Detail += fmt.Sprintf(" (store update failed: %v)", err)
+					} else {
+						repaired++
+					}
+				}
+				entries = append(entries, entry)
+				continue
+			}
+
+			// Target album is gone
+			if params.RecreateMissing && !params.DryRun {
+				newAlbum, err := immichClient.CreateAlbum(ctx, immich.CreateAlbumParams{
+					Name:        def.AlbumName,
+					Description: def.Description,
+				})
+				if err != nil {
+					entry.Status = "orphaned"
+					entry.Detail = fmt.Sprintf("recreation failed: %v", err)
+					orphaned++
+				} else {
+					entry.Status = "recreated"
+					entry.Detail = fmt.Sprintf("album recreated as %s", newAlbum.ID)
+					def.AlbumID = newAlbum.ID
+					if err := store.Update(def); err != nil {
+						entry.Detail += fmt.Sprintf(" (store update failed: %v)", err)
+					} else {
+						repaired++
+					}
+				}
+			} else {
+				entry.Status = "orphaned"
+				entry.Detail = fmt.Sprintf("target album '%s' no longer exists", def.AlbumName)
+				orphaned++
+			}
+			entries = append(entries, entry)
+		}
+
+		result := map[string]interface{}{
+			"success":     true,
+			"definitions": len(entries),
+			"repaired":    repaired,
+			"orphaned":    orphaned,
+			"entries":     entries,
+		}
+		if params.DryRun {
+			result["dryRun"] = true
+		}
+		result["message"] = fmt.Sprintf("Reconciled %d definitions: %d repaired, %d orphaned", len(entries), repaired, orphaned)
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// defineSmartAlbum tool
+func registerDefineSmartAlbum(s *server.MCPServer, immichClient *immich.Client, store *smartalbums.Store, searches *savedsearches.Store) {
+	tool := mcp.Tool{
+		Name:        "defineSmartAlbum",
+		Description: "Create or update a smart album definition: a persisted smart-search query that keeps an Immich album populated via refreshSmartAlbum",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Unique definition name",
+				},
+				"albumName": map[string]interface{}{
+					"type":        "string",
+					"description": "Target Immich album name (defaults to the definition name)",
+				},
+				"description": map[string]interface{}{
+					"type":        "string",
+					"description": "Human-readable description of the rule",
+				},
+				"query": map[string]interface{}{
+					"type":        "object",
+					"description": "Smart search parameters (same fields as smartSearchAdvanced)",
+				},
+				"savedSearch": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of a saved search to use as the query (alternative to query)",
+				},
+				"maxAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum assets per refresh (0 for unlimited)",
+					"default":     0,
+				},
+				"refreshIntervalMinutes": map[string]interface{}{
+					"type":        "integer",
+					"description": "How often the background scheduler refreshes this definition, overriding the global smart_album_refresh_interval (0 to use the global default)",
+					"default":     0,
+				},
+				"refreshCron": map[string]interface{}{
+					"type":        "string",
+					"description": "Five-field cron expression scheduling this definition's refreshes (e.g. '0 3 * * *'), taking precedence over refreshIntervalMinutes",
+				},
+				"syncStrategy": map[string]interface{}{
+					"type":        "string",
+					"description": "What a refresh does with album assets that no longer match: add-only never removes, full-sync removes them, archive-removed removes and archives them; pinned assets always stay",
+					"enum":        []string{smartalbums.SyncAddOnly, smartalbums.SyncFull, smartalbums.SyncArchiveRemoved},
+					"default":     smartalbums.SyncAddOnly,
+				},
+			},
+			Required: []string{"name"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Name                   string                   `json:"name"`
+			AlbumName              string                   `json:"albumName"`
+			Description            string                   `json:"description"`
+			Query                  immich.SmartSearchParams `json:"query"`
+			SavedSearch            string                   `json:"savedSearch"`
+			MaxAssets              int                      `json:"maxAssets"`
+			RefreshIntervalMinutes int                      `json:"refreshIntervalMinutes"`
+			RefreshCron            string                   `json:"refreshCron"`
+			SyncStrategy           string                   `json:"syncStrategy"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if params.Name == "" {
+			return nil, fmt.Errorf("name is required")
+		}
+		switch params.SyncStrategy {
+		case "", smartalbums.SyncAddOnly, smartalbums.SyncFull, smartalbums.SyncArchiveRemoved:
+		default:
+			return nil, fmt.Errorf("invalid syncStrategy: %s (must be %s, %s or %s)", params.SyncStrategy, smartalbums.SyncAddOnly, smartalbums.SyncFull, smartalbums.SyncArchiveRemoved)
+		}
+		if params.RefreshCron != "" {
+			if _, err := smartalbums.ParseCron(params.RefreshCron); err != nil {
+				return nil, fmt.Errorf("invalid refreshCron: %w", err)
+			}
+		}
+		if params.AlbumName == "" {
+			params.AlbumName = params.Name
+		}
+
+		// A saved search can stand in for an inline query
+		if params.SavedSearch != "" {
+			saved, found := searches.GetByName(params.SavedSearch)
+			if !found {
+				return nil, fmt.Errorf("saved search '%s' not found", params.SavedSearch)
+			}
+			params.Query = saved.Query
+		}
+
+		def, err := store.Define(smartalbums.SmartAlbumDefinition{
+			Name:                   params.Name,
+			AlbumName:              params.AlbumName,
+			Description:            params.Description,
+			Query:                  params.Query,
+			MaxAssets:              params.MaxAssets,
+			RefreshIntervalMinutes: params.RefreshIntervalMinutes,
+			RefreshCron:            params.RefreshCron,
+			SyncStrategy:           params.SyncStrategy,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to store definition: %w", err)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":    true,
+			"definition": def,
+			"message":    fmt.Sprintf("Smart album '%s' defined; run refreshSmartAlbum to populate album '%s'", def.Name, def.AlbumName),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// refreshSmartAlbum tool
+func registerRefreshSmartAlbum(s *server.MCPServer, immichClient *immich.Client, store *smartalbums.Store) {
+	tool := mcp.Tool{
+		Name:        "refreshSmartAlbum",
+		Description: "Run a smart album definition's query and sync its target album: matches are added, and with a full-sync or archive-removed strategy, assets that no longer match are removed",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Definition name to refresh",
+				},
+				"id": map[string]interface{}{
+					"type":        "string",
+					"description": "Definition ID (alternative to name)",
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Preview the full diff (additions, already present, removals, cap overflow) without modifying the album",
+					"default":     false,
+				},
+				"reportPath": map[string]interface{}{
+					"type":        "string",
+					"description": "With dryRun, also write the complete diff (every asset ID per bucket) as JSON to this server-side file",
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Name       string `json:"name"`
+			ID         string `json:"id"`
+			DryRun     bool   `json:"dryRun"`
+			ReportPath string `json:"reportPath"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		def, err := lookupSmartAlbum(store, params.ID, params.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if params.DryRun {
+			return dryRunSmartAlbumDiffResult(ctx, immichClient, store, def, params.ReportPath)
+		}
+		return refreshSmartAlbumDefinition(ctx, immichClient, store, def)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// lookupSmartAlbum resolves a definition by ID or name
+func lookupSmartAlbum(store *smartalbums.Store, id, name string) (*smartalbums.SmartAlbumDefinition, error) {
+	if id != "" {
+		def, found := store.Get(id)
+		if !found {
+			return nil, fmt.Errorf("smart album definition '%s' not found", id)
+		}
+		return def, nil
+	}
+	if name != "" {
+		def, found := store.GetByName(name)
+		if !found {
+			return nil, fmt.Errorf("smart album definition '%s' not found", name)
+		}
+		return def, nil
+	}
+	return nil, fmt.Errorf("either name or id must be provided")
+}
+
+// listSmartAlbums tool
+func registerListSmartAlbums(s *server.MCPServer, store *smartalbums.Store) {
+	tool := mcp.Tool{
+		Name:        "listSmartAlbums",
+		Description: "List all smart album definitions with their query summary and last-run stats",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		defs := store.List()
+		sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+
+		entries := make([]map[string]interface{}, 0, len(defs))
+		for _, def := range defs {
+			entry := map[string]interface{}{
+				"id":        def.ID,
+				"name":      def.Name,
+				"albumName": def.AlbumName,
+				"query":     queryFields(def.Query),
+			}
+			if def.AlbumID != "" {
+				entry["albumId"] = def.AlbumID
+			}
+			if def.MaxAssets > 0 {
+				entry["maxAssets"] = def.MaxAssets
+			}
+			if def.RefreshIntervalMinutes > 0 {
+				entry["refreshIntervalMinutes"] = def.RefreshIntervalMinutes
+			}
+			if def.RefreshCron != "" {
+				entry["refreshCron"] = def.RefreshCron
+			}
+			if def.SyncStrategy != "" {
+				entry["syncStrategy"] = def.SyncStrategy
+			}
+			if !def.LastRunAt.IsZero() {
+				lastRun := map[string]interface{}{
+					"at":      def.LastRunAt.Format(time.RFC3339),
+					"matched": def.LastRunMatched,
+					"added":   def.LastRunAdded,
+				}
+				if def.LastRunRemoved > 0 {
+					lastRun["removed"] = def.LastRunRemoved
+				}
+				if def.LastRunError != "" {
+					lastRun["error"] = def.LastRunError
+				}
+				entry["lastRun"] = lastRun
+			}
+			entries = append(entries, entry)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":     true,
+			"smartAlbums": entries,
+			"count":       len(entries),
+			"message":     fmt.Sprintf("%d smart album definitions", len(entries)),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// deleteSmartAlbum tool
+func registerDeleteSmartAlbum(s *server.MCPServer, immichClient *immich.Client, store *smartalbums.Store) {
+	tool := mcp.Tool{
+		Name:        "deleteSmartAlbum",
+		Description: "Delete a smart album definition by name or ID, optionally deleting the backing Immich album as well",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Definition name to delete",
+				},
+				"id": map[string]interface{}{
+					"type":        "string",
+					"description": "Definition ID (alternative to name)",
+				},
+				"deleteAlbum": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Also delete the backing Immich album and its memberships (assets themselves are untouched)",
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Name        string `json:"name"`
+			ID          string `json:"id"`
+			DeleteAlbum bool   `json:"deleteAlbum"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		def, err := lookupSmartAlbum(store, params.ID, params.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		result := map[string]interface{}{
+			"definition": def.Name,
+			"albumName":  def.AlbumName,
+		}
+
+		// Remove the backing album first so a failure leaves the
+		// definition in place for a retry
+		if params.DeleteAlbum && def.AlbumID != "" {
+			if err := checkAlbumLock(def.AlbumID, def.AlbumName); err != nil {
+				return nil, err
+			}
+			if err := immichClient.DeleteAlbum(ctx, def.AlbumID); err != nil {
+				return nil, fmt.Errorf("failed to delete album '%s': %w", def.AlbumName, err)
+			}
+			result["albumDeleted"] = true
+		}
+
+		if err := store.Delete(def.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete definition: %w", err)
+		}
+
+		message := fmt.Sprintf("Smart album definition '%s' deleted", def.Name)
+		if params.DeleteAlbum && def.AlbumID != "" {
+			message += fmt.Sprintf("; backing album '%s' deleted", def.AlbumName)
+		}
+		result["success"] = true
+		result["message"] = message
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// RefreshSmartAlbumDefinition refreshes one stored definition outside an MCP
+// call; used by the background scheduler.
+func RefreshSmartAlbumDefinition(ctx context.Context, immichClient *immich.Client, store *smartalbums.Store, def *smartalbums.SmartAlbumDefinition) error {
+	_, err := refreshSmartAlbumDefinition(ctx, immichClient, store, def)
+	return err
+}
+
+// RefreshSmartAlbumByName refreshes one definition by name; used by non-MCP
+// triggers such as the Home Assistant webhook endpoint.
+func RefreshSmartAlbumByName(ctx context.Context, immichClient *immich.Client, store *smartalbums.Store, name string) error {
+	def, err := lookupSmartAlbum(store, "", name)
+	if err != nil {
+		return err
+	}
+	_, err = refreshSmartAlbumDefinition(ctx, immichClient, store, def)
+	return err
+}
+
+// refreshSmartAlbumDefinition executes one definition's query and syncs the
+// target album; dry runs take the structured-diff path in smartalbumdiff.go
+// instead
+func refreshSmartAlbumDefinition(ctx context.Context, immichClient *immich.Client, store *smartalbums.Store, def *smartalbums.SmartAlbumDefinition) (_ *mcp.CallToolResult, refreshErr error) {
+	// Record the run in the history regardless of how it ends; a history
+	// that cannot be written never fails the refresh
+	run := smartalbums.RunRecord{DefinitionID: def.ID, StartedAt: time.Now()}
+	defer func() {
+		run.FinishedAt = time.Now()
+		if refreshErr != nil {
+			run.Error = refreshErr.Error()
+		}
+		if err := store.AppendRun(run); err != nil {
+			log.Warn().Err(err).Str("definition", def.Name).Msg("Failed to record smart album run")
+		}
+	}()
+
+	query := def.Query
+	if def.MaxAssets > 0 {
+		query.Size = def.MaxAssets
+	}
+
+	// Stream the matches, retaining only IDs rather than full asset objects
+	var assetIDs []string
+	err := immichClient.SmartSearchStream(ctx, query, func(asset immich.Asset) error {
+		assetIDs = append(assetIDs, asset.ID)
+		return nil
+	})
+	if err != nil {
+		def.LastRunError = err.Error()
+		def.LastRunAt = time.Now()
+		_ = store.Update(*def)
+		return nil, fmt.Errorf("smart search failed: %w", err)
+	}
+	run.Matched = len(assetIDs)
+
+	result := map[string]interface{}{
+		"definition": def.Name,
+		"albumName":  def.AlbumName,
+		"matched":    len(assetIDs),
+	}
+
+	// Find or create the target album
+	albumID := def.AlbumID
+	if albumID == "" {
+		albums, err := immichClient.ListAlbums(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list albums: %w", err)
+		}
+		for _, album := range albums {
+			if album.AlbumName == def.AlbumName {
+				albumID = album.ID
+				break
+			}
+		}
+		if albumID == "" {
+			newAlbum, err := immichClient.CreateAlbum(ctx, immich.CreateAlbumParams{
+				Name:        def.AlbumName,
+				Description: def.Description,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create album: %w", err)
+			}
+			albumID = newAlbum.ID
+			result["albumCreated"] = true
+		}
+	}
+
+	if err := checkAlbumLock(albumID, def.AlbumName); err != nil {
+		return nil, err
+	}
+
+	added := 0
+	var addedIDs []string
+	if len(assetIDs) > 0 {
+		bulkResult, err := immichClient.AddAssetsToAlbum(ctx, albumID, assetIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add assets to album: %w", err)
+		}
+		added = len(bulkResult.Success)
+		addedIDs = bulkResult.Success
+	}
+	run.Added = added
+
+	// Membership-change hook: albums with a tag sync rule get the rule's
+	// tags applied to newly added assets
+	if len(addedIDs) > 0 {
+		if syncTags := store.AlbumTags(albumID); len(syncTags) > 0 {
+			if _, err := applyTagsToAssets(ctx, immichClient, syncTags, addedIDs); err != nil {
+				result["tagSyncError"] = err.Error()
+			} else {
+				result["tagsSynced"] = syncTags
+			}
+		}
+	}
+
+	// Strategy-dependent removal of album assets that no longer match;
+	// pinned assets always stay
+	removed := 0
+	if def.SyncStrategy == smartalbums.SyncFull || def.SyncStrategy == smartalbums.SyncArchiveRemoved {
+		albumAssets, err := immichClient.GetAlbumAssets(ctx, albumID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get album assets: %w", err)
+		}
+		matched := make(map[string]bool, len(assetIDs))
+		for _, id := range assetIDs {
+			matched[id] = true
+		}
+		pinned := map[string]bool{}
+		for _, id := range store.PinnedAssets(albumID) {
+			pinned[id] = true
+		}
+
+		var removeIDs []string
+		pinnedKept := 0
+		for _, asset := range albumAssets {
+			if matched[asset.ID] {
+				continue
+			}
+			if pinned[asset.ID] {
+				pinnedKept++
+				continue
+			}
+			removeIDs = append(removeIDs, asset.ID)
+		}
+
+		if len(removeIDs) > 0 {
+			removeResult, err := immichClient.RemoveAssetsFromAlbum(ctx, albumID, removeIDs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove stale assets from album: %w", err)
+			}
+			removed = len(removeResult.Success)
+			if removed > 0 {
+				recordUndo(undo.Operation{
+					Tool:     "refreshSmartAlbum",
+					Kind:     undo.KindAlbumRemove,
+					AlbumID:  albumID,
+					AssetIDs: removeResult.Success,
+				})
+			}
+			if def.SyncStrategy == smartalbums.SyncArchiveRemoved && removed > 0 {
+				if err := immichClient.UpdateAssetsBulk(ctx, removeResult.Success, map[string]interface{}{"isArchived": true}); err != nil {
+					result["archiveError"] = err.Error()
+				} else {
+					result["archived"] = removed
+				}
+			}
+		}
+		if pinnedKept > 0 {
+			result["pinnedKept"] = pinnedKept
+		}
+		result["removed"] = removed
+	}
+
+	// Persist run stats and the resolved album ID
+	def.AlbumID = albumID
+	def.LastRunAt = time.Now()
+	def.LastRunMatched = len(assetIDs)
+	def.LastRunAdded = added
+	def.LastRunRemoved = removed
+	def.LastRunError = ""
+	if err := store.Update(*def); err != nil {
+		return nil, fmt.Errorf("failed to update definition: %w", err)
+	}
+
+	result["albumID"] = albumID
+	result["added"] = added
+	result["success"] = true
+	message := fmt.Sprintf("Smart album '%s': %d matched, %d newly added", def.Name, len(assetIDs), added)
+	if removed > 0 {
+		if def.SyncStrategy == smartalbums.SyncArchiveRemoved {
+			message += fmt.Sprintf(", %d no-longer-matching removed and archived", removed)
+		} else {
+			message += fmt.Sprintf(", %d no-longer-matching removed", removed)
+		}
+	}
+	result["message"] = message
+
+	publishEvent("album_refreshed", map[string]interface{}{
+		"smartAlbum": def.Name,
+		"albumId":    albumID,
+		"matched":    len(assetIDs),
+		"added":      added,
+		"removed":    removed,
+	})
+
+	return makeMCPResult(result)
+}
+
+// renameAlbum tool
+func registerRenameAlbum(s *server.MCPServer, immichClient *immich.Client, store *smartalbums.Store) {
+	tool := mcp.Tool{
+		Name:        "renameAlbum",
+		Description: "Rename an album and transparently update every smart album definition referencing the old name, preventing silent breakage of automation",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"oldName": map[string]interface{}{
+					"type":        "string",
+					"description": "Current album name",
+				},
+				"albumId": map[string]interface{}{
+					"type":        "string",
+					"description": "Album ID (alternative to oldName)",
+				},
+				"newName": map[string]interface{}{
+					"type":        "string",
+					"description": "New album name",
+				},
+			},
+			Required: []string{"newName"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			OldName string `json:"oldName"`
+			AlbumID string `json:"albumId"`
+			NewName string `json:"newName"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if params.NewName == "" {
+			return nil, fmt.Errorf("newName is required")
+		}
+		if params.OldName == "" && params.AlbumID == "" {
+			return nil, fmt.Errorf("either oldName or albumId must be provided")
+		}
+
+		albums, err := immichClient.ListAlbums(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list albums: %w", err)
+		}
+
+		// Resolve the album and guard against name collisions
+		albumID := params.AlbumID
+		oldName := params.OldName
+		for _, album := range albums {
+			if album.AlbumName == params.NewName {
+				return nil, fmt.Errorf("an album named '%s' already exists", params.NewName)
+			}
+			if albumID == "" && album.AlbumName == params.OldName {
+				albumID = album.ID
+			}
+			if albumID != "" && album.ID == albumID && oldName == "" {
+				oldName = album.AlbumName
+			}
+		}
+		if albumID == "" {
+			return nil, fmt.Errorf("album '%s' not found", params.OldName)
+		}
+
+		if err := checkAlbumLock(albumID, oldName); err != nil {
+			return nil, err
+		}
+
+		if _, err := immichClient.UpdateAlbum(ctx, albumID, map[string]interface{}{
+			"albumName": params.NewName,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to rename album: %w", err)
+		}
+
+		// Keep smart album definitions pointing at the renamed album
+		updatedDefs, err := store.RenameAlbumReferences(albumID, oldName, params.NewName)
+		if err != nil {
+			return nil, fmt.Errorf("album renamed but updating smart album definitions failed: %w", err)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":            true,
+			"albumId":            albumID,
+			"oldName":            oldName,
+			"newName":            params.NewName,
+			"updatedDefinitions": updatedDefs,
+			"message":            fmt.Sprintf("Renamed album '%s' to '%s' and updated %d smart album definitions", oldName, params.NewName, len(updatedDefs)),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
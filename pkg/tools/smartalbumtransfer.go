@@ -0,0 +1,260 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/smartalbums"
+)
+
+// smartAlbumExportVersion is the format version written into export
+// documents; bump it when portableSmartAlbum changes shape.
+const smartAlbumExportVersion = 1
+
+// portableSmartAlbum is a definition stripped to what transfers between
+// servers: no IDs, run stats, or query history — those are local state.
+type portableSmartAlbum struct {
+	Name                   string                   `json:"name"`
+	AlbumName              string                   `json:"albumName"`
+	Description            string                   `json:"description,omitempty"`
+	Query                  immich.SmartSearchParams `json:"query"`
+	MaxAssets              int                      `json:"maxAssets,omitempty"`
+	RefreshIntervalMinutes int                      `json:"refreshIntervalMinutes,omitempty"`
+	RefreshCron            string                   `json:"refreshCron,omitempty"`
+	SyncStrategy           string                   `json:"syncStrategy,omitempty"`
+}
+
+// smartAlbumExport is the portable JSON document produced by
+// exportSmartAlbums and consumed by importSmartAlbums.
+type smartAlbumExport struct {
+	Version     int                  `json:"version"`
+	ExportedAt  time.Time            `json:"exportedAt"`
+	Definitions []portableSmartAlbum `json:"definitions"`
+}
+
+// exportSmartAlbums tool
+func registerExportSmartAlbums(s *server.MCPServer, store *smartalbums.Store) {
+	tool := mcp.Tool{
+		Name:        "exportSmartAlbums",
+		Description: "Serialize all smart album definitions to a portable JSON document for backup or transfer to another server; local state (album IDs, run stats, query history) is not included",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"path": map[string]interface{}{
+					"type":        "string",
+					"description": "Also write the document to this server-side file",
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Path string `json:"path"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		defs := store.List()
+		sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+
+		doc := smartAlbumExport{
+			Version:     smartAlbumExportVersion,
+			ExportedAt:  time.Now(),
+			Definitions: make([]portableSmartAlbum, 0, len(defs)),
+		}
+		for _, def := range defs {
+			doc.Definitions = append(doc.Definitions, portableSmartAlbum{
+				Name:                   def.Name,
+				AlbumName:              def.AlbumName,
+				Description:            def.Description,
+				Query:                  def.Query,
+				MaxAssets:              def.MaxAssets,
+				RefreshIntervalMinutes: def.RefreshIntervalMinutes,
+				RefreshCron:            def.RefreshCron,
+				SyncStrategy:           def.SyncStrategy,
+			})
+		}
+
+		result := map[string]interface{}{
+			"success":  true,
+			"count":    len(doc.Definitions),
+			"document": doc,
+			"message":  fmt.Sprintf("Exported %d smart album definitions", len(doc.Definitions)),
+		}
+
+		if params.Path != "" {
+			data, err := json.MarshalIndent(doc, "", "  ")
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode export document: %w", err)
+			}
+			if err := os.WriteFile(params.Path, data, 0o644); err != nil {
+				return nil, fmt.Errorf("failed to write export document: %w", err)
+			}
+			result["path"] = params.Path
+		}
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// importSmartAlbums tool
+func registerImportSmartAlbums(s *server.MCPServer, store *smartalbums.Store) {
+	tool := mcp.Tool{
+		Name:        "importSmartAlbums",
+		Description: "Import smart album definitions from an exportSmartAlbums document, with a conflict policy for names that already exist (skip, overwrite, or rename)",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"document": map[string]interface{}{
+					"type":        "object",
+					"description": "Export document produced by exportSmartAlbums (alternative to path)",
+				},
+				"path": map[string]interface{}{
+					"type":        "string",
+					"description": "Server-side file holding the export document (alternative to document)",
+				},
+				"onConflict": map[string]interface{}{
+					"type":        "string",
+					"description": "What to do when an imported name already exists: skip it, overwrite the existing definition, or rename the import",
+					"enum":        []string{"skip", "overwrite", "rename"},
+					"default":     "skip",
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Report what would be imported, skipped or renamed without changing the store",
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Document   json.RawMessage `json:"document"`
+			Path       string          `json:"path"`
+			OnConflict string          `json:"onConflict"`
+			DryRun     bool            `json:"dryRun"`
+		}
+
+		// Set defaults
+		params.OnConflict = "skip"
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		switch params.OnConflict {
+		case "skip", "overwrite", "rename":
+		default:
+			return nil, fmt.Errorf("invalid onConflict: %s (must be skip, overwrite or rename)", params.OnConflict)
+		}
+
+		data := []byte(params.Document)
+		if len(data) == 0 {
+			if params.Path == "" {
+				return nil, fmt.Errorf("either document or path must be provided")
+			}
+			fileData, err := os.ReadFile(params.Path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read import document: %w", err)
+			}
+			data = fileData
+		}
+
+		var doc smartAlbumExport
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse import document: %w", err)
+		}
+		if doc.Version > smartAlbumExportVersion {
+			return nil, fmt.Errorf("import document version %d is newer than this build supports (%d)", doc.Version, smartAlbumExportVersion)
+		}
+
+		imported := []string{}
+		overwritten := []string{}
+		skipped := []string{}
+		renamed := map[string]string{}
+
+		for _, portable := range doc.Definitions {
+			if portable.Name == "" {
+				return nil, fmt.Errorf("import document contains a definition without a name")
+			}
+
+			name := portable.Name
+			_, exists := store.GetByName(name)
+			if exists {
+				switch params.OnConflict {
+				case "skip":
+					skipped = append(skipped, name)
+					continue
+				case "overwrite":
+					overwritten = append(overwritten, name)
+				case "rename":
+					original := name
+					for n := 2; ; n++ {
+						name = fmt.Sprintf("%s (imported)", original)
+						if n > 2 {
+							name = fmt.Sprintf("%s (imported %d)", original, n-1)
+						}
+						if _, taken := store.GetByName(name); !taken {
+							break
+						}
+					}
+					renamed[original] = name
+				}
+			} else {
+				imported = append(imported, name)
+			}
+
+			if params.DryRun {
+				continue
+			}
+			if _, err := store.Define(smartalbums.SmartAlbumDefinition{
+				Name:                   name,
+				AlbumName:              portable.AlbumName,
+				Description:            portable.Description,
+				Query:                  portable.Query,
+				MaxAssets:              portable.MaxAssets,
+				RefreshIntervalMinutes: portable.RefreshIntervalMinutes,
+				RefreshCron:            portable.RefreshCron,
+				SyncStrategy:           portable.SyncStrategy,
+			}); err != nil {
+				return nil, fmt.Errorf("failed to import definition '%s': %w", name, err)
+			}
+		}
+
+		applied := len(imported) + len(overwritten) + len(renamed)
+		message := fmt.Sprintf("Imported %d smart album definitions (%d new, %d overwritten, %d renamed, %d skipped)",
+			applied, len(imported), len(overwritten), len(renamed), len(skipped))
+		if params.DryRun {
+			message = "Dry run: " + message
+		}
+
+		result := map[string]interface{}{
+			"success":     true,
+			"dryRun":      params.DryRun,
+			"imported":    imported,
+			"overwritten": overwritten,
+			"skipped":     skipped,
+			"message":     message,
+		}
+		if len(renamed) > 0 {
+			result["renamed"] = renamed
+		}
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
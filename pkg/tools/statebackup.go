@@ -0,0 +1,153 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/storage"
+)
+
+// RegisterStateTools registers the backup and restore tools over the
+// server's own persistent state (smart albums, saved searches, recycle
+// snapshots, operation journal, tool history), all reached through the
+// storage backend's namespaces.
+func RegisterStateTools(s *server.MCPServer, backend storage.Backend) {
+	registerBackupServerState(s, backend)
+	registerRestoreServerState(s, backend)
+}
+
+// registerBackupServerState registers the tool that bundles every storage
+// namespace into a single versioned backup file.
+func registerBackupServerState(s *server.MCPServer, backend storage.Backend) {
+	tool := mcp.Tool{
+		Name:        "backupServerState",
+		Description: "Write a single versioned backup file containing all of the server's own persistent state (smart album definitions, saved searches, recycle snapshots, operation journal, tool history)",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"path": map[string]interface{}{
+					"type":        "string",
+					"description": "Where to write the backup file (server-local path)",
+					"default":     "mcp_state_backup.json",
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Path string `json:"path"`
+		}
+
+		// Set defaults
+		params.Path = "mcp_state_backup.json"
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		snap, err := storage.Snapshot(backend)
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot server state: %w", err)
+		}
+
+		data, err := json.MarshalIndent(snap, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode backup: %w", err)
+		}
+		if err := os.WriteFile(params.Path, data, 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write backup file: %w", err)
+		}
+
+		namespaces := make([]string, 0, len(snap.State))
+		for namespace := range snap.State {
+			namespaces = append(namespaces, namespace)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":    true,
+			"path":       params.Path,
+			"version":    snap.Version,
+			"createdAt":  snap.CreatedAt.Format(time.RFC3339),
+			"namespaces": namespaces,
+			"sizeBytes":  len(data),
+			"message":    fmt.Sprintf("Backed up %d state namespaces to %s", len(namespaces), params.Path),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// registerRestoreServerState registers the tool that restores a backup file
+// back into the live state files.
+func registerRestoreServerState(s *server.MCPServer, backend storage.Backend) {
+	tool := mcp.Tool{
+		Name:        "restoreServerState",
+		Description: "Restore a backupServerState backup file over the server's persistent state; the running server keeps its in-memory state, so restart after restoring",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"path": map[string]interface{}{
+					"type":        "string",
+					"description": "Backup file to restore from (server-local path)",
+				},
+				"confirm": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Must be true; restoring overwrites the current state files",
+					"default":     false,
+				},
+			},
+			Required: []string{"path"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Path    string `json:"path"`
+			Confirm bool   `json:"confirm"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if params.Path == "" {
+			return nil, fmt.Errorf("path is required")
+		}
+		if !params.Confirm {
+			return nil, fmt.Errorf("restoring overwrites the current state files; pass confirm=true to proceed")
+		}
+
+		data, err := os.ReadFile(params.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup file: %w", err)
+		}
+		var snap storage.StateSnapshot
+		if err := json.Unmarshal(data, &snap); err != nil {
+			return nil, fmt.Errorf("invalid backup file: %w", err)
+		}
+
+		restored, skipped, err := storage.Restore(backend, &snap)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore server state: %w", err)
+		}
+
+		result := map[string]interface{}{
+			"success":         true,
+			"backupCreatedAt": snap.CreatedAt.Format(time.RFC3339),
+			"restored":        restored,
+			"message":         fmt.Sprintf("Restored %d state namespaces from %s; restart the server to load them", len(restored), params.Path),
+		}
+		if len(skipped) > 0 {
+			result["skippedUnknownNamespaces"] = skipped
+		}
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
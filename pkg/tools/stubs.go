@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// strictTools controls how declared-but-unimplemented tools behave: when
+// true (the default) they are not advertised at all; when false they are
+// registered with a handler that reports they are not implemented.
+var strictTools = true
+
+// SetStrictTools toggles strict registration of planned tools.
+func SetStrictTools(strict bool) {
+	strictTools = strict
+}
+
+// PlannedTool describes a tool that is declared but not implemented yet.
+type PlannedTool struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// plannedTools collects every planned tool seen during registration so the
+// capability report can list implemented vs planned.
+var plannedTools []PlannedTool
+
+// PlannedTools returns the tools declared but not implemented.
+func PlannedTools() []PlannedTool {
+	return plannedTools
+}
+
+// registerPlannedTool records a declared-but-unimplemented tool. In strict
+// mode it is not advertised; otherwise it responds with a clear
+// not-implemented error instead of silently doing nothing.
+func registerPlannedTool(s *server.MCPServer, name, description string) {
+	known := false
+	for _, planned := range plannedTools {
+		if planned.Name == name {
+			known = true
+			break
+		}
+	}
+	if !known {
+		plannedTools = append(plannedTools, PlannedTool{Name: name, Description: description})
+	}
+
+	if strictTools {
+		return
+	}
+
+	tool := mcp.Tool{
+		Name:        name,
+		Description: description + " (not implemented yet)",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+	}
+	s.AddTool(tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, fmt.Errorf("tool '%s' is declared but not implemented", name)
+	})
+}
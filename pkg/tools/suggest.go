@@ -0,0 +1,348 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/bulkops"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// albumProfile summarizes one existing album for suggestion scoring: who
+// appears in it, where its photos were taken, and the date range it covers.
+type albumProfile struct {
+	ID        string
+	Name      string
+	People    map[string]bool
+	Cities    map[string]bool
+	Countries map[string]bool
+	Earliest  time.Time
+	Latest    time.Time
+}
+
+// albumSuggestion is one ranked placement suggestion for an asset
+type albumSuggestion struct {
+	AlbumID   string   `json:"albumId"`
+	AlbumName string   `json:"albumName"`
+	Score     int      `json:"score"`
+	Reasons   []string `json:"reasons"`
+}
+
+// assetSuggestions pairs an asset with its ranked album suggestions
+type assetSuggestions struct {
+	AssetID     string            `json:"assetId"`
+	FileName    string            `json:"fileName"`
+	TakenAt     string            `json:"takenAt,omitempty"`
+	Suggestions []albumSuggestion `json:"suggestions"`
+}
+
+// Suggestion scoring weights: shared people are the strongest signal,
+// then matching location, then date proximity to the album's range.
+const (
+	suggestScorePerPerson   = 3
+	suggestScoreCity        = 2
+	suggestScoreCountry     = 1
+	suggestScoreInDateRange = 2
+	suggestScoreNearDates   = 1
+	suggestNearDays         = 30
+)
+
+// registerSuggestAlbumsForAssets registers the tool that proposes album
+// placements for unorganized assets.
+func registerSuggestAlbumsForAssets(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "suggestAlbumsForAssets",
+		Description: "For assets not in any album (or an explicit list), rank the most similar existing albums by shared people, location, and date proximity, returning placement suggestions for confirmation",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"assetIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Assets to place (when empty, unfiled assets are scanned instead)",
+				},
+				"maxAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum unfiled assets to consider when no explicit list is given",
+					"default":     100,
+				},
+				"maxSuggestions": map[string]interface{}{
+					"type":        "integer",
+					"description": "Ranked suggestions to return per asset",
+					"default":     3,
+				},
+				"minScore": map[string]interface{}{
+					"type":        "integer",
+					"description": "Drop suggestions scoring below this",
+					"default":     2,
+				},
+				"maxAlbums": map[string]interface{}{
+					"type":        "integer",
+					"description": "Most recently updated albums to profile as candidates (0 for all)",
+					"default":     50,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AssetIds       []string `json:"assetIds"`
+			MaxAssets      int      `json:"maxAssets"`
+			MaxSuggestions int      `json:"maxSuggestions"`
+			MinScore       int      `json:"minScore"`
+			MaxAlbums      int      `json:"maxAlbums"`
+		}
+
+		// Set defaults
+		params.MaxAssets = 100
+		params.MaxSuggestions = 3
+		params.MinScore = 2
+		params.MaxAlbums = 50
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		// Collect the assets to place
+		var candidates []immich.Asset
+		if len(params.AssetIds) > 0 {
+			tombstones := newTombstoneTracker()
+			for _, id := range params.AssetIds {
+				// Check for context cancellation
+				select {
+				case <-ctx.Done():
+					return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+				default:
+				}
+
+				asset, err := immichClient.GetAssetMetadata(ctx, id)
+				if err != nil {
+					tombstones.Note(id, err)
+					continue
+				}
+				candidates = append(candidates, *asset)
+			}
+			result := map[string]interface{}{}
+			tombstones.Report(result)
+			if len(candidates) == 0 {
+				result["success"] = false
+				result["message"] = "None of the requested assets could be loaded"
+				return makeMCPResult(result)
+			}
+			return suggestAlbums(ctx, immichClient, candidates, params.MaxAlbums, params.MaxSuggestions, params.MinScore, result)
+		}
+
+		notInAlbum := true
+		unfiled, err := bulkops.ScanAssets(ctx, immichClient, bulkops.ScanOptions{
+			Max:     params.MaxAssets,
+			Filters: immich.MetadataSearchFilters{IsNotInAlbum: &notInAlbum},
+		}, func(asset immich.Asset) bool {
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan unfiled assets: %w", err)
+		}
+
+		return suggestAlbums(ctx, immichClient, unfiled.Assets, params.MaxAlbums, params.MaxSuggestions, params.MinScore, map[string]interface{}{})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// suggestAlbums profiles the candidate albums, scores every asset against
+// them, and builds the ranked-suggestion result.
+func suggestAlbums(ctx context.Context, immichClient *immich.Client, assets []immich.Asset, maxAlbums, maxSuggestions, minScore int, result map[string]interface{}) (*mcp.CallToolResult, error) {
+	profiles, err := buildAlbumProfiles(ctx, immichClient, maxAlbums)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]assetSuggestions, 0, len(assets))
+	unmatched := 0
+	for _, asset := range assets {
+		// Check for context cancellation
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+		default:
+		}
+
+		ranked := rankAlbumsForAsset(asset, profiles, maxSuggestions, minScore)
+		if len(ranked) == 0 {
+			unmatched++
+			continue
+		}
+		entry := assetSuggestions{
+			AssetID:     asset.ID,
+			FileName:    asset.OriginalFileName,
+			Suggestions: ranked,
+		}
+		if taken := assetTakenAt(asset); !taken.IsZero() {
+			entry.TakenAt = taken.Format("2006-01-02")
+		}
+		suggestions = append(suggestions, entry)
+	}
+
+	result["assetsConsidered"] = len(assets)
+	result["albumsProfiled"] = len(profiles)
+	result["withSuggestions"] = len(suggestions)
+	result["unmatched"] = unmatched
+	result["suggestions"] = suggestions
+	result["success"] = true
+	result["message"] = fmt.Sprintf("Suggested albums for %d of %d assets; review and confirm placements with moveAssetsToAlbum",
+		len(suggestions), len(assets))
+
+	return makeMCPResult(result)
+}
+
+// buildAlbumProfiles loads the people, places, and date range of each
+// candidate album, most recently updated first.
+func buildAlbumProfiles(ctx context.Context, immichClient *immich.Client, maxAlbums int) ([]albumProfile, error) {
+	albums, err := immichClient.ListAlbums(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list albums: %w", err)
+	}
+
+	sort.Slice(albums, func(i, j int) bool {
+		return albums[i].UpdatedAt.After(albums[j].UpdatedAt)
+	})
+	if maxAlbums > 0 && len(albums) > maxAlbums {
+		albums = albums[:maxAlbums]
+	}
+
+	profiles := make([]albumProfile, 0, len(albums))
+	for _, album := range albums {
+		// Check for context cancellation
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+		default:
+		}
+
+		if album.AssetCount == 0 {
+			continue
+		}
+
+		assets, err := immichClient.GetAlbumAssets(ctx, album.ID)
+		if err != nil {
+			// A single unreadable album should not sink the whole analysis
+			continue
+		}
+
+		profile := albumProfile{
+			ID:        album.ID,
+			Name:      album.AlbumName,
+			People:    map[string]bool{},
+			Cities:    map[string]bool{},
+			Countries: map[string]bool{},
+		}
+		for _, asset := range assets {
+			for _, person := range asset.People {
+				profile.People[person.ID] = true
+			}
+			if asset.ExifInfo != nil {
+				if asset.ExifInfo.City != "" {
+					profile.Cities[strings.ToLower(asset.ExifInfo.City)] = true
+				}
+				if asset.ExifInfo.Country != "" {
+					profile.Countries[strings.ToLower(asset.ExifInfo.Country)] = true
+				}
+			}
+			if taken := assetTakenAt(asset); !taken.IsZero() {
+				if profile.Earliest.IsZero() || taken.Before(profile.Earliest) {
+					profile.Earliest = taken
+				}
+				if taken.After(profile.Latest) {
+					profile.Latest = taken
+				}
+			}
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+// rankAlbumsForAsset scores one asset against every album profile and
+// returns the top suggestions that clear the score floor.
+func rankAlbumsForAsset(asset immich.Asset, profiles []albumProfile, maxSuggestions, minScore int) []albumSuggestion {
+	var ranked []albumSuggestion
+	for i := range profiles {
+		score, reasons := scoreAssetAgainstProfile(asset, &profiles[i])
+		if score < minScore || score <= 0 {
+			continue
+		}
+		ranked = append(ranked, albumSuggestion{
+			AlbumID:   profiles[i].ID,
+			AlbumName: profiles[i].Name,
+			Score:     score,
+			Reasons:   reasons,
+		})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	if maxSuggestions > 0 && len(ranked) > maxSuggestions {
+		ranked = ranked[:maxSuggestions]
+	}
+	return ranked
+}
+
+// scoreAssetAgainstProfile computes the similarity score and the
+// human-readable reasons behind it
+func scoreAssetAgainstProfile(asset immich.Asset, profile *albumProfile) (int, []string) {
+	score := 0
+	var reasons []string
+
+	shared := 0
+	for _, person := range asset.People {
+		if profile.People[person.ID] {
+			shared++
+		}
+	}
+	if shared > 0 {
+		score += shared * suggestScorePerPerson
+		reasons = append(reasons, fmt.Sprintf("%d shared people", shared))
+	}
+
+	if asset.ExifInfo != nil {
+		if city := strings.ToLower(asset.ExifInfo.City); city != "" && profile.Cities[city] {
+			score += suggestScoreCity
+			reasons = append(reasons, fmt.Sprintf("taken in %s", asset.ExifInfo.City))
+		} else if country := strings.ToLower(asset.ExifInfo.Country); country != "" && profile.Countries[country] {
+			score += suggestScoreCountry
+			reasons = append(reasons, fmt.Sprintf("taken in %s", asset.ExifInfo.Country))
+		}
+	}
+
+	if taken := assetTakenAt(asset); !taken.IsZero() && !profile.Earliest.IsZero() {
+		margin := suggestNearDays * 24 * time.Hour
+		switch {
+		case !taken.Before(profile.Earliest) && !taken.After(profile.Latest):
+			score += suggestScoreInDateRange
+			reasons = append(reasons, "within the album's date range")
+		case taken.After(profile.Earliest.Add(-margin)) && taken.Before(profile.Latest.Add(margin)):
+			score += suggestScoreNearDates
+			reasons = append(reasons, fmt.Sprintf("within %d days of the album's date range", suggestNearDays))
+		}
+	}
+
+	return score, reasons
+}
+
+// assetTakenAt picks the best available capture time for an asset
+func assetTakenAt(asset immich.Asset) time.Time {
+	if asset.ExifInfo != nil && asset.ExifInfo.DateTimeOriginal != "" {
+		if t, err := time.Parse(time.RFC3339, asset.ExifInfo.DateTimeOriginal); err == nil {
+			return t
+		}
+	}
+	return asset.FileCreatedAt
+}
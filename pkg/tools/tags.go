@@ -0,0 +1,245 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// registerMaterializeSmartTags registers the tool that converts ML-derived
+// SmartInfo concepts into real Immich tags.
+func registerMaterializeSmartTags(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "materializeSmartTags",
+		Description: "Read SmartInfo tags/objects for assets and create and link matching Immich tags in bulk, making ML-derived concepts filterable via the standard tag APIs",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"assetIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Asset IDs to process (use either this or albumName)",
+				},
+				"albumName": map[string]interface{}{
+					"type":        "string",
+					"description": "Process every asset in this album instead of listing IDs",
+				},
+				"source": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"tags", "objects", "both"},
+					"description": "Which SmartInfo fields to materialize",
+					"default":     "both",
+				},
+				"minAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Only create tags matched by at least this many assets",
+					"default":     1,
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Preview the tags that would be created without writing anything",
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AssetIds  []string `json:"assetIds"`
+			AlbumName string   `json:"albumName"`
+			Source    string   `json:"source"`
+			MinAssets int      `json:"minAssets"`
+			DryRun    bool     `json:"dryRun"`
+		}
+
+		// Set defaults
+		params.Source = "both"
+		params.MinAssets = 1
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if len(params.AssetIds) == 0 && params.AlbumName == "" {
+			return nil, fmt.Errorf("either assetIds or albumName is required")
+		}
+		if params.MinAssets < 1 {
+			params.MinAssets = 1
+		}
+
+		// Resolve the album to a list of asset IDs if needed
+		assetIDs := params.AssetIds
+		if params.AlbumName != "" {
+			albums, err := immichClient.ListAlbums(ctx, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list albums: %w", err)
+			}
+			var albumID string
+			for _, album := range albums {
+				if album.AlbumName == params.AlbumName {
+					albumID = album.ID
+					break
+				}
+			}
+			if albumID == "" {
+				return nil, fmt.Errorf("album '%s' not found", params.AlbumName)
+			}
+			assets, err := immichClient.GetAlbumAssets(ctx, albumID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get album assets: %w", err)
+			}
+			for _, asset := range assets {
+				assetIDs = append(assetIDs, asset.ID)
+			}
+		}
+
+		// Collect concepts per asset
+		conceptAssets := map[string][]string{}
+		tombstones := newTombstoneTracker()
+		errors := []string{}
+		scanned := 0
+		withSmartInfo := 0
+
+		for _, assetID := range assetIDs {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			asset, err := immichClient.GetAssetMetadata(ctx, assetID)
+			if err != nil {
+				// Assets trashed mid-operation are tombstoned, not failures
+				if tombstones.Note(assetID, err) {
+					continue
+				}
+				if len(errors) < 10 {
+					errors = append(errors, fmt.Sprintf("failed to get metadata for %s: %v", assetID, err))
+				}
+				continue
+			}
+			scanned++
+
+			if asset.SmartInfo == nil {
+				continue
+			}
+			withSmartInfo++
+
+			var concepts []string
+			if params.Source == "tags" || params.Source == "both" {
+				concepts = append(concepts, asset.SmartInfo.Tags...)
+			}
+			if params.Source == "objects" || params.Source == "both" {
+				concepts = append(concepts, asset.SmartInfo.Objects...)
+			}
+
+			seen := map[string]bool{}
+			for _, concept := range concepts {
+				concept = strings.ToLower(strings.TrimSpace(concept))
+				if concept == "" || seen[concept] {
+					continue
+				}
+				seen[concept] = true
+				conceptAssets[concept] = append(conceptAssets[concept], asset.ID)
+			}
+		}
+
+		// Drop concepts below the threshold and sort for stable output
+		concepts := make([]string, 0, len(conceptAssets))
+		for concept, ids := range conceptAssets {
+			if len(ids) < params.MinAssets {
+				delete(conceptAssets, concept)
+				continue
+			}
+			concepts = append(concepts, concept)
+		}
+		sort.Strings(concepts)
+
+		result := map[string]interface{}{
+			"success":       true,
+			"assetsScanned": scanned,
+			"withSmartInfo": withSmartInfo,
+			"conceptsFound": len(concepts),
+			"dryRun":        params.DryRun,
+		}
+
+		conceptCounts := map[string]int{}
+		for _, concept := range concepts {
+			conceptCounts[concept] = len(conceptAssets[concept])
+		}
+		result["concepts"] = conceptCounts
+
+		if params.DryRun {
+			result["message"] = fmt.Sprintf("DRY RUN: would materialize %d tags across %d assets", len(concepts), scanned)
+			tombstones.Report(result)
+			if len(errors) > 0 {
+				result["errors"] = errors
+			}
+			return makeMCPResult(result)
+		}
+
+		if len(concepts) == 0 {
+			result["message"] = "No SmartInfo concepts met the threshold; nothing to materialize"
+			tombstones.Report(result)
+			if len(errors) > 0 {
+				result["errors"] = errors
+			}
+			return makeMCPResult(result)
+		}
+
+		// Create any missing tags, then link each tag to its matching assets
+		tags, err := immichClient.UpsertTags(ctx, concepts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upsert tags: %w", err)
+		}
+
+		tagsByName := map[string]immich.Tag{}
+		for _, tag := range tags {
+			tagsByName[strings.ToLower(tag.Name)] = tag
+		}
+
+		linked := 0
+		for _, concept := range concepts {
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			tag, found := tagsByName[concept]
+			if !found {
+				if len(errors) < 10 {
+					errors = append(errors, fmt.Sprintf("tag '%s' missing from upsert response", concept))
+				}
+				continue
+			}
+			linkResult, err := immichClient.TagAssets(ctx, tag.ID, conceptAssets[concept])
+			if err != nil {
+				if len(errors) < 10 {
+					errors = append(errors, fmt.Sprintf("failed to tag assets with '%s': %v", concept, err))
+				}
+				continue
+			}
+			linked += len(linkResult.Success)
+		}
+
+		result["tagsMaterialized"] = len(tags)
+		result["assetsLinked"] = linked
+		result["message"] = fmt.Sprintf("Materialized %d tags and linked %d asset-tag pairs", len(tags), linked)
+		tombstones.Report(result)
+		if len(errors) > 0 {
+			result["errors"] = errors
+		}
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
@@ -0,0 +1,162 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// registerHideAlbumFromTimeline registers the tool that archives every asset
+// in an album so they stay organized but no longer appear in the main feed.
+func registerHideAlbumFromTimeline(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "hideAlbumFromTimeline",
+		Description: "Archive (hide) every asset in an album without deleting anything, keeping the photos out of the main timeline. Use unhide=true to bring them back.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"albumName": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the album whose assets should be hidden",
+				},
+				"albumId": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the album (if known, otherwise will search by name)",
+				},
+				"unhide": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Un-archive the album's assets instead, restoring them to the timeline",
+					"default":     false,
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Just count affected assets without changing them",
+					"default":     false,
+				},
+			},
+			Required: []string{},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AlbumName string `json:"albumName"`
+			AlbumID   string `json:"albumId"`
+			Unhide    bool   `json:"unhide"`
+			DryRun    bool   `json:"dryRun"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		// Find album if not provided by ID
+		var albumID string
+		var albumName string
+
+		if params.AlbumID != "" {
+			albumID = params.AlbumID
+			albumName = params.AlbumName // May be empty
+		} else if params.AlbumName != "" {
+			albums, err := immichClient.ListAlbums(ctx, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list albums: %w", err)
+			}
+
+			for _, album := range albums {
+				if album.AlbumName == params.AlbumName {
+					albumID = album.ID
+					albumName = album.AlbumName
+					break
+				}
+			}
+
+			if albumID == "" {
+				return nil, fmt.Errorf("album '%s' not found", params.AlbumName)
+			}
+		} else {
+			return nil, fmt.Errorf("either albumName or albumId must be provided")
+		}
+
+		assets, err := immichClient.GetAlbumAssets(ctx, albumID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get album assets: %w", err)
+		}
+
+		// Skip assets that are already in the desired state
+		targetArchived := !params.Unhide
+		toUpdate := []immich.Asset{}
+		for _, asset := range assets {
+			if asset.IsArchived != targetArchived {
+				toUpdate = append(toUpdate, asset)
+			}
+		}
+
+		verb := "hide"
+		if params.Unhide {
+			verb = "unhide"
+		}
+
+		result := map[string]interface{}{
+			"albumID":        albumID,
+			"albumName":      albumName,
+			"totalAssets":    len(assets),
+			"alreadyInState": len(assets) - len(toUpdate),
+		}
+
+		if params.DryRun {
+			result["success"] = true
+			result["dryRun"] = true
+			result["eta"] = estimateBulkETA(immichClient, len(toUpdate))
+			result["message"] = fmt.Sprintf("Dry run: would %s %d assets in album", verb, len(toUpdate))
+			return makeMCPResult(result)
+		}
+
+		updated := 0
+		failed := 0
+		var errors []string
+		tombstones := newTombstoneTracker()
+
+		for _, asset := range toUpdate {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			err := immichClient.UpdateAssetMetadata(ctx, asset.ID, map[string]interface{}{
+				"isArchived": targetArchived,
+			})
+			if err != nil {
+				// Assets trashed mid-operation are tombstoned, not failures
+				if tombstones.Note(asset.ID, err) {
+					continue
+				}
+				failed++
+				errors = append(errors, fmt.Sprintf("%s: %v", asset.ID, err))
+				continue
+			}
+			updated++
+		}
+
+		result["success"] = failed == 0
+		result["updated"] = updated
+		result["failed"] = failed
+		result["message"] = fmt.Sprintf("%sd %d assets in album '%s'", verb, updated, albumName)
+		if len(errors) > 0 {
+			if len(errors) > 10 {
+				errors = errors[:10]
+			}
+			result["errors"] = errors
+		}
+		tombstones.Report(result)
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
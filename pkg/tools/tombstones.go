@@ -0,0 +1,45 @@
+package tools
+
+import (
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// tombstoneTracker records asset IDs found to be deleted (404/410) while a
+// bulk operation is running, so they can be excluded from retries and
+// reported separately instead of surfacing as confusing failures.
+type tombstoneTracker struct {
+	ids  []string
+	seen map[string]bool
+}
+
+func newTombstoneTracker() *tombstoneTracker {
+	return &tombstoneTracker{seen: make(map[string]bool)}
+}
+
+// Note inspects an error from a per-asset operation; if it indicates the
+// asset no longer exists, the ID is tombstoned and true is returned so the
+// caller can skip failure accounting for it.
+func (t *tombstoneTracker) Note(assetID string, err error) bool {
+	if !immich.IsNotFound(err) {
+		return false
+	}
+	if !t.seen[assetID] {
+		t.seen[assetID] = true
+		t.ids = append(t.ids, assetID)
+	}
+	return true
+}
+
+// Contains reports whether an asset was already tombstoned in this operation.
+func (t *tombstoneTracker) Contains(assetID string) bool {
+	return t.seen[assetID]
+}
+
+// Report attaches the tombstoned IDs to a result map when any were seen.
+func (t *tombstoneTracker) Report(result map[string]interface{}) {
+	if len(t.ids) == 0 {
+		return
+	}
+	result["deletedDuringOperation"] = len(t.ids)
+	result["deletedAssetIds"] = t.ids
+}
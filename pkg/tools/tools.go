@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -12,7 +14,9 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/patrickmn/go-cache"
+	"github.com/yourusername/mcp-immich/pkg/bulkops"
 	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/undo"
 )
 
 // RegisterTools registers all tools with the MCP server
@@ -31,27 +35,62 @@ func RegisterTools(s *server.MCPServer, immichClient *immich.Client, cacheStore
 	registerGetAllAlbums(s, immichClient, cacheStore)
 	registerCreateAlbum(s, immichClient)
 	registerMoveToAlbum(s, immichClient)
+	registerCreateAlbumsFromCalendar(s, immichClient)
+	registerExportSlideshowManifest(s, immichClient)
+	registerGetMembershipMatrix(s, immichClient)
+	registerGetAlbumGrowth(s, immichClient)
+	registerSuggestAlbumsForAssets(s, immichClient)
+	registerMirrorFolderStructureToAlbums(s, immichClient)
+
+	// People tools
+	registerListPeople(s, immichClient)
+	registerRenamePerson(s, immichClient)
+	registerSetPersonBirthDate(s, immichClient)
+	registerMergePeople(s, immichClient)
+	registerGetPersonTimeline(s, immichClient)
 
 	// Library tools
 	registerListLibraries(s, immichClient, cacheStore)
 	registerMoveToLibrary(s, immichClient)
 
 	// Maintenance tools
+	registerOrganizeLibrary(s, immichClient, cacheStore)
 	registerFindBrokenFiles(s, immichClient)
 	registerRepairAssets(s, immichClient)
-	registerMoveBrokenThumbnailsToAlbum(s, immichClient)
-	registerMoveSmallImagesToAlbum(s, immichClient)
-	registerMoveLargeMoviesToAlbum(s, immichClient)
+	registerMoveBrokenThumbnailsToAlbum(s, immichClient, cacheStore)
+	registerMoveSmallImagesToAlbum(s, immichClient, cacheStore)
+	registerMoveLargeMoviesToAlbum(s, immichClient, cacheStore)
+	registerGetMaintenanceTrends(s, cacheStore)
+	registerFindDuplicates(s, immichClient)
+	registerResolveDuplicates(s, immichClient)
+	registerFindCrossAlbumDuplicates(s, immichClient)
 	registerMovePersonalVideosFromAlbum(s, immichClient)
 	registerMovePhotosBySearch(s, immichClient)
 	registerSmartSearchAdvanced(s, immichClient)
 	registerDeleteAlbumContents(s, immichClient)
 
 	// Asset management tools
+	registerShiftAssetDates(s, immichClient, cacheStore)
+	registerHideAlbumFromTimeline(s, immichClient)
+	registerNormalizeAlbumOrder(s, immichClient)
+	registerLockAssets(s, immichClient)
 	registerUpdateAssetMetadata(s, immichClient)
 	registerAnalyzePhotos(s, immichClient)
+	registerGetJobStatus(s, immichClient)
+	registerMaterializeSmartTags(s, immichClient)
+	registerGenerateAssetDescriptions(s, immichClient)
 	registerExportPhotos(s, immichClient)
+	registerGetExportStatus(s)
+	registerImportFavorites(s, immichClient)
 	registerGetAllAssets(s, immichClient, cacheStore)
+
+	// Library insight tools
+	registerFindTimelineGaps(s, immichClient)
+	registerListDevices(s, immichClient)
+	registerCheckPermissions(s, immichClient)
+	registerAuditSharedExposure(s, immichClient)
+	registerScrubMetadataForSharing(s, immichClient)
+	registerReviewRecentUploads(s, immichClient, cacheStore)
 }
 
 // queryPhotos tool
@@ -68,27 +107,32 @@ func registerQueryPhotos(s *server.MCPServer, immichClient *immich.Client, cache
 				"albumId":   map[string]interface{}{"type": "string"},
 				"type":      map[string]interface{}{"type": "string", "enum": []string{"IMAGE", "VIDEO", "ALL"}},
 				"limit":     map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 1000, "default": 100},
+				"asResourceLinks": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Return matches as MCP resource links instead of inline JSON (automatic for large result sets)",
+					"default":     false,
+				},
+				"aggregateOnly": aggregateOnlySchema(),
+				"verbosity":     verbositySchema(),
 			},
 		},
 	}
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		var params struct {
-			Query     string `json:"query"`
-			StartDate string `json:"startDate"`
-			EndDate   string `json:"endDate"`
-			AlbumID   string `json:"albumId"`
-			Type      string `json:"type"`
-			Limit     int    `json:"limit"`
-		}
-
-		argBytes, ok := request.Params.Arguments.([]byte)
-		if !ok {
-			// Try to marshal if it's already a structured type
-			argBytes, _ = json.Marshal(request.Params.Arguments)
-		}
-		if err := json.Unmarshal(argBytes, &params); err != nil {
-			return nil, fmt.Errorf("invalid parameters: %w", err)
+			Query           string `json:"query"`
+			StartDate       string `json:"startDate"`
+			EndDate         string `json:"endDate"`
+			AlbumID         string `json:"albumId"`
+			Type            string `json:"type"`
+			Limit           int    `json:"limit"`
+			AsResourceLinks bool   `json:"asResourceLinks"`
+			AggregateOnly   bool   `json:"aggregateOnly"`
+			Verbosity       string `json:"verbosity"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
 		}
 
 		// Set defaults
@@ -98,32 +142,45 @@ func registerQueryPhotos(s *server.MCPServer, immichClient *immich.Client, cache
 
 		// Check cache
 		cacheKey := fmt.Sprintf("%v", request.Params.Arguments)
+		var results *immich.PhotoResults
 		if cached, found := cacheStore.Get(cacheKey); found {
-			return makeMCPResult(cached)
-		}
+			results, _ = cached.(*immich.PhotoResults)
+		}
+		if results == nil {
+			// Query Immich
+			var err error
+			results, err = immichClient.QueryPhotos(ctx, immich.QueryPhotosParams{
+				Query:     params.Query,
+				StartDate: params.StartDate,
+				EndDate:   params.EndDate,
+				AlbumID:   params.AlbumID,
+				Type:      params.Type,
+				Limit:     params.Limit,
+			})
 
-		// Query Immich
-		results, err := immichClient.QueryPhotos(ctx, immich.QueryPhotosParams{
-			Query:     params.Query,
-			StartDate: params.StartDate,
-			EndDate:   params.EndDate,
-			AlbumID:   params.AlbumID,
-			Type:      params.Type,
-			Limit:     params.Limit,
-		})
+			if err != nil {
+				return nil, err
+			}
 
-		if err != nil {
-			return nil, err
+			// Cache results
+			cacheStore.Set(cacheKey, results, cache.DefaultExpiration)
 		}
 
-		// Cache results
-		cacheStore.Set(cacheKey, results, cache.DefaultExpiration)
+		if params.AggregateOnly {
+			return makeMCPResult(map[string]interface{}{
+				"success":       true,
+				"totalCount":    results.Total,
+				"aggregateOnly": true,
+				"aggregate":     aggregateAssets(results.Photos),
+			})
+		}
 
-		return makeMCPResult(map[string]interface{}{
+		photos := trimAssetsForVerbosity(results.Photos, resolveVerbosity(params.Verbosity))
+
+		return makeAssetListResult(map[string]interface{}{
 			"success":    true,
 			"totalCount": results.Total,
-			"photos":     results.Photos,
-		})
+		}, "photos", photos, params.AsResourceLinks)
 	}
 
 	s.AddTool(tool, handler)
@@ -137,15 +194,15 @@ func registerQueryPhotosWithBuckets(s *server.MCPServer, immichClient *immich.Cl
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"bucketSize":  map[string]interface{}{"type": "string", "enum": []string{"day", "month", "year"}},
-				"startDate":   map[string]interface{}{"type": "string", "format": "date-time"},
-				"endDate":     map[string]interface{}{"type": "string", "format": "date-time"},
-				"albumId":     map[string]interface{}{"type": "string"},
-				"personId":    map[string]interface{}{"type": "string"},
-				"isArchived":  map[string]interface{}{"type": "boolean"},
-				"isFavorite":  map[string]interface{}{"type": "boolean"},
-				"withAssets":  map[string]interface{}{"type": "boolean"},
-				"maxBuckets":  map[string]interface{}{"type": "integer"},
+				"bucketSize": map[string]interface{}{"type": "string", "enum": []string{"day", "month", "year"}},
+				"startDate":  map[string]interface{}{"type": "string", "format": "date-time"},
+				"endDate":    map[string]interface{}{"type": "string", "format": "date-time"},
+				"albumId":    map[string]interface{}{"type": "string"},
+				"personId":   map[string]interface{}{"type": "string"},
+				"isArchived": map[string]interface{}{"type": "boolean"},
+				"isFavorite": map[string]interface{}{"type": "boolean"},
+				"withAssets": map[string]interface{}{"type": "boolean"},
+				"maxBuckets": map[string]interface{}{"type": "integer"},
 			},
 		},
 	}
@@ -161,13 +218,8 @@ func registerQueryPhotosWithBuckets(s *server.MCPServer, immichClient *immich.Cl
 			MaxBuckets int    `json:"maxBuckets"`
 		}
 
-		argBytes, ok := request.Params.Arguments.([]byte)
-		if !ok {
-			// Try to marshal if it's already a structured type
-			argBytes, _ = json.Marshal(request.Params.Arguments)
-		}
-		if err := json.Unmarshal(argBytes, &params); err != nil {
-			return nil, fmt.Errorf("invalid parameters: %w", err)
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
 		}
 
 		// Get buckets
@@ -229,16 +281,19 @@ func registerGetPhotoMetadata(s *server.MCPServer, immichClient *immich.Client,
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		var params struct {
-			PhotoID string `json:"photoId"`
+			PhotoID       string `json:"photoId"`
+			IncludeExif   bool   `json:"includeExif"`
+			IncludeFaces  bool   `json:"includeFaces"`
+			IncludeAlbums bool   `json:"includeAlbums"`
 		}
 
-		argBytes, ok := request.Params.Arguments.([]byte)
-		if !ok {
-			// Try to marshal if it's already a structured type
-			argBytes, _ = json.Marshal(request.Params.Arguments)
-		}
-		if err := json.Unmarshal(argBytes, &params); err != nil {
-			return nil, fmt.Errorf("invalid parameters: %w", err)
+		// Set defaults (explicit false in the request overrides these)
+		params.IncludeExif = true
+		params.IncludeFaces = true
+		params.IncludeAlbums = true
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
 		}
 
 		asset, err := immichClient.GetAssetMetadata(ctx, params.PhotoID)
@@ -246,64 +301,550 @@ func registerGetPhotoMetadata(s *server.MCPServer, immichClient *immich.Client,
 			return nil, err
 		}
 
-		return makeMCPResult(map[string]interface{}{
+		// Strip sections the caller did not ask for to keep the payload small
+		if !params.IncludeExif {
+			asset.ExifInfo = nil
+		}
+		if !params.IncludeFaces {
+			asset.People = nil
+		}
+
+		result := map[string]interface{}{
 			"success": true,
 			"photo":   asset,
-		})
+		}
+
+		// Face regions come from the faces endpoint, which carries bounding
+		// boxes and person assignments the asset payload may omit
+		if params.IncludeFaces {
+			faces, err := immichClient.GetAssetFaces(ctx, params.PhotoID)
+			if err != nil {
+				result["facesError"] = err.Error()
+			} else {
+				result["faces"] = faces
+				result["faceCount"] = len(faces)
+			}
+		}
+
+		// Containing albums require an extra lookup
+		if params.IncludeAlbums {
+			albums, err := immichClient.GetAlbumsForAsset(ctx, params.PhotoID)
+			if err != nil {
+				result["albumsError"] = err.Error()
+			} else {
+				type albumRef struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				}
+				refs := make([]albumRef, 0, len(albums))
+				for _, album := range albums {
+					refs = append(refs, albumRef{ID: album.ID, Name: album.AlbumName})
+				}
+				result["albums"] = refs
+			}
+		}
+
+		return makeMCPResult(result)
 	}
 
 	s.AddTool(tool, handler)
 }
 
-// Stub implementations for remaining tools
+// searchByFace tool
 func registerSearchByFace(s *server.MCPServer, immichClient *immich.Client) {
-	// Implementation similar to above
+	tool := mcp.Tool{
+		Name:        "searchByFace",
+		Description: "Search for photos containing a specific person's face, optionally restricted to a date range or album",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"personId": map[string]interface{}{
+					"type":        "string",
+					"description": "Person ID (from listPeople)",
+				},
+				"personName": map[string]interface{}{
+					"type":        "string",
+					"description": "Person name (alternative to personId; must match a named person)",
+				},
+				"minConfidence": map[string]interface{}{
+					"type":        "number",
+					"description": "Minimum face recognition confidence (0-1)",
+					"default":     0,
+				},
+				"startDate": map[string]interface{}{"type": "string", "format": "date-time"},
+				"endDate":   map[string]interface{}{"type": "string", "format": "date-time"},
+				"albumId": map[string]interface{}{
+					"type":        "string",
+					"description": "Only matches that are also in this album",
+				},
+				"limit":  map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 1000, "default": 100},
+				"offset": map[string]interface{}{"type": "integer", "minimum": 0, "default": 0},
+				"asResourceLinks": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Return matches as MCP resource links instead of inline JSON (automatic for large result sets)",
+					"default":     false,
+				},
+				"verbosity": verbositySchema(),
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			PersonID        string  `json:"personId"`
+			PersonName      string  `json:"personName"`
+			MinConfidence   float64 `json:"minConfidence"`
+			StartDate       string  `json:"startDate"`
+			EndDate         string  `json:"endDate"`
+			AlbumID         string  `json:"albumId"`
+			Limit           int     `json:"limit"`
+			Offset          int     `json:"offset"`
+			AsResourceLinks bool    `json:"asResourceLinks"`
+			Verbosity       string  `json:"verbosity"`
+		}
+
+		// Set defaults
+		params.Limit = 100
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if params.Limit <= 0 {
+			params.Limit = 100
+		}
+
+		startDate, endDate, err := parseFaceSearchRange(params.StartDate, params.EndDate)
+		if err != nil {
+			return nil, err
+		}
+
+		person, candidates, err := resolvePerson(ctx, immichClient, params.PersonID, params.PersonName)
+		if err != nil {
+			return nil, err
+		}
+		if person == nil {
+			return makeMCPResult(map[string]interface{}{
+				"success":    false,
+				"candidates": candidates,
+				"message":    fmt.Sprintf("No person named '%s'; did you mean one of the candidates?", params.PersonName),
+			})
+		}
+
+		results, err := immichClient.SearchByFace(ctx, immich.FaceSearchParams{
+			PersonID:      person.ID,
+			MinConfidence: params.MinConfidence,
+			Limit:         params.Limit + params.Offset,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("face search failed: %w", err)
+		}
+
+		// Album restriction is applied here; the person-assets endpoint has
+		// no album filter of its own
+		var inAlbum map[string]bool
+		if params.AlbumID != "" {
+			albumAssets, err := immichClient.GetAlbumAssets(ctx, params.AlbumID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get album assets: %w", err)
+			}
+			inAlbum = make(map[string]bool, len(albumAssets))
+			for _, asset := range albumAssets {
+				inAlbum[asset.ID] = true
+			}
+		}
+
+		matched := make([]immich.Asset, 0, len(results.Photos))
+		for _, asset := range results.Photos {
+			if inAlbum != nil && !inAlbum[asset.ID] {
+				continue
+			}
+			taken := assetCaptureTime(asset)
+			if !startDate.IsZero() && (taken.IsZero() || taken.Before(startDate)) {
+				continue
+			}
+			if !endDate.IsZero() && (taken.IsZero() || taken.After(endDate)) {
+				continue
+			}
+			matched = append(matched, asset)
+		}
+
+		total := len(matched)
+		page := matched
+		if params.Offset > 0 {
+			if params.Offset >= len(page) {
+				page = nil
+			} else {
+				page = page[params.Offset:]
+			}
+		}
+		if len(page) > params.Limit {
+			page = page[:params.Limit]
+		}
+
+		photos := trimAssetsForVerbosity(page, resolveVerbosity(params.Verbosity))
+
+		return makeAssetListResult(map[string]interface{}{
+			"success":    true,
+			"personId":   person.ID,
+			"personName": person.Name,
+			"totalCount": total,
+			"offset":     params.Offset,
+		}, "photos", photos, params.AsResourceLinks)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// parseFaceSearchRange parses the optional date bounds, accepting RFC 3339
+// timestamps or bare dates
+func parseFaceSearchRange(start, end string) (time.Time, time.Time, error) {
+	parse := func(value, label string) (time.Time, error) {
+		if value == "" {
+			return time.Time{}, nil
+		}
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid %s: %s (use RFC 3339 or YYYY-MM-DD)", label, value)
+		}
+		return t, nil
+	}
+	startDate, err := parse(start, "startDate")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	endDate, err := parse(end, "endDate")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return startDate, endDate, nil
 }
 
+// searchByLocation tool
 func registerSearchByLocation(s *server.MCPServer, immichClient *immich.Client) {
-	// Implementation similar to above
+	tool := mcp.Tool{
+		Name:        "searchByLocation",
+		Description: "Search for photos taken near coordinates or in a named city/state/country, annotating each match with its distance from the search center",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"latitude":  map[string]interface{}{"type": "number", "minimum": -90, "maximum": 90},
+				"longitude": map[string]interface{}{"type": "number", "minimum": -180, "maximum": 180},
+				"radius": map[string]interface{}{
+					"type":        "number",
+					"description": "Search radius in kilometers around the coordinates",
+					"default":     10,
+				},
+				"city":    map[string]interface{}{"type": "string", "description": "City name (alternative to coordinates)"},
+				"state":   map[string]interface{}{"type": "string", "description": "State or region name"},
+				"country": map[string]interface{}{"type": "string", "description": "Country name"},
+				"limit":   map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 1000, "default": 100},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Latitude  *float64 `json:"latitude"`
+			Longitude *float64 `json:"longitude"`
+			Radius    float64  `json:"radius"`
+			City      string   `json:"city"`
+			State     string   `json:"state"`
+			Country   string   `json:"country"`
+			Limit     int      `json:"limit"`
+		}
+
+		// Set defaults
+		params.Radius = 10
+		params.Limit = 100
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if params.Limit <= 0 {
+			params.Limit = 100
+		}
+
+		byCoordinates := params.Latitude != nil && params.Longitude != nil
+		byName := params.City != "" || params.State != "" || params.Country != ""
+		if !byCoordinates && !byName {
+			return nil, fmt.Errorf("either latitude/longitude or a city/state/country name must be provided")
+		}
+		if (params.Latitude == nil) != (params.Longitude == nil) {
+			return nil, fmt.Errorf("latitude and longitude must be set together")
+		}
+
+		var assets []immich.Asset
+		if byCoordinates {
+			results, err := immichClient.SearchByLocation(ctx, immich.LocationSearchParams{
+				Latitude:  *params.Latitude,
+				Longitude: *params.Longitude,
+				Radius:    params.Radius,
+				Limit:     params.Limit,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("location search failed: %w", err)
+			}
+			assets = results.Photos
+		} else {
+			// Named places go through smart search, which matches the EXIF
+			// reverse-geocoding fields
+			withExif := true
+			assets, err := immichClient.SmartSearchAdvanced(ctx, immich.SmartSearchParams{
+				City:     params.City,
+				State:    params.State,
+				Country:  params.Country,
+				WithExif: &withExif,
+				Size:     params.Limit,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("place search failed: %w", err)
+			}
+			return makeLocationSearchResult(assets, params.Latitude, params.Longitude, placeLabel(params.City, params.State, params.Country))
+		}
+
+		return makeLocationSearchResult(assets, params.Latitude, params.Longitude,
+			fmt.Sprintf("%.4f,%.4f (%.1f km)", *params.Latitude, *params.Longitude, params.Radius))
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// makeLocationSearchResult annotates each match with its distance from the
+// search center when one is known
+func makeLocationSearchResult(assets []immich.Asset, latitude, longitude *float64, where string) (*mcp.CallToolResult, error) {
+	entries := make([]map[string]interface{}, 0, len(assets))
+	for _, asset := range assets {
+		entry := map[string]interface{}{
+			"id":       asset.ID,
+			"fileName": asset.OriginalFileName,
+			"type":     asset.Type,
+		}
+		if taken := assetCaptureTime(asset); !taken.IsZero() {
+			entry["takenAt"] = taken.Format(time.RFC3339)
+		}
+		if asset.ExifInfo != nil {
+			if asset.ExifInfo.City != "" {
+				entry["city"] = asset.ExifInfo.City
+			}
+			if asset.ExifInfo.Country != "" {
+				entry["country"] = asset.ExifInfo.Country
+			}
+			if asset.ExifInfo.Latitude != nil && asset.ExifInfo.Longitude != nil {
+				entry["latitude"] = *asset.ExifInfo.Latitude
+				entry["longitude"] = *asset.ExifInfo.Longitude
+				if latitude != nil && longitude != nil {
+					entry["distanceKm"] = roundDistance(haversineKm(*latitude, *longitude, *asset.ExifInfo.Latitude, *asset.ExifInfo.Longitude))
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	// Nearest first when distances are known
+	if latitude != nil && longitude != nil {
+		sort.SliceStable(entries, func(i, j int) bool {
+			di, iok := entries[i]["distanceKm"].(float64)
+			dj, jok := entries[j]["distanceKm"].(float64)
+			if iok != jok {
+				return iok
+			}
+			return di < dj
+		})
+	}
+
+	return makeMCPResult(map[string]interface{}{
+		"success": true,
+		"count":   len(entries),
+		"photos":  entries,
+		"message": fmt.Sprintf("Found %d assets near %s", len(entries), where),
+	})
+}
+
+// haversineKm is the great-circle distance between two coordinates
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusKm = 6371.0
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// roundDistance keeps distance annotations readable (10 m precision)
+func roundDistance(km float64) float64 {
+	return math.Round(km*100) / 100
+}
+
+// placeLabel renders the named-place filters for the result message
+func placeLabel(city, state, country string) string {
+	parts := []string{}
+	for _, part := range []string{city, state, country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
 }
 
 func registerListAlbums(s *server.MCPServer, immichClient *immich.Client, cacheStore *cache.Cache) {
 	tool := mcp.Tool{
 		Name:        "listAlbums",
-		Description: "List all albums (basic info only)",
+		Description: "List albums (basic info only) with optional sorting, filtering, and pagination so agents don't have to post-process the full list",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"shared": map[string]interface{}{"type": "boolean", "default": false},
+				"shared": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Only albums shared with other users",
+					"default":     false,
+				},
+				"nameContains": map[string]interface{}{
+					"type":        "string",
+					"description": "Only albums whose name contains this substring (case-insensitive)",
+				},
+				"minAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Only albums with at least this many assets",
+					"default":     0,
+				},
+				"emptyOnly": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Only albums with no assets",
+					"default":     false,
+				},
+				"sortBy": map[string]interface{}{
+					"type":        "string",
+					"description": "Sort field: name, assetCount, or updatedAt",
+					"enum":        []string{"name", "assetCount", "updatedAt"},
+				},
+				"sortOrder": map[string]interface{}{
+					"type":        "string",
+					"description": "Sort direction",
+					"enum":        []string{"asc", "desc"},
+					"default":     "asc",
+				},
+				"offset": map[string]interface{}{
+					"type":        "integer",
+					"description": "Skip this many albums after filtering and sorting",
+					"default":     0,
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Return at most this many albums (0 for all)",
+					"default":     0,
+				},
 			},
 		},
 	}
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		var params struct {
-			Shared bool `json:"shared"`
+			Shared       bool   `json:"shared"`
+			NameContains string `json:"nameContains"`
+			MinAssets    int    `json:"minAssets"`
+			EmptyOnly    bool   `json:"emptyOnly"`
+			SortBy       string `json:"sortBy"`
+			SortOrder    string `json:"sortOrder"`
+			Offset       int    `json:"offset"`
+			Limit        int    `json:"limit"`
 		}
 
-		argBytes, ok := request.Params.Arguments.([]byte)
-		if !ok {
-			argBytes, _ = json.Marshal(request.Params.Arguments)
+		// Set defaults
+		params.SortOrder = "asc"
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
 		}
-		if err := json.Unmarshal(argBytes, &params); err != nil {
-			return nil, fmt.Errorf("invalid parameters: %w", err)
+
+		switch params.SortBy {
+		case "", "name", "assetCount", "updatedAt":
+		default:
+			return nil, fmt.Errorf("invalid sortBy: %s (must be name, assetCount, or updatedAt)", params.SortBy)
 		}
 
+		// The shared filter is pushed down to Immich; the rest are applied
+		// here so the agent gets a pre-trimmed listing
 		albums, err := immichClient.ListAlbums(ctx, params.Shared)
 		if err != nil {
 			return nil, err
 		}
 
+		filtered := make([]immich.Album, 0, len(albums))
+		nameFilter := strings.ToLower(params.NameContains)
+		for _, album := range albums {
+			if nameFilter != "" && !strings.Contains(strings.ToLower(album.AlbumName), nameFilter) {
+				continue
+			}
+			if album.AssetCount < params.MinAssets {
+				continue
+			}
+			if params.EmptyOnly && album.AssetCount > 0 {
+				continue
+			}
+			filtered = append(filtered, album)
+		}
+
+		if params.SortBy != "" {
+			descending := params.SortOrder == "desc"
+			sort.SliceStable(filtered, func(i, j int) bool {
+				a, b := filtered[i], filtered[j]
+				if descending {
+					a, b = b, a
+				}
+				switch params.SortBy {
+				case "assetCount":
+					return a.AssetCount < b.AssetCount
+				case "updatedAt":
+					return a.UpdatedAt.Before(b.UpdatedAt)
+				default:
+					return strings.ToLower(a.AlbumName) < strings.ToLower(b.AlbumName)
+				}
+			})
+		}
+
+		totalMatched := len(filtered)
+		if params.Offset > 0 {
+			if params.Offset >= len(filtered) {
+				filtered = filtered[:0]
+			} else {
+				filtered = filtered[params.Offset:]
+			}
+		}
+		if params.Limit > 0 && len(filtered) > params.Limit {
+			filtered = filtered[:params.Limit]
+		}
+
 		return makeMCPResult(map[string]interface{}{
-			"success": true,
-			"albums":  albums,
-			"count":   len(albums),
+			"success":      true,
+			"albums":       filtered,
+			"count":        len(filtered),
+			"totalMatched": totalMatched,
+			"totalAlbums":  len(albums),
 		})
 	}
 
 	s.AddTool(tool, handler)
 }
 
+// Album listings are served straight from cache within the freshness
+// window, then revalidated with a conditional request: an unchanged
+// listing just refreshes the window instead of being re-decoded.
+const (
+	albumsCacheFreshFor = 1 * time.Minute
+	albumsCacheMaxAge   = 10 * time.Minute
+)
+
+// albumsCacheEntry pairs the cached getAllAlbums result with when it was
+// last validated against the server
+type albumsCacheEntry struct {
+	result      map[string]interface{}
+	validatedAt time.Time
+}
+
 func registerGetAllAlbums(s *server.MCPServer, immichClient *immich.Client, cacheStore *cache.Cache) {
 	tool := mcp.Tool{
 		Name:        "getAllAlbums",
@@ -315,53 +856,151 @@ func registerGetAllAlbums(s *server.MCPServer, immichClient *immich.Client, cach
 	}
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Use cache for this potentially expensive operation
 		cacheKey := "getAllAlbums"
 		if cached, found := cacheStore.Get(cacheKey); found {
-			return makeMCPResult(cached)
+			if entry, ok := cached.(albumsCacheEntry); ok {
+				if time.Since(entry.validatedAt) < albumsCacheFreshFor {
+					return makeMCPResult(entry.result)
+				}
+
+				// Past the freshness window: revalidate instead of refetching
+				albums, notModified, err := immichClient.GetAllAlbumsConditional(ctx)
+				if err != nil {
+					return nil, err
+				}
+				if notModified {
+					entry.validatedAt = time.Now()
+					cacheStore.Set(cacheKey, entry, albumsCacheMaxAge)
+					return makeMCPResult(entry.result)
+				}
+				return makeMCPResult(cacheAlbumsResult(cacheStore, cacheKey, albums))
+			}
 		}
 
-		albums, err := immichClient.GetAllAlbumsWithInfo(ctx)
+		albums, _, err := immichClient.GetAllAlbumsConditional(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		result := map[string]interface{}{
-			"success":     true,
-			"albums":      albums,
-			"totalAlbums": len(albums),
-		}
-
-		// Cache for 1 minute
-		cacheStore.Set(cacheKey, result, 1*time.Minute)
-
-		return makeMCPResult(result)
+		return makeMCPResult(cacheAlbumsResult(cacheStore, cacheKey, albums))
 	}
 
 	s.AddTool(tool, handler)
 }
 
-func registerCreateAlbum(s *server.MCPServer, immichClient *immich.Client) {
-	// Implementation similar to above
+// cacheAlbumsResult builds the getAllAlbums result and caches it with a
+// fresh validation timestamp
+func cacheAlbumsResult(cacheStore *cache.Cache, cacheKey string, albums []immich.Album) map[string]interface{} {
+	result := map[string]interface{}{
+		"success":     true,
+		"albums":      albums,
+		"totalAlbums": len(albums),
+	}
+	cacheStore.Set(cacheKey, albumsCacheEntry{result: result, validatedAt: time.Now()}, albumsCacheMaxAge)
+	return result
 }
 
-func registerMoveToAlbum(s *server.MCPServer, immichClient *immich.Client) {
+// createAlbum tool
+func registerCreateAlbum(s *server.MCPServer, immichClient *immich.Client) {
 	tool := mcp.Tool{
-		Name:        "moveAssetsToAlbum",
-		Description: "Move specified assets to an album",
+		Name:        "createAlbum",
+		Description: "Create a new album, optionally seeding it with assets, sharing it with other users, and setting its display order",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"assetIds": map[string]interface{}{
-					"type":        "array",
-					"description": "List of asset IDs to move",
-					"items": map[string]interface{}{
-						"type": "string",
-					},
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Album name",
 				},
-				"albumName": map[string]interface{}{
+				"description": map[string]interface{}{
 					"type":        "string",
-					"description": "Name of the album to move assets to",
+					"description": "Album description",
+				},
+				"assetIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Assets to add on creation",
+				},
+				"sharedUserIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "User IDs to share the album with (editor role)",
+				},
+				"order": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"asc", "desc"},
+					"description": "Display order by capture time (Immich's default is desc)",
+				},
+			},
+			Required: []string{"name"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Name          string   `json:"name"`
+			Description   string   `json:"description"`
+			AssetIDs      []string `json:"assetIds"`
+			SharedUserIDs []string `json:"sharedUserIds"`
+			Order         string   `json:"order"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if params.Name == "" {
+			return nil, fmt.Errorf("name is required")
+		}
+		if params.Order != "" && params.Order != "asc" && params.Order != "desc" {
+			return nil, fmt.Errorf("invalid order: %s (must be asc or desc)", params.Order)
+		}
+
+		album, err := immichClient.CreateAlbum(ctx, immich.CreateAlbumParams{
+			Name:          params.Name,
+			Description:   params.Description,
+			AssetIDs:      params.AssetIDs,
+			SharedUserIDs: params.SharedUserIDs,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create album: %w", err)
+		}
+
+		// Ordering is a separate update; creation has no order field
+		if params.Order != "" {
+			updated, err := immichClient.UpdateAlbum(ctx, album.ID, map[string]interface{}{"order": params.Order})
+			if err != nil {
+				return nil, fmt.Errorf("album created but setting order failed: %w", err)
+			}
+			album = updated
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success": true,
+			"album":   album,
+			"message": fmt.Sprintf("Created album '%s' with %d assets", album.AlbumName, len(params.AssetIDs)),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+func registerMoveToAlbum(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "moveAssetsToAlbum",
+		Description: "Move specified assets to an album",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"assetIds": map[string]interface{}{
+					"type":        "array",
+					"description": "List of asset IDs to move",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+				"albumName": map[string]interface{}{
+					"type":        "string",
+					"description": "Name of the album to move assets to",
 				},
 				"createAlbum": map[string]interface{}{
 					"type":        "boolean",
@@ -374,116 +1013,558 @@ func registerMoveToAlbum(s *server.MCPServer, immichClient *immich.Client) {
 					"default":     "",
 				},
 			},
-			Required: []string{"assetIds", "albumName"},
+			Required: []string{"assetIds", "albumName"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AssetIds         []string `json:"assetIds"`
+			AlbumName        string   `json:"albumName"`
+			CreateAlbum      bool     `json:"createAlbum"`
+			AlbumDescription string   `json:"albumDescription"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if len(params.AssetIds) == 0 {
+			return makeMCPResult(map[string]interface{}{
+				"success": false,
+				"message": "No asset IDs provided",
+			})
+		}
+
+		// Find existing album
+		var albumID string
+		var albumFound bool
+		albums, err := immichClient.ListAlbums(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list albums: %w", err)
+		}
+
+		for _, album := range albums {
+			if album.AlbumName == params.AlbumName {
+				albumID = album.ID
+				albumFound = true
+				break
+			}
+		}
+
+		// Create album if needed
+		if !albumFound {
+			if !params.CreateAlbum {
+				return nil, fmt.Errorf("album '%s' not found and createAlbum is false", params.AlbumName)
+			}
+
+			newAlbum, err := immichClient.CreateAlbum(ctx, immich.CreateAlbumParams{
+				Name:        params.AlbumName,
+				Description: params.AlbumDescription,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create album: %w", err)
+			}
+			albumID = newAlbum.ID
+		}
+
+		if err := checkAlbumLock(albumID, params.AlbumName); err != nil {
+			return nil, err
+		}
+
+		// Add assets to album
+		bulkResult, err := immichClient.AddAssetsToAlbum(ctx, albumID, params.AssetIds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add assets to album: %w", err)
+		}
+		if len(bulkResult.Success) > 0 {
+			recordUndo(undo.Operation{
+				Tool:     "moveAssetsToAlbum",
+				Kind:     undo.KindAlbumAdd,
+				AlbumID:  albumID,
+				AssetIDs: bulkResult.Success,
+			})
+		}
+
+		result := map[string]interface{}{
+			"success":      true,
+			"albumID":      albumID,
+			"albumName":    params.AlbumName,
+			"albumCreated": !albumFound,
+			"movedCount":   len(bulkResult.Success),
+			"failedCount":  len(bulkResult.Error),
+		}
+
+		if len(bulkResult.Error) > 0 {
+			result["failedAssets"] = bulkResult.Error
+		}
+
+		publishEvent("assets_moved", map[string]interface{}{
+			"albumName": params.AlbumName,
+			"albumId":   albumID,
+			"moved":     len(bulkResult.Success),
+			"failed":    len(bulkResult.Error),
+		})
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
+
+func registerListLibraries(s *server.MCPServer, immichClient *immich.Client, cacheStore *cache.Cache) {
+	registerPlannedTool(s, "listLibraries", "List all Immich libraries")
+}
+
+func registerMoveToLibrary(s *server.MCPServer, immichClient *immich.Client) {
+	registerPlannedTool(s, "moveToLibrary", "Move assets to another library")
+}
+
+// findBrokenFiles tool
+func registerFindBrokenFiles(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "findBrokenFiles",
+		Description: "Scan the library for broken assets: missing thumbnails, zero-size files, failed processing, or offline external files",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"checkType": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"all", "missing_thumbnail", "zero_size", "processing_failed", "offline"},
+					"description": "Which issue to look for; all checks every kind",
+					"default":     "all",
+				},
+				"libraryId": map[string]interface{}{
+					"type":        "string",
+					"description": "Only scan assets in this library",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Stop after this many broken assets (0 for no limit)",
+					"default":     100,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			CheckType string `json:"checkType"`
+			LibraryID string `json:"libraryId"`
+			Limit     int    `json:"limit"`
+		}
+
+		// Set defaults
+		params.CheckType = "all"
+		params.Limit = 100
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		switch params.CheckType {
+		case "all", "missing_thumbnail", "zero_size", "processing_failed", "offline":
+		default:
+			return nil, fmt.Errorf("invalid checkType: %s", params.CheckType)
+		}
+
+		checkType := params.CheckType
+		if checkType == "all" {
+			checkType = ""
+		}
+		broken, err := immichClient.FindBrokenAssets(ctx, checkType, params.LibraryID, params.Limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan for broken assets: %w", err)
+		}
+
+		byIssue := map[string]int{}
+		assetIDs := make([]string, 0, len(broken))
+		for _, asset := range broken {
+			byIssue[asset.IssueType]++
+			assetIDs = append(assetIDs, asset.ID)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":   true,
+			"checkType": params.CheckType,
+			"count":     len(broken),
+			"byIssue":   byIssue,
+			"assets":    broken,
+			"assetIds":  assetIDs,
+			"message":   fmt.Sprintf("Found %d broken assets; pass assetIds to repairAssets to queue repairs", len(broken)),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// repairAssets tool
+func registerRepairAssets(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "repairAssets",
+		Description: "Queue repair jobs for broken assets: regenerate thumbnails and previews or re-extract metadata; detects broken assets itself when no IDs are given",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"assetIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Assets to repair (from findBrokenFiles); omit to detect and repair automatically",
+				},
+				"checkType": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"all", "missing_thumbnail", "zero_size", "processing_failed", "offline"},
+					"description": "Which issue to detect when assetIds is omitted",
+					"default":     "all",
+				},
+				"maxAssets": map[string]interface{}{
+					"type":        "integer",
+					"description": "Cap on automatically detected assets",
+					"default":     500,
+				},
+				"regenerateThumbnails": map[string]interface{}{"type": "boolean", "default": true},
+				"regeneratePreviews":   map[string]interface{}{"type": "boolean", "default": false},
+				"reextractMetadata":    map[string]interface{}{"type": "boolean", "default": false},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AssetIDs             []string `json:"assetIds"`
+			CheckType            string   `json:"checkType"`
+			MaxAssets            int      `json:"maxAssets"`
+			RegenerateThumbnails *bool    `json:"regenerateThumbnails"`
+			RegeneratePreviews   bool     `json:"regeneratePreviews"`
+			ReextractMetadata    bool     `json:"reextractMetadata"`
+		}
+
+		// Set defaults
+		params.CheckType = "all"
+		params.MaxAssets = 500
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		regenerateThumbnails := params.RegenerateThumbnails == nil || *params.RegenerateThumbnails
+		if !regenerateThumbnails && !params.RegeneratePreviews && !params.ReextractMetadata {
+			return nil, fmt.Errorf("at least one repair action must be enabled")
+		}
+
+		assetIDs := params.AssetIDs
+		detected := false
+		if len(assetIDs) == 0 {
+			checkType := params.CheckType
+			if checkType == "all" {
+				checkType = ""
+			}
+			broken, err := immichClient.FindBrokenAssets(ctx, checkType, "", params.MaxAssets)
+			if err != nil {
+				return nil, fmt.Errorf("failed to scan for broken assets: %w", err)
+			}
+			for _, asset := range broken {
+				assetIDs = append(assetIDs, asset.ID)
+			}
+			detected = true
+		}
+		if len(assetIDs) == 0 {
+			return makeMCPResult(map[string]interface{}{
+				"success": true,
+				"queued":  0,
+				"message": "No broken assets to repair",
+			})
+		}
+
+		repairResult, err := immichClient.RepairAssets(ctx, assetIDs, immich.RepairActions{
+			RegenerateThumbnails: regenerateThumbnails,
+			RegeneratePreviews:   params.RegeneratePreviews,
+			ReextractMetadata:    params.ReextractMetadata,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to queue repair jobs: %w", err)
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":  true,
+			"jobId":    repairResult.JobID,
+			"queued":   repairResult.Summary.Queued,
+			"total":    repairResult.Summary.Total,
+			"detected": detected,
+			"message":  fmt.Sprintf("Queued repair job %s for %d assets", repairResult.JobID, repairResult.Summary.Queued),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// updateAssetMetadata tool
+func registerUpdateAssetMetadata(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "updateAssetMetadata",
+		Description: "Update metadata fields on one or more assets: description, capture time, GPS coordinates, favorite/archive flags, and rating",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"assetIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Assets to update; the same fields are applied to all of them",
+				},
+				"fields": map[string]interface{}{
+					"type":        "object",
+					"description": "Fields to set: description, dateTimeOriginal (RFC 3339), latitude, longitude, isFavorite, isArchived, rating (-1 to 5)",
+				},
+			},
+			Required: []string{"assetIds", "fields"},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			AssetIDs []string               `json:"assetIds"`
+			Fields   map[string]interface{} `json:"fields"`
+		}
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+		if len(params.AssetIDs) == 0 {
+			return nil, fmt.Errorf("assetIds is required")
+		}
+		if len(params.Fields) == 0 {
+			return nil, fmt.Errorf("fields is required")
+		}
+
+		updates, err := validateMetadataFields(params.Fields)
+		if err != nil {
+			return nil, err
+		}
+
+		// Capture the values being overwritten so the change can be undone
+		previous := captureMetadataPrevious(ctx, immichClient, params.AssetIDs, updates)
+
+		// The bulk endpoint applies one set of updates to every ID, so a
+		// single call covers both the one-asset and many-asset cases
+		if err := immichClient.UpdateAssetsBulk(ctx, params.AssetIDs, updates); err != nil {
+			return nil, fmt.Errorf("failed to update assets: %w", err)
+		}
+		if len(previous) > 0 {
+			recordUndo(undo.Operation{
+				Tool:     "updateAssetMetadata",
+				Kind:     undo.KindMetadata,
+				AssetIDs: params.AssetIDs,
+				Previous: previous,
+			})
+		}
+
+		fieldNames := make([]string, 0, len(updates))
+		for name := range updates {
+			fieldNames = append(fieldNames, name)
+		}
+		sort.Strings(fieldNames)
+
+		return makeMCPResult(map[string]interface{}{
+			"success": true,
+			"updated": len(params.AssetIDs),
+			"fields":  fieldNames,
+			"message": fmt.Sprintf("Updated %s on %d assets", strings.Join(fieldNames, ", "), len(params.AssetIDs)),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// validateMetadataFields whitelists and type-checks the updatable asset
+// metadata fields, rejecting anything the API would silently drop
+func validateMetadataFields(fields map[string]interface{}) (map[string]interface{}, error) {
+	updates := map[string]interface{}{}
+	for name, value := range fields {
+		switch name {
+		case "description":
+			if _, ok := value.(string); !ok {
+				return nil, fmt.Errorf("description must be a string")
+			}
+		case "dateTimeOriginal":
+			text, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("dateTimeOriginal must be an RFC 3339 string")
+			}
+			if _, err := time.Parse(time.RFC3339, text); err != nil {
+				return nil, fmt.Errorf("invalid dateTimeOriginal: %s", text)
+			}
+		case "latitude":
+			deg, ok := toFloat(value)
+			if !ok || deg < -90 || deg > 90 {
+				return nil, fmt.Errorf("latitude must be a number between -90 and 90")
+			}
+		case "longitude":
+			deg, ok := toFloat(value)
+			if !ok || deg < -180 || deg > 180 {
+				return nil, fmt.Errorf("longitude must be a number between -180 and 180")
+			}
+		case "isFavorite", "isArchived":
+			if _, ok := value.(bool); !ok {
+				return nil, fmt.Errorf("%s must be a boolean", name)
+			}
+		case "rating":
+			rating, ok := toFloat(value)
+			if !ok || rating != math.Trunc(rating) || rating < -1 || rating > 5 {
+				return nil, fmt.Errorf("rating must be an integer between -1 and 5")
+			}
+		default:
+			return nil, fmt.Errorf("unknown field: %s (allowed: description, dateTimeOriginal, latitude, longitude, isFavorite, isArchived, rating)", name)
+		}
+		updates[name] = value
+	}
+	// GPS coordinates only make sense as a pair
+	_, hasLat := updates["latitude"]
+	_, hasLon := updates["longitude"]
+	if hasLat != hasLon {
+		return nil, fmt.Errorf("latitude and longitude must be set together")
+	}
+	return updates, nil
+}
+
+// toFloat accepts the numeric types JSON decoding can produce
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// analyzePhotos tool
+func registerAnalyzePhotos(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "analyzePhotos",
+		Description: "Queue machine learning analysis for assets: smart-search embeddings, face detection, and metadata extraction via the Immich jobs API",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"assetIds": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Assets to analyze",
+				},
+				"smartSearch":     map[string]interface{}{"type": "boolean", "description": "Recompute smart-search embeddings", "default": true},
+				"detectFaces":     map[string]interface{}{"type": "boolean", "description": "Re-run face detection", "default": true},
+				"extractMetadata": map[string]interface{}{"type": "boolean", "description": "Re-extract EXIF metadata", "default": false},
+			},
+			Required: []string{"assetIds"},
 		},
 	}
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		var params struct {
-			AssetIds         []string `json:"assetIds"`
-			AlbumName        string   `json:"albumName"`
-			CreateAlbum      bool     `json:"createAlbum"`
-			AlbumDescription string   `json:"albumDescription"`
+			AssetIDs        []string `json:"assetIds"`
+			SmartSearch     *bool    `json:"smartSearch"`
+			DetectFaces     *bool    `json:"detectFaces"`
+			ExtractMetadata bool     `json:"extractMetadata"`
 		}
 
-		argBytes, ok := request.Params.Arguments.([]byte)
-		if !ok {
-			argBytes, _ = json.Marshal(request.Params.Arguments)
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
 		}
-		if err := json.Unmarshal(argBytes, &params); err != nil {
-			return nil, fmt.Errorf("invalid parameters: %w", err)
+		if len(params.AssetIDs) == 0 {
+			return nil, fmt.Errorf("assetIds is required")
 		}
 
-		if len(params.AssetIds) == 0 {
-			return makeMCPResult(map[string]interface{}{
-				"success": false,
-				"message": "No asset IDs provided",
-			})
+		var jobNames []string
+		if params.SmartSearch == nil || *params.SmartSearch {
+			jobNames = append(jobNames, "smart-search")
 		}
-
-		// Find existing album
-		var albumID string
-		var albumFound bool
-		albums, err := immichClient.ListAlbums(ctx, false)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list albums: %w", err)
+		if params.DetectFaces == nil || *params.DetectFaces {
+			jobNames = append(jobNames, "face-detection")
 		}
-
-		for _, album := range albums {
-			if album.AlbumName == params.AlbumName {
-				albumID = album.ID
-				albumFound = true
-				break
-			}
+		if params.ExtractMetadata {
+			jobNames = append(jobNames, "refresh-metadata")
+		}
+		if len(jobNames) == 0 {
+			return nil, fmt.Errorf("at least one analysis job must be enabled")
 		}
 
-		// Create album if needed
-		if !albumFound {
-			if !params.CreateAlbum {
-				return nil, fmt.Errorf("album '%s' not found and createAlbum is false", params.AlbumName)
+		queued := make([]map[string]interface{}, 0, len(jobNames))
+		for _, name := range jobNames {
+			if err := immichClient.RunAssetJobs(ctx, params.AssetIDs, name); err != nil {
+				return nil, fmt.Errorf("failed to queue %s job: %w", name, err)
 			}
-
-			newAlbum, err := immichClient.CreateAlbum(ctx, immich.CreateAlbumParams{
-				Name:        params.AlbumName,
-				Description: params.AlbumDescription,
+			queued = append(queued, map[string]interface{}{
+				"job":    name,
+				"assets": len(params.AssetIDs),
 			})
-			if err != nil {
-				return nil, fmt.Errorf("failed to create album: %w", err)
-			}
-			albumID = newAlbum.ID
-		}
-
-		// Add assets to album
-		bulkResult, err := immichClient.AddAssetsToAlbum(ctx, albumID, params.AssetIds)
-		if err != nil {
-			return nil, fmt.Errorf("failed to add assets to album: %w", err)
-		}
-
-		result := map[string]interface{}{
-			"success":      true,
-			"albumID":      albumID,
-			"albumName":    params.AlbumName,
-			"albumCreated": !albumFound,
-			"movedCount":   len(bulkResult.Success),
-			"failedCount":  len(bulkResult.Error),
-		}
-
-		if len(bulkResult.Error) > 0 {
-			result["failedAssets"] = bulkResult.Error
 		}
 
-		return makeMCPResult(result)
+		return makeMCPResult(map[string]interface{}{
+			"success": true,
+			"jobs":    queued,
+			"message": fmt.Sprintf("Queued %s for %d assets; check progress with getJobStatus", strings.Join(jobNames, ", "), len(params.AssetIDs)),
+		})
 	}
 
 	s.AddTool(tool, handler)
 }
 
-func registerListLibraries(s *server.MCPServer, immichClient *immich.Client, cacheStore *cache.Cache) {
-	// Implementation similar to above
-}
+// getJobStatus tool
+func registerGetJobStatus(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "getJobStatus",
+		Description: "Report the state of Immich's job queues (active, waiting, and failed counts per queue)",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"queue": map[string]interface{}{
+					"type":        "string",
+					"description": "Only report this queue (e.g. smartSearch, faceDetection, metadataExtraction)",
+				},
+			},
+		},
+	}
 
-func registerMoveToLibrary(s *server.MCPServer, immichClient *immich.Client) {
-	// Implementation similar to above
-}
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Queue string `json:"queue"`
+		}
 
-func registerFindBrokenFiles(s *server.MCPServer, immichClient *immich.Client) {
-	// Implementation similar to above
-}
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
 
-func registerRepairAssets(s *server.MCPServer, immichClient *immich.Client) {
-	// Implementation similar to above
-}
+		statuses, err := immichClient.GetJobStatuses(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job statuses: %w", err)
+		}
 
-func registerUpdateAssetMetadata(s *server.MCPServer, immichClient *immich.Client) {
-	// Implementation similar to above
-}
+		if params.Queue != "" {
+			status, found := statuses[params.Queue]
+			if !found {
+				names := make([]string, 0, len(statuses))
+				for name := range statuses {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				return nil, fmt.Errorf("unknown queue: %s (available: %s)", params.Queue, strings.Join(names, ", "))
+			}
+			return makeMCPResult(map[string]interface{}{
+				"success": true,
+				"queue":   params.Queue,
+				"status":  status,
+				"message": fmt.Sprintf("Job queue %s status", params.Queue),
+			})
+		}
 
-func registerAnalyzePhotos(s *server.MCPServer, immichClient *immich.Client) {
-	// Implementation similar to above
-}
+		return makeMCPResult(map[string]interface{}{
+			"success": true,
+			"queues":  statuses,
+			"message": fmt.Sprintf("%d job queues reported", len(statuses)),
+		})
+	}
 
-func registerExportPhotos(s *server.MCPServer, immichClient *immich.Client) {
-	// Implementation similar to above
+	s.AddTool(tool, handler)
 }
 
 func registerGetAllAssets(s *server.MCPServer, immichClient *immich.Client, cacheStore *cache.Cache) {
@@ -506,26 +1587,36 @@ func registerGetAllAssets(s *server.MCPServer, immichClient *immich.Client, cach
 					"maximum":     1000,
 					"default":     50,
 				},
+				"includeLocked": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include assets in the locked/private space",
+					"default":     false,
+				},
+				"asResourceLinks": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Return assets as MCP resource links instead of inline JSON (automatic for large pages)",
+					"default":     false,
+				},
+				"verbosity": verbositySchema(),
 			},
 		},
 	}
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		var params struct {
-			Page     int `json:"page"`
-			PageSize int `json:"pageSize"`
+			Page            int    `json:"page"`
+			PageSize        int    `json:"pageSize"`
+			IncludeLocked   bool   `json:"includeLocked"`
+			AsResourceLinks bool   `json:"asResourceLinks"`
+			Verbosity       string `json:"verbosity"`
 		}
 
 		// Set defaults
 		params.Page = 1
 		params.PageSize = 50
 
-		argBytes, ok := request.Params.Arguments.([]byte)
-		if !ok {
-			argBytes, _ = json.Marshal(request.Params.Arguments)
-		}
-		if err := json.Unmarshal(argBytes, &params); err != nil {
-			return nil, fmt.Errorf("invalid parameters: %w", err)
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
 		}
 
 		// Validate parameters
@@ -540,37 +1631,41 @@ func registerGetAllAssets(s *server.MCPServer, immichClient *immich.Client, cach
 		}
 
 		// Check cache for this specific page
-		cacheKey := fmt.Sprintf("getAllAssets:page:%d:size:%d", params.Page, params.PageSize)
+		cacheKey := fmt.Sprintf("getAllAssets:page:%d:size:%d:locked:%t", params.Page, params.PageSize, params.IncludeLocked)
+		var assetPage *immich.AssetPage
 		if cached, found := cacheStore.Get(cacheKey); found {
-			return makeMCPResult(cached)
+			assetPage, _ = cached.(*immich.AssetPage)
 		}
+		if assetPage == nil {
+			var err error
+			assetPage, err = immichClient.GetAllAssets(ctx, params.Page, params.PageSize)
+			if err != nil {
+				return nil, err
+			}
 
-		assetPage, err := immichClient.GetAllAssets(ctx, params.Page, params.PageSize)
-		if err != nil {
-			return nil, err
+			assetPage.Assets = excludeLockedAssets(assetPage.Assets, params.IncludeLocked)
+
+			// Cache for 30 seconds (shorter than albums since data changes more frequently)
+			cacheStore.Set(cacheKey, assetPage, 30*time.Second)
 		}
 
-		result := map[string]interface{}{
+		assets := trimAssetsForVerbosity(assetPage.Assets, resolveVerbosity(params.Verbosity))
+
+		return makeAssetListResult(map[string]interface{}{
 			"success":     true,
-			"assets":      assetPage.Assets,
 			"page":        assetPage.Page,
 			"pageSize":    assetPage.PageSize,
-			"assetCount":  len(assetPage.Assets),
+			"assetCount":  len(assets),
 			"hasNextPage": assetPage.HasNextPage,
 			"totalCount":  assetPage.TotalCount,
-		}
-
-		// Cache for 30 seconds (shorter than albums since data changes more frequently)
-		cacheStore.Set(cacheKey, result, 30*time.Second)
-
-		return makeMCPResult(result)
+		}, "assets", assets, params.AsResourceLinks)
 	}
 
 	s.AddTool(tool, handler)
 }
 
 // registerMoveBrokenThumbnailsToAlbum registers the tool for moving images with no thumbhash
-func registerMoveBrokenThumbnailsToAlbum(s *server.MCPServer, immichClient *immich.Client) {
+func registerMoveBrokenThumbnailsToAlbum(s *server.MCPServer, immichClient *immich.Client, cacheStore *cache.Cache) {
 	tool := mcp.Tool{
 		Name:        "moveBrokenThumbnailsToAlbum",
 		Description: "Find all images with no thumbhash (broken thumbnails) and move them to a specified album",
@@ -601,6 +1696,8 @@ func registerMoveBrokenThumbnailsToAlbum(s *server.MCPServer, immichClient *immi
 					"description": "Starting page number for pagination",
 					"default":     1,
 				},
+				"async":  asyncSchema(),
+				"detail": detailSchema(),
 			},
 			Required: []string{"albumName"},
 		},
@@ -613,6 +1710,8 @@ func registerMoveBrokenThumbnailsToAlbum(s *server.MCPServer, immichClient *immi
 			DryRun      bool   `json:"dryRun"`
 			MaxImages   int    `json:"maxImages"`
 			StartPage   int    `json:"startPage"`
+			Async       bool   `json:"async"`
+			Detail      string `json:"detail"`
 		}
 
 		// Set defaults
@@ -620,126 +1719,95 @@ func registerMoveBrokenThumbnailsToAlbum(s *server.MCPServer, immichClient *immi
 		params.MaxImages = 1000
 		params.StartPage = 1
 
-		argBytes, ok := request.Params.Arguments.([]byte)
-		if !ok {
-			argBytes, _ = json.Marshal(request.Params.Arguments)
-		}
-		if err := json.Unmarshal(argBytes, &params); err != nil {
-			return nil, fmt.Errorf("invalid parameters: %w", err)
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
 		}
 
-		// Find images with no thumbhash
-		brokenImages := []immich.Asset{}
-		page := params.StartPage
-		pageSize := 1000 // Increased for efficiency
-		totalProcessed := 0
-
-		for params.MaxImages == 0 || len(brokenImages) < params.MaxImages {
-			// Check for context cancellation
-			select {
-			case <-ctx.Done():
-				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
-			default:
+		run := func(ctx context.Context, report func(string)) (map[string]interface{}, error) {
+			// Scan for images with no thumbhash
+			var scan *bulkops.ScanResult
+			matcher := func(ctx context.Context) ([]immich.Asset, error) {
+				report("scanning for images with no thumbhash")
+				var err error
+				scan, err = bulkops.ScanAssets(ctx, immichClient, bulkops.ScanOptions{
+					StartPage: params.StartPage,
+					Max:       params.MaxImages,
+					// The type filter is pushed down; thumbhash has no API
+					// filter and stays client-side
+					Filters: immich.MetadataSearchFilters{Type: "IMAGE"},
+				}, func(asset immich.Asset) bool {
+					// Simple check: IMAGE type with no thumbhash
+					return asset.Type == "IMAGE" && asset.Thumbhash == ""
+				})
+				if err != nil {
+					return nil, err
+				}
+				report(fmt.Sprintf("moving %d matched assets", len(scan.Assets)))
+				return scan.Assets, nil
 			}
 
-			assetPage, err := immichClient.GetAllAssets(ctx, page, pageSize)
+			opLog := beginOperationLog("moveBrokenThumbnailsToAlbum")
+			defer opLog.Close()
+			moveResult, err := bulkops.MoveMatchingAssets(ctx, immichClient, matcher, bulkops.Target{
+				AlbumName:        params.AlbumName,
+				AlbumDescription: "Album for images with broken thumbnails (no thumbhash)",
+				CreateAlbum:      params.CreateAlbum,
+			}, bulkops.Options{
+				DryRun:          params.DryRun,
+				InvalidateCache: func() { invalidateAssetCaches(cacheStore) },
+				Guard:           checkAlbumLock,
+				Log:             opLog,
+				Undo:            undoJournal,
+			})
 			if err != nil {
-				return nil, fmt.Errorf("failed to get assets page %d: %w", page, err)
+				return nil, err
 			}
+			brokenImages := moveResult.Matched
 
-			totalProcessed += len(assetPage.Assets)
-
-			for _, asset := range assetPage.Assets {
-				// Simple check: IMAGE type with no thumbhash
-				if asset.Type == "IMAGE" && asset.Thumbhash == "" {
-					brokenImages = append(brokenImages, asset)
-					if params.MaxImages > 0 && len(brokenImages) >= params.MaxImages {
-						break
-					}
-				}
-			}
+			// Record findings for run-to-run trend reporting
+			recordMaintenanceScan(cacheStore, maintenanceCheckBrokenThumbnails, brokenImages)
 
-			if !assetPage.HasNextPage {
-				break
+			result := map[string]interface{}{
+				"foundBrokenImages": len(brokenImages),
+				"totalProcessed":    scan.TotalProcessed,
+				"lastPage":          scan.LastPage,
 			}
-			page++
-		}
-
-		result := map[string]interface{}{
-			"foundBrokenImages": len(brokenImages),
-			"totalProcessed":    totalProcessed,
-			"lastPage":          page,
-		}
 
-		// Include first few broken images in dry run for inspection
-		if params.DryRun {
-			sampleSize := 5
-			if len(brokenImages) < sampleSize {
-				sampleSize = len(brokenImages)
+			// Include first few broken images in dry run for inspection
+			if params.DryRun {
+				result["sampleBrokenImages"] = hydrateAssetSamples(ctx, immichClient, brokenImages, 5, params.Detail)
+				result["dryRun"] = true
+				result["eta"] = estimateBulkETA(immichClient, scan.LastPage+2)
+				result["message"] = fmt.Sprintf("Dry run: found %d images with no thumbhash", len(brokenImages))
+				return result, nil
 			}
-			result["sampleBrokenImages"] = brokenImages[:sampleSize]
-			result["dryRun"] = true
-			result["message"] = fmt.Sprintf("Dry run: found %d images with no thumbhash", len(brokenImages))
-			return makeMCPResult(result)
-		}
-
-		if len(brokenImages) == 0 {
-			result["message"] = "No broken thumbnail images found"
-			result["success"] = true
-			return makeMCPResult(result)
-		}
-
-		// Find or create album
-		var albumID string
-		var albumFound bool
-		albums, err := immichClient.ListAlbums(ctx, false)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list albums: %w", err)
-		}
 
-		for _, album := range albums {
-			if album.AlbumName == params.AlbumName {
-				albumID = album.ID
-				albumFound = true
-				break
+			if len(brokenImages) == 0 {
+				result["message"] = "No broken thumbnail images found"
+				result["success"] = true
+				return result, nil
 			}
-		}
 
-		if !albumFound {
-			if !params.CreateAlbum {
-				return nil, fmt.Errorf("album '%s' not found and createAlbum is false", params.AlbumName)
+			result["albumCreated"] = moveResult.AlbumCreated
+			result["movedCount"] = moveResult.Moved
+			result["failedCount"] = moveResult.Failed
+			if len(moveResult.Errors) > 0 {
+				result["errors"] = moveResult.Errors
 			}
+			result["albumID"] = moveResult.AlbumID
+			result["albumName"] = params.AlbumName
+			result["success"] = true
 
-			newAlbum, err := immichClient.CreateAlbum(ctx, immich.CreateAlbumParams{
-				Name:        params.AlbumName,
-				Description: "Album for images with broken thumbnails (no thumbhash)",
-			})
-			if err != nil {
-				return nil, fmt.Errorf("failed to create album: %w", err)
-			}
-			albumID = newAlbum.ID
-			result["albumCreated"] = true
-		} else {
-			result["albumCreated"] = false
+			return result, nil
 		}
 
-		// Move images to album
-		assetIDs := make([]string, len(brokenImages))
-		for i, img := range brokenImages {
-			assetIDs[i] = img.ID
+		if params.Async {
+			return startBackgroundJob("moveBrokenThumbnailsToAlbum", run)
 		}
-
-		bulkResult, err := immichClient.AddAssetsToAlbum(ctx, albumID, assetIDs)
+		result, err := run(ctx, func(string) {})
 		if err != nil {
-			return nil, fmt.Errorf("failed to add assets to album: %w", err)
+			return nil, err
 		}
-
-		result["movedCount"] = len(bulkResult.Success)
-		result["failedCount"] = len(bulkResult.Error)
-		result["albumID"] = albumID
-		result["albumName"] = params.AlbumName
-		result["success"] = true
-
 		return makeMCPResult(result)
 	}
 
@@ -747,7 +1815,7 @@ func registerMoveBrokenThumbnailsToAlbum(s *server.MCPServer, immichClient *immi
 }
 
 // registerMoveSmallImagesToAlbum registers the tool for moving small images
-func registerMoveSmallImagesToAlbum(s *server.MCPServer, immichClient *immich.Client) {
+func registerMoveSmallImagesToAlbum(s *server.MCPServer, immichClient *immich.Client, cacheStore *cache.Cache) {
 	tool := mcp.Tool{
 		Name:        "moveSmallImagesToAlbum",
 		Description: "Find all images 400x400 pixels or smaller and move them to a 'Small Images' album",
@@ -779,18 +1847,20 @@ func registerMoveSmallImagesToAlbum(s *server.MCPServer, immichClient *immich.Cl
 					"description": "Maximum number of images to process",
 					"default":     1000,
 				},
+				"async": asyncSchema(),
 			},
 		},
 	}
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		var params struct {
-			AlbumName     string `json:"albumName"`
-			MaxDimension  int    `json:"maxDimension"`
-			CreateAlbum   bool   `json:"createAlbum"`
-			DryRun        bool   `json:"dryRun"`
-			MaxImages     int    `json:"maxImages"`
-			StartPage     int    `json:"startPage"`
+			AlbumName    string `json:"albumName"`
+			MaxDimension int    `json:"maxDimension"`
+			CreateAlbum  bool   `json:"createAlbum"`
+			DryRun       bool   `json:"dryRun"`
+			MaxImages    int    `json:"maxImages"`
+			StartPage    int    `json:"startPage"`
+			Async        bool   `json:"async"`
 		}
 
 		// Set defaults
@@ -800,145 +1870,118 @@ func registerMoveSmallImagesToAlbum(s *server.MCPServer, immichClient *immich.Cl
 		params.MaxImages = 1000
 		params.StartPage = 1
 
-		argBytes, ok := request.Params.Arguments.([]byte)
-		if !ok {
-			argBytes, _ = json.Marshal(request.Params.Arguments)
-		}
-		if err := json.Unmarshal(argBytes, &params); err != nil {
-			return nil, fmt.Errorf("invalid parameters: %w", err)
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
 		}
 
-		// Find small images
-		smallImages := []immich.Asset{}
-		page := params.StartPage
-		pageSize := 1000 // Increased for efficiency
-		totalProcessed := 0
-
-		for params.MaxImages == 0 || len(smallImages) < params.MaxImages {
-			// Check for context cancellation
-			select {
-			case <-ctx.Done():
-				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
-			default:
-			}
-
-			assetPage, err := immichClient.GetAllAssets(ctx, page, pageSize)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get assets page %d: %w", page, err)
-			}
-
-			totalProcessed += len(assetPage.Assets)
-
-			for _, asset := range assetPage.Assets {
-				// Check if image is small
-				if asset.Type == "IMAGE" && asset.ExifInfo != nil {
+		run := func(ctx context.Context, report func(string)) (map[string]interface{}, error) {
+			// Scan for small images
+			var scan *bulkops.ScanResult
+			matcher := func(ctx context.Context) ([]immich.Asset, error) {
+				report("scanning for small images")
+				var err error
+				scan, err = bulkops.ScanAssets(ctx, immichClient, bulkops.ScanOptions{
+					StartPage: params.StartPage,
+					Max:       params.MaxImages,
+					// The type filter is pushed down; dimension checks need
+					// EXIF and stay client-side
+					Filters: immich.MetadataSearchFilters{Type: "IMAGE"},
+				}, func(asset immich.Asset) bool {
+					if asset.Type != "IMAGE" || asset.ExifInfo == nil {
+						return false
+					}
 					width := asset.ExifInfo.ExifImageWidth
 					height := asset.ExifInfo.ExifImageHeight
 
-					// Check if both dimensions are <= maxDimension (and > 0)
-					if width > 0 && height > 0 && width <= params.MaxDimension && height <= params.MaxDimension {
-						smallImages = append(smallImages, asset)
-						if params.MaxImages > 0 && len(smallImages) >= params.MaxImages {
-							break
-						}
-					}
+					// Both dimensions must be <= maxDimension (and > 0)
+					return width > 0 && height > 0 && width <= params.MaxDimension && height <= params.MaxDimension
+				})
+				if err != nil {
+					return nil, err
 				}
+				report(fmt.Sprintf("moving %d matched assets", len(scan.Assets)))
+				return scan.Assets, nil
 			}
 
-			if !assetPage.HasNextPage {
-				break
+			opLog := beginOperationLog("moveSmallImagesToAlbum")
+			defer opLog.Close()
+			moveResult, err := bulkops.MoveMatchingAssets(ctx, immichClient, matcher, bulkops.Target{
+				AlbumName:        params.AlbumName,
+				AlbumDescription: fmt.Sprintf("Album for small images (%dx%d or smaller)", params.MaxDimension, params.MaxDimension),
+				CreateAlbum:      params.CreateAlbum,
+			}, bulkops.Options{
+				DryRun:          params.DryRun,
+				InvalidateCache: func() { invalidateAssetCaches(cacheStore) },
+				Guard:           checkAlbumLock,
+				Log:             opLog,
+				Undo:            undoJournal,
+			})
+			if err != nil {
+				return nil, err
 			}
-			page++
-		}
-
-		result := map[string]interface{}{
-			"foundSmallImages": len(smallImages),
-			"maxDimension":     params.MaxDimension,
-			"totalProcessed":   totalProcessed,
-			"lastPage":         page,
-		}
+			smallImages := moveResult.Matched
 
-		// Include sample in dry run
-		if params.DryRun {
-			sampleSize := 5
-			if len(smallImages) < sampleSize {
-				sampleSize = len(smallImages)
-			}
+			// Record findings for run-to-run trend reporting
+			recordMaintenanceScan(cacheStore, maintenanceCheckSmallImages, smallImages)
 
-			sampleData := []map[string]interface{}{}
-			for i := 0; i < sampleSize; i++ {
-				img := smallImages[i]
-				sampleData = append(sampleData, map[string]interface{}{
-					"id":         img.ID,
-					"name":       img.OriginalFileName,
-					"width":      img.ExifInfo.ExifImageWidth,
-					"height":     img.ExifInfo.ExifImageHeight,
-				})
+			result := map[string]interface{}{
+				"foundSmallImages": len(smallImages),
+				"maxDimension":     params.MaxDimension,
+				"totalProcessed":   scan.TotalProcessed,
+				"lastPage":         scan.LastPage,
 			}
 
-			result["sampleSmallImages"] = sampleData
-			result["dryRun"] = true
-			result["message"] = fmt.Sprintf("Dry run: found %d images <= %dx%d pixels", len(smallImages), params.MaxDimension, params.MaxDimension)
-			return makeMCPResult(result)
-		}
-
-		if len(smallImages) == 0 {
-			result["message"] = fmt.Sprintf("No images smaller than %dx%d found", params.MaxDimension, params.MaxDimension)
-			result["success"] = true
-			return makeMCPResult(result)
-		}
+			// Include sample in dry run
+			if params.DryRun {
+				sampleSize := 5
+				if len(smallImages) < sampleSize {
+					sampleSize = len(smallImages)
+				}
 
-		// Find or create album
-		var albumID string
-		var albumFound bool
-		albums, err := immichClient.ListAlbums(ctx, false)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list albums: %w", err)
-		}
+				sampleData := []map[string]interface{}{}
+				for i := 0; i < sampleSize; i++ {
+					img := smallImages[i]
+					sampleData = append(sampleData, map[string]interface{}{
+						"id":     img.ID,
+						"name":   img.OriginalFileName,
+						"width":  img.ExifInfo.ExifImageWidth,
+						"height": img.ExifInfo.ExifImageHeight,
+					})
+				}
 
-		for _, album := range albums {
-			if album.AlbumName == params.AlbumName {
-				albumID = album.ID
-				albumFound = true
-				break
+				result["sampleSmallImages"] = sampleData
+				result["dryRun"] = true
+				result["eta"] = estimateBulkETA(immichClient, scan.LastPage+2)
+				result["message"] = fmt.Sprintf("Dry run: found %d images <= %dx%d pixels", len(smallImages), params.MaxDimension, params.MaxDimension)
+				return result, nil
 			}
-		}
 
-		if !albumFound {
-			if !params.CreateAlbum {
-				return nil, fmt.Errorf("album '%s' not found and createAlbum is false", params.AlbumName)
+			if len(smallImages) == 0 {
+				result["message"] = fmt.Sprintf("No images smaller than %dx%d found", params.MaxDimension, params.MaxDimension)
+				result["success"] = true
+				return result, nil
 			}
 
-			newAlbum, err := immichClient.CreateAlbum(ctx, immich.CreateAlbumParams{
-				Name:        params.AlbumName,
-				Description: fmt.Sprintf("Album for small images (%dx%d or smaller)", params.MaxDimension, params.MaxDimension),
-			})
-			if err != nil {
-				return nil, fmt.Errorf("failed to create album: %w", err)
+			result["albumCreated"] = moveResult.AlbumCreated
+			result["movedCount"] = moveResult.Moved
+			result["failedCount"] = moveResult.Failed
+			if len(moveResult.Errors) > 0 {
+				result["errors"] = moveResult.Errors
 			}
-			albumID = newAlbum.ID
-			result["albumCreated"] = true
-		} else {
-			result["albumCreated"] = false
-		}
+			result["albumID"] = moveResult.AlbumID
+			result["albumName"] = params.AlbumName
+			result["success"] = true
 
-		// Move images to album
-		assetIDs := make([]string, len(smallImages))
-		for i, img := range smallImages {
-			assetIDs[i] = img.ID
+			return result, nil
 		}
 
-		bulkResult, err := immichClient.AddAssetsToAlbum(ctx, albumID, assetIDs)
+		if params.Async {
+			return startBackgroundJob("moveSmallImagesToAlbum", run)
+		}
+		result, err := run(ctx, func(string) {})
 		if err != nil {
-			return nil, fmt.Errorf("failed to add assets to album: %w", err)
+			return nil, err
 		}
-
-		result["movedCount"] = len(bulkResult.Success)
-		result["failedCount"] = len(bulkResult.Error)
-		result["albumID"] = albumID
-		result["albumName"] = params.AlbumName
-		result["success"] = true
-
 		return makeMCPResult(result)
 	}
 
@@ -946,7 +1989,7 @@ func registerMoveSmallImagesToAlbum(s *server.MCPServer, immichClient *immich.Cl
 }
 
 // registerMoveLargeMoviesToAlbum registers the tool for moving large movies
-func registerMoveLargeMoviesToAlbum(s *server.MCPServer, immichClient *immich.Client) {
+func registerMoveLargeMoviesToAlbum(s *server.MCPServer, immichClient *immich.Client, cacheStore *cache.Cache) {
 	tool := mcp.Tool{
 		Name:        "moveLargeMoviesToAlbum",
 		Description: "Find all movies over 20 minutes and move them to a 'Large Movies' album",
@@ -983,18 +2026,20 @@ func registerMoveLargeMoviesToAlbum(s *server.MCPServer, immichClient *immich.Cl
 					"description": "Starting page number for pagination",
 					"default":     1,
 				},
+				"async": asyncSchema(),
 			},
 		},
 	}
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		var params struct {
-			AlbumName    string `json:"albumName"`
-			MinDuration  int    `json:"minDuration"`
-			CreateAlbum  bool   `json:"createAlbum"`
-			DryRun       bool   `json:"dryRun"`
-			MaxVideos    int    `json:"maxVideos"`
-			StartPage    int    `json:"startPage"`
+			AlbumName   string `json:"albumName"`
+			MinDuration int    `json:"minDuration"`
+			CreateAlbum bool   `json:"createAlbum"`
+			DryRun      bool   `json:"dryRun"`
+			MaxVideos   int    `json:"maxVideos"`
+			StartPage   int    `json:"startPage"`
+			Async       bool   `json:"async"`
 		}
 
 		// Set defaults
@@ -1004,151 +2049,123 @@ func registerMoveLargeMoviesToAlbum(s *server.MCPServer, immichClient *immich.Cl
 		params.MaxVideos = 1000
 		params.StartPage = 1
 
-		argBytes, ok := request.Params.Arguments.([]byte)
-		if !ok {
-			argBytes, _ = json.Marshal(request.Params.Arguments)
-		}
-		if err := json.Unmarshal(argBytes, &params); err != nil {
-			return nil, fmt.Errorf("invalid parameters: %w", err)
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
 		}
 
 		// Convert minimum duration to seconds
 		minDurationSec := params.MinDuration * 60
 
-		// Find large movies
-		largeMovies := []immich.Asset{}
-		page := params.StartPage
-		pageSize := 1000
-		totalProcessed := 0
-
-		for params.MaxVideos == 0 || len(largeMovies) < params.MaxVideos {
-			// Check for context cancellation
-			select {
-			case <-ctx.Done():
-				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
-			default:
-			}
-
-			assetPage, err := immichClient.GetAllAssets(ctx, page, pageSize)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get assets page %d: %w", page, err)
-			}
-
-			totalProcessed += len(assetPage.Assets)
-
-			for _, asset := range assetPage.Assets {
-				// Check if it's a video with duration
-				if asset.Type == "VIDEO" && asset.Duration != nil {
-					// Parse duration string (format: "H:MM:SS.mmmmm")
-					durationSec := parseDuration(*asset.Duration)
-					if durationSec >= minDurationSec {
-						largeMovies = append(largeMovies, asset)
-						if params.MaxVideos > 0 && len(largeMovies) >= params.MaxVideos {
-							break
-						}
+		run := func(ctx context.Context, report func(string)) (map[string]interface{}, error) {
+			// Scan for large movies
+			var scan *bulkops.ScanResult
+			matcher := func(ctx context.Context) ([]immich.Asset, error) {
+				report("scanning for large movies")
+				var err error
+				scan, err = bulkops.ScanAssets(ctx, immichClient, bulkops.ScanOptions{
+					StartPage: params.StartPage,
+					Max:       params.MaxVideos,
+					// The type filter is pushed down; there is no duration
+					// range filter so that check stays client-side
+					Filters: immich.MetadataSearchFilters{Type: "VIDEO"},
+				}, func(asset immich.Asset) bool {
+					if asset.Type != "VIDEO" || asset.Duration == nil {
+						return false
 					}
+					// Parse duration string (format: "H:MM:SS.mmmmm")
+					return parseDuration(*asset.Duration) >= minDurationSec
+				})
+				if err != nil {
+					return nil, err
 				}
+				report(fmt.Sprintf("moving %d matched assets", len(scan.Assets)))
+				return scan.Assets, nil
 			}
 
-			if !assetPage.HasNextPage {
-				break
+			opLog := beginOperationLog("moveLargeMoviesToAlbum")
+			defer opLog.Close()
+			moveResult, err := bulkops.MoveMatchingAssets(ctx, immichClient, matcher, bulkops.Target{
+				AlbumName:        params.AlbumName,
+				AlbumDescription: fmt.Sprintf("Movies over %d minutes", params.MinDuration),
+				CreateAlbum:      params.CreateAlbum,
+			}, bulkops.Options{
+				DryRun:          params.DryRun,
+				InvalidateCache: func() { invalidateAssetCaches(cacheStore) },
+				Guard:           checkAlbumLock,
+				Log:             opLog,
+				Undo:            undoJournal,
+			})
+			if err != nil {
+				return nil, err
 			}
-			page++
-		}
+			largeMovies := moveResult.Matched
 
-		result := map[string]interface{}{
-			"foundLargeMovies": len(largeMovies),
-			"minDuration":      params.MinDuration,
-			"totalProcessed":   totalProcessed,
-			"lastPage":         page,
-		}
+			// Record findings for run-to-run trend reporting
+			recordMaintenanceScan(cacheStore, maintenanceCheckLargeMovies, largeMovies)
 
-		// Include sample in dry run
-		if params.DryRun {
-			sampleSize := 5
-			if len(largeMovies) < sampleSize {
-				sampleSize = len(largeMovies)
+			result := map[string]interface{}{
+				"foundLargeMovies": len(largeMovies),
+				"minDuration":      params.MinDuration,
+				"totalProcessed":   scan.TotalProcessed,
+				"lastPage":         scan.LastPage,
 			}
 
-			sampleData := []map[string]interface{}{}
-			for i := 0; i < sampleSize; i++ {
-				movie := largeMovies[i]
-				durationMin := 0
-				if movie.Duration != nil {
-					durationMin = parseDuration(*movie.Duration) / 60
+			// Include sample in dry run
+			if params.DryRun {
+				sampleSize := 5
+				if len(largeMovies) < sampleSize {
+					sampleSize = len(largeMovies)
 				}
-				sampleData = append(sampleData, map[string]interface{}{
-					"id":       movie.ID,
-					"name":     movie.OriginalFileName,
-					"duration": *movie.Duration,
-					"minutes":  durationMin,
-				})
-			}
-
-			result["sampleLargeMovies"] = sampleData
-			result["dryRun"] = true
-			result["message"] = fmt.Sprintf("Dry run: found %d movies over %d minutes", len(largeMovies), params.MinDuration)
-			result["success"] = true
-			return makeMCPResult(result)
-		}
-
-		if len(largeMovies) == 0 {
-			result["message"] = fmt.Sprintf("No movies over %d minutes found", params.MinDuration)
-			result["success"] = true
-			return makeMCPResult(result)
-		}
 
-		// Find or create album
-		var albumID string
-		var albumFound bool
-		albums, err := immichClient.ListAlbums(ctx, false)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list albums: %w", err)
-		}
+				sampleData := []map[string]interface{}{}
+				for i := 0; i < sampleSize; i++ {
+					movie := largeMovies[i]
+					durationMin := 0
+					if movie.Duration != nil {
+						durationMin = parseDuration(*movie.Duration) / 60
+					}
+					sampleData = append(sampleData, map[string]interface{}{
+						"id":       movie.ID,
+						"name":     movie.OriginalFileName,
+						"duration": *movie.Duration,
+						"minutes":  durationMin,
+					})
+				}
 
-		for _, album := range albums {
-			if album.AlbumName == params.AlbumName {
-				albumID = album.ID
-				albumFound = true
-				break
+				result["sampleLargeMovies"] = sampleData
+				result["dryRun"] = true
+				result["eta"] = estimateBulkETA(immichClient, scan.LastPage+2)
+				result["message"] = fmt.Sprintf("Dry run: found %d movies over %d minutes", len(largeMovies), params.MinDuration)
+				result["success"] = true
+				return result, nil
 			}
-		}
 
-		if !albumFound {
-			if !params.CreateAlbum {
-				return nil, fmt.Errorf("album '%s' not found and createAlbum is false", params.AlbumName)
+			if len(largeMovies) == 0 {
+				result["message"] = fmt.Sprintf("No movies over %d minutes found", params.MinDuration)
+				result["success"] = true
+				return result, nil
 			}
 
-			newAlbum, err := immichClient.CreateAlbum(ctx, immich.CreateAlbumParams{
-				Name:        params.AlbumName,
-				Description: fmt.Sprintf("Movies over %d minutes", params.MinDuration),
-			})
-			if err != nil {
-				return nil, fmt.Errorf("failed to create album: %w", err)
+			result["albumCreated"] = moveResult.AlbumCreated
+			result["movedCount"] = moveResult.Moved
+			result["failedCount"] = moveResult.Failed
+			if len(moveResult.Errors) > 0 {
+				result["errors"] = moveResult.Errors
 			}
-			albumID = newAlbum.ID
-			result["albumCreated"] = true
-		} else {
-			result["albumCreated"] = false
-		}
+			result["albumID"] = moveResult.AlbumID
+			result["albumName"] = params.AlbumName
+			result["success"] = true
 
-		// Move movies to album
-		movieIDs := make([]string, len(largeMovies))
-		for i, movie := range largeMovies {
-			movieIDs[i] = movie.ID
+			return result, nil
 		}
 
-		bulkResult, err := immichClient.AddAssetsToAlbum(ctx, albumID, movieIDs)
+		if params.Async {
+			return startBackgroundJob("moveLargeMoviesToAlbum", run)
+		}
+		result, err := run(ctx, func(string) {})
 		if err != nil {
-			return nil, fmt.Errorf("failed to add movies to album: %w", err)
+			return nil, err
 		}
-
-		result["movedCount"] = len(bulkResult.Success)
-		result["failedCount"] = len(bulkResult.Error)
-		result["albumID"] = albumID
-		result["albumName"] = params.AlbumName
-		result["success"] = true
-
 		return makeMCPResult(result)
 	}
 
@@ -1213,29 +2230,25 @@ func registerMovePersonalVideosFromAlbum(s *server.MCPServer, immichClient *immi
 		params.SourceAlbum = "Large Movies"
 		params.TargetAlbum = "Personal Videos"
 		params.Patterns = []string{
-			"^\\d{8}_",     // Date format: 20160525_
+			"^\\d{8}_",              // Date format: 20160525_
 			"^\\d{4}-\\d{2}-\\d{2}", // Date format: 2024-01-15
-			"^IMG_",        // iPhone/camera format
-			"^VID_",        // Video format
-			"^MOV_",        // Movie format
-			"^DSC",         // Digital camera
-			"^DSCN",        // Nikon
-			"^GOPR",        // GoPro
-			"^DJI_",        // DJI drone
-			"^PXL_",        // Pixel phone
-			"^FILE",        // Generic file
-			"\\.MOV$",       // MOV extension (personal videos)
-			"\\.mov$",       // mov extension
+			"^IMG_",                 // iPhone/camera format
+			"^VID_",                 // Video format
+			"^MOV_",                 // Movie format
+			"^DSC",                  // Digital camera
+			"^DSCN",                 // Nikon
+			"^GOPR",                 // GoPro
+			"^DJI_",                 // DJI drone
+			"^PXL_",                 // Pixel phone
+			"^FILE",                 // Generic file
+			"\\.MOV$",               // MOV extension (personal videos)
+			"\\.mov$",               // mov extension
 		}
 		params.CreateAlbum = true
 		params.RemoveFromSource = true
 
-		argBytes, ok := request.Params.Arguments.([]byte)
-		if !ok {
-			argBytes, _ = json.Marshal(request.Params.Arguments)
-		}
-		if err := json.Unmarshal(argBytes, &params); err != nil {
-			return nil, fmt.Errorf("invalid parameters: %w", err)
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
 		}
 
 		// Find source album
@@ -1262,10 +2275,13 @@ func registerMovePersonalVideosFromAlbum(s *server.MCPServer, immichClient *immi
 			return nil, fmt.Errorf("failed to get assets from source album: %w", err)
 		}
 
-		// Identify personal videos
-		personalVideos := []immich.Asset{}
-		for _, asset := range sourceAssets {
-			if asset.Type == "VIDEO" {
+		// Identify personal videos and move them via the shared engine
+		matcher := func(ctx context.Context) ([]immich.Asset, error) {
+			personalVideos := []immich.Asset{}
+			for _, asset := range sourceAssets {
+				if asset.Type != "VIDEO" {
+					continue
+				}
 				// Check if filename matches any personal video pattern
 				for _, pattern := range params.Patterns {
 					matched, _ := regexp.MatchString(pattern, asset.OriginalFileName)
@@ -1275,11 +2291,36 @@ func registerMovePersonalVideosFromAlbum(s *server.MCPServer, immichClient *immi
 					}
 				}
 			}
+			return personalVideos, nil
+		}
+
+		removeFrom := ""
+		if params.RemoveFromSource {
+			removeFrom = sourceAlbumID
+		}
+		opLog := beginOperationLog("movePersonalVideosFromAlbum")
+		defer opLog.Close()
+		moveResult, err := bulkops.MoveMatchingAssets(ctx, immichClient, matcher, bulkops.Target{
+			AlbumName:        params.TargetAlbum,
+			AlbumDescription: "Personal videos from phones, cameras, and other devices",
+			CreateAlbum:      params.CreateAlbum,
+		}, bulkops.Options{
+			DryRun:            params.DryRun,
+			RemoveFromAlbumID: removeFrom,
+			Journal:           operationJournal,
+			Operation:         "movePersonalVideosFromAlbum",
+			Guard:             checkAlbumLock,
+			Log:               opLog,
+			Undo:              undoJournal,
+		})
+		if err != nil {
+			return nil, err
 		}
+		personalVideos := moveResult.Matched
 
 		result := map[string]interface{}{
-			"sourceAlbum":        params.SourceAlbum,
-			"targetAlbum":        params.TargetAlbum,
+			"sourceAlbum":         params.SourceAlbum,
+			"targetAlbum":         params.TargetAlbum,
 			"totalVideosInSource": len(sourceAssets),
 			"personalVideosFound": len(personalVideos),
 		}
@@ -1318,64 +2359,23 @@ func registerMovePersonalVideosFromAlbum(s *server.MCPServer, immichClient *immi
 			return makeMCPResult(result)
 		}
 
-		// Find or create target album
-		var targetAlbumID string
-		var targetAlbumFound bool
-
-		for _, album := range albums {
-			if album.AlbumName == params.TargetAlbum {
-				targetAlbumID = album.ID
-				targetAlbumFound = true
-				break
-			}
-		}
-
-		if !targetAlbumFound {
-			if !params.CreateAlbum {
-				return nil, fmt.Errorf("target album '%s' not found and createAlbum is false", params.TargetAlbum)
-			}
-
-			newAlbum, err := immichClient.CreateAlbum(ctx, immich.CreateAlbumParams{
-				Name:        params.TargetAlbum,
-				Description: "Personal videos from phones, cameras, and other devices",
-			})
-			if err != nil {
-				return nil, fmt.Errorf("failed to create target album: %w", err)
-			}
-			targetAlbumID = newAlbum.ID
-			result["targetAlbumCreated"] = true
-		} else {
-			result["targetAlbumCreated"] = false
-		}
-
-		// Move videos to target album
-		videoIDs := make([]string, len(personalVideos))
-		for i, video := range personalVideos {
-			videoIDs[i] = video.ID
-		}
-
-		bulkResult, err := immichClient.AddAssetsToAlbum(ctx, targetAlbumID, videoIDs)
-		if err != nil {
-			return nil, fmt.Errorf("failed to add videos to target album: %w", err)
+		result["targetAlbumCreated"] = moveResult.AlbumCreated
+		result["movedCount"] = moveResult.Moved
+		result["failedCount"] = moveResult.Failed
+		if len(moveResult.Errors) > 0 {
+			result["errors"] = moveResult.Errors
 		}
 
-		result["movedCount"] = len(bulkResult.Success)
-		result["failedCount"] = len(bulkResult.Error)
-
-		// Remove from source album if requested
-		if params.RemoveFromSource && len(bulkResult.Success) > 0 {
-			removeResult, err := immichClient.RemoveAssetsFromAlbum(ctx, sourceAlbumID, bulkResult.Success)
-			if err != nil {
-				result["removeError"] = fmt.Sprintf("failed to remove from source: %v", err)
-			} else {
-				result["removedFromSource"] = len(removeResult.Success)
-			}
+		if moveResult.RemoveError != "" {
+			result["removeError"] = moveResult.RemoveError
+		} else if params.RemoveFromSource {
+			result["removedFromSource"] = moveResult.RemovedFromSource
 		}
 
-		result["targetAlbumID"] = targetAlbumID
+		result["targetAlbumID"] = moveResult.AlbumID
 		result["success"] = true
 		result["message"] = fmt.Sprintf("Moved %d personal videos from %s to %s",
-			len(bulkResult.Success), params.SourceAlbum, params.TargetAlbum)
+			moveResult.Moved, params.SourceAlbum, params.TargetAlbum)
 
 		return makeMCPResult(result)
 	}
@@ -1409,6 +2409,11 @@ func registerDeleteAlbumContents(s *server.MCPServer, immichClient *immich.Clien
 					"description": "Just count assets without deleting them",
 					"default":     false,
 				},
+				"recycle": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Snapshot the removed asset IDs into the recycle store first, restorable via restoreRecycleSnapshot",
+					"default":     true,
+				},
 				"batchSize": map[string]interface{}{
 					"type":        "integer",
 					"description": "Number of assets to delete in each batch",
@@ -1419,6 +2424,16 @@ func registerDeleteAlbumContents(s *server.MCPServer, immichClient *immich.Clien
 					"description": "Maximum number of assets to delete (0 for all)",
 					"default":     0,
 				},
+				"expectedChecksum": map[string]interface{}{
+					"type":        "string",
+					"description": "contentsChecksum from a prior dryRun; the delete aborts if the album's composition changed since then",
+				},
+				"maxFailureRatePercent": map[string]interface{}{
+					"type":        "integer",
+					"description": "Abort the run when more than this percent of processed assets fail to delete (0 disables)",
+					"default":     20,
+				},
+				"detail": detailSchema(),
 			},
 			Required: []string{},
 		},
@@ -1426,23 +2441,25 @@ func registerDeleteAlbumContents(s *server.MCPServer, immichClient *immich.Clien
 
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		var params struct {
-			AlbumName   string `json:"albumName"`
-			AlbumID     string `json:"albumId"`
-			ForceDelete bool   `json:"forceDelete"`
-			DryRun      bool   `json:"dryRun"`
-			BatchSize   int    `json:"batchSize"`
-			MaxAssets   int    `json:"maxAssets"`
+			AlbumName             string `json:"albumName"`
+			AlbumID               string `json:"albumId"`
+			ForceDelete           bool   `json:"forceDelete"`
+			DryRun                bool   `json:"dryRun"`
+			Recycle               bool   `json:"recycle"`
+			BatchSize             int    `json:"batchSize"`
+			MaxAssets             int    `json:"maxAssets"`
+			ExpectedChecksum      string `json:"expectedChecksum"`
+			MaxFailureRatePercent int    `json:"maxFailureRatePercent"`
+			Detail                string `json:"detail"`
 		}
 
 		// Set defaults
+		params.Recycle = true
 		params.BatchSize = 100
+		params.MaxFailureRatePercent = 20
 
-		argBytes, ok := request.Params.Arguments.([]byte)
-		if !ok {
-			argBytes, _ = json.Marshal(request.Params.Arguments)
-		}
-		if err := json.Unmarshal(argBytes, &params); err != nil {
-			return nil, fmt.Errorf("invalid parameters: %w", err)
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
 		}
 
 		// Find album if not provided by ID
@@ -1453,27 +2470,25 @@ func registerDeleteAlbumContents(s *server.MCPServer, immichClient *immich.Clien
 			albumID = params.AlbumID
 			albumName = params.AlbumName // May be empty
 		} else if params.AlbumName != "" {
-			// Search for album by name
-			albums, err := immichClient.ListAlbums(ctx, false)
+			// Resolve by name, asking the client to disambiguate similar
+			// names rather than guessing which album to empty
+			album, candidates, err := findAlbumInteractive(ctx, s, immichClient, params.AlbumName)
 			if err != nil {
-				return nil, fmt.Errorf("failed to list albums: %w", err)
-			}
-
-			for _, album := range albums {
-				if album.AlbumName == params.AlbumName {
-					albumID = album.ID
-					albumName = album.AlbumName
-					break
-				}
+				return nil, err
 			}
-
-			if albumID == "" {
-				return nil, fmt.Errorf("album '%s' not found", params.AlbumName)
+			if album == nil {
+				return albumCandidatesResult(params.AlbumName, candidates)
 			}
+			albumID = album.ID
+			albumName = album.AlbumName
 		} else {
 			return nil, fmt.Errorf("either albumName or albumId must be provided")
 		}
 
+		if err := checkAlbumLock(albumID, albumName); err != nil {
+			return nil, err
+		}
+
 		// Get all assets in the album
 		assets, err := immichClient.GetAlbumAssets(ctx, albumID)
 		if err != nil {
@@ -1497,40 +2512,53 @@ func registerDeleteAlbumContents(s *server.MCPServer, immichClient *immich.Clien
 		}
 
 		result := map[string]interface{}{
-			"albumID":         albumID,
-			"albumName":       albumName,
-			"totalAssets":     len(assets),
-			"assetsToDelete":  len(assetsToDelete),
+			"albumID":        albumID,
+			"albumName":      albumName,
+			"totalAssets":    len(assets),
+			"assetsToDelete": len(assetsToDelete),
 		}
 
 		if params.DryRun {
 			// Just return count and sample
-			sampleSize := 5
-			if len(assetsToDelete) < sampleSize {
-				sampleSize = len(assetsToDelete)
-			}
-
-			sampleData := []map[string]interface{}{}
-			for i := 0; i < sampleSize; i++ {
-				asset := assetsToDelete[i]
-				sampleData = append(sampleData, map[string]interface{}{
-					"id":       asset.ID,
-					"fileName": asset.OriginalFileName,
-					"type":     asset.Type,
-				})
-			}
-
-			result["sampleAssets"] = sampleData
+			result["sampleAssets"] = hydrateAssetSamples(ctx, immichClient, assetsToDelete, 5, params.Detail)
 			result["dryRun"] = true
-			result["message"] = fmt.Sprintf("Dry run: would delete %d assets from album", len(assetsToDelete))
+			result["eta"] = estimateBulkETA(immichClient, (len(assetsToDelete)+params.BatchSize-1)/params.BatchSize+1)
+			result["contentsChecksum"] = assetSetChecksum(assetsToDelete)
+			result["message"] = fmt.Sprintf("Dry run: would delete %d assets from album; pass contentsChecksum as expectedChecksum to guard the live run", len(assetsToDelete))
 			result["success"] = true
 			return makeMCPResult(result)
 		}
 
+		// Refuse to run against a different asset set than the one the dry
+		// run was approved on
+		if params.ExpectedChecksum != "" {
+			if checksum := assetSetChecksum(assetsToDelete); checksum != params.ExpectedChecksum {
+				return nil, fmt.Errorf("album contents changed since the dry run (checksum %s, expected %s); re-run with dryRun=true and review before deleting", checksum, params.ExpectedChecksum)
+			}
+		}
+
+		// Snapshot the asset IDs before deleting so the removal can be
+		// undone via restoreRecycleSnapshot
+		if params.Recycle {
+			deleteIDs := make([]string, len(assetsToDelete))
+			for i, asset := range assetsToDelete {
+				deleteIDs[i] = asset.ID
+			}
+			snapshotID, err := recordRecycleSnapshot(albumID, albumName, "deleteAlbumContents", deleteIDs)
+			if err != nil {
+				return nil, err
+			}
+			if snapshotID != "" {
+				result["recycleSnapshotId"] = snapshotID
+			}
+		}
+
 		// Delete assets in batches
 		deleted := 0
 		failed := 0
 		var deleteErrors []string
+		var trashedIDs []string
+		monitor := &failureRateMonitor{maxPercent: params.MaxFailureRatePercent}
 
 		for i := 0; i < len(assetsToDelete); i += params.BatchSize {
 			// Check for context cancellation
@@ -1559,11 +2587,37 @@ func registerDeleteAlbumContents(s *server.MCPServer, immichClient *immich.Clien
 			if err != nil {
 				failed += len(batch)
 				deleteErrors = append(deleteErrors, fmt.Sprintf("batch %d-%d: %v", i, end, err))
+				monitor.record(len(batch), len(batch))
 			} else {
 				deleted += len(batch)
+				trashedIDs = append(trashedIDs, batchIDs...)
+				monitor.record(len(batch), 0)
+			}
+
+			// Abort runs that are failing en masse rather than grinding
+			// through the rest of the album
+			if monitor.tripped() {
+				result["deleted"] = deleted
+				result["failed"] = failed
+				result["remaining"] = len(assetsToDelete) - end
+				result["aborted"] = true
+				result["errors"] = deleteErrors
+				result["success"] = false
+				result["message"] = fmt.Sprintf("Aborted: %d%% of processed assets failed to delete (threshold %d%%); check errors before retrying", monitor.rate(), params.MaxFailureRatePercent)
+				return makeMCPResult(result)
 			}
 		}
 
+		// Only trashing is reversible; a force delete has no inverse
+		if !params.ForceDelete && len(trashedIDs) > 0 {
+			recordUndo(undo.Operation{
+				Tool:     "deleteAlbumContents",
+				Kind:     undo.KindTrash,
+				AlbumID:  albumID,
+				AssetIDs: trashedIDs,
+			})
+		}
+
 		result["deleted"] = deleted
 		result["failed"] = failed
 		result["forceDelete"] = params.ForceDelete
@@ -1617,6 +2671,12 @@ func registerMovePhotosBySearch(s *server.MCPServer, immichClient *immich.Client
 					"description": "Just show search results without creating album",
 					"default":     false,
 				},
+				"withPeople": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include detected people names per sampled asset in dry run output",
+					"default":     false,
+				},
+				"async": asyncSchema(),
 			},
 			Required: []string{"query", "albumName"},
 		},
@@ -1629,116 +2689,112 @@ func registerMovePhotosBySearch(s *server.MCPServer, immichClient *immich.Client
 			MaxResults  int    `json:"maxResults"`
 			CreateAlbum bool   `json:"createAlbum"`
 			DryRun      bool   `json:"dryRun"`
+			WithPeople  bool   `json:"withPeople"`
+			Async       bool   `json:"async"`
 		}
 
 		// Set defaults
 		params.MaxResults = 100
 		params.CreateAlbum = true
 
-		argBytes, ok := request.Params.Arguments.([]byte)
-		if !ok {
-			argBytes, _ = json.Marshal(request.Params.Arguments)
-		}
-		if err := json.Unmarshal(argBytes, &params); err != nil {
-			return nil, fmt.Errorf("invalid parameters: %w", err)
-		}
-
-		// Perform smart search
-		searchResults, err := immichClient.SmartSearch(ctx, params.Query, params.MaxResults)
-		if err != nil {
-			return nil, fmt.Errorf("smart search failed: %w", err)
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
 		}
 
-		result := map[string]interface{}{
-			"query":        params.Query,
-			"albumName":    params.AlbumName,
-			"foundAssets":  len(searchResults),
-			"maxResults":   params.MaxResults,
-		}
+		run := func(ctx context.Context, report func(string)) (map[string]interface{}, error) {
+			// Perform smart search and move the results via the shared engine
+			matcher := func(ctx context.Context) ([]immich.Asset, error) {
+				report(fmt.Sprintf("searching for '%s'", params.Query))
+				searchResults, err := immichClient.SmartSearch(ctx, params.Query, params.MaxResults)
+				if err != nil {
+					return nil, fmt.Errorf("smart search failed: %w", err)
+				}
+				report(fmt.Sprintf("moving %d matched assets", len(searchResults)))
+				return searchResults, nil
+			}
 
-		if len(searchResults) == 0 {
-			result["message"] = fmt.Sprintf("No assets found for query: %s", params.Query)
-			result["success"] = true
-			return makeMCPResult(result)
-		}
+			opLog := beginOperationLog("movePhotosBySearch")
+			defer opLog.Close()
+			moveResult, err := bulkops.MoveMatchingAssets(ctx, immichClient, matcher, bulkops.Target{
+				AlbumName:        params.AlbumName,
+				AlbumDescription: fmt.Sprintf("Photos from search: %s", params.Query),
+				CreateAlbum:      params.CreateAlbum,
+			}, bulkops.Options{
+				DryRun: params.DryRun,
+				Guard:  checkAlbumLock,
+				Log:    opLog,
+				Undo:   undoJournal,
+			})
+			if err != nil {
+				return nil, err
+			}
+			searchResults := moveResult.Matched
 
-		// In dry run, show sample results
-		if params.DryRun {
-			sampleSize := 10
-			if len(searchResults) < sampleSize {
-				sampleSize = len(searchResults)
+			result := map[string]interface{}{
+				"query":       params.Query,
+				"albumName":   params.AlbumName,
+				"foundAssets": len(searchResults),
+				"maxResults":  params.MaxResults,
 			}
 
-			sampleData := []map[string]interface{}{}
-			for i := 0; i < sampleSize; i++ {
-				asset := searchResults[i]
-				sampleData = append(sampleData, map[string]interface{}{
-					"id":       asset.ID,
-					"fileName": asset.OriginalFileName,
-					"type":     asset.Type,
-					"date":     asset.FileCreatedAt,
-				})
+			if len(searchResults) == 0 {
+				result["message"] = fmt.Sprintf("No assets found for query: %s", params.Query)
+				result["success"] = true
+				return result, nil
 			}
 
-			result["sampleResults"] = sampleData
-			result["dryRun"] = true
-			result["message"] = fmt.Sprintf("Dry run: found %d assets for '%s'", len(searchResults), params.Query)
-			result["success"] = true
-			return makeMCPResult(result)
-		}
+			// In dry run, show sample results
+			if params.DryRun {
+				sampleSize := 10
+				if len(searchResults) < sampleSize {
+					sampleSize = len(searchResults)
+				}
 
-		// Find or create album
-		var albumID string
-		var albumFound bool
-		albums, err := immichClient.ListAlbums(ctx, false)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list albums: %w", err)
-		}
+				sampleData := []map[string]interface{}{}
+				for i := 0; i < sampleSize; i++ {
+					asset := searchResults[i]
+					assetInfo := map[string]interface{}{
+						"id":       asset.ID,
+						"fileName": asset.OriginalFileName,
+						"type":     asset.Type,
+						"date":     asset.FileCreatedAt,
+					}
+					if params.WithPeople {
+						if names := hydratePeopleNames(ctx, immichClient, asset); len(names) > 0 {
+							assetInfo["people"] = names
+						}
+					}
+					sampleData = append(sampleData, assetInfo)
+				}
 
-		for _, album := range albums {
-			if album.AlbumName == params.AlbumName {
-				albumID = album.ID
-				albumFound = true
-				break
+				result["sampleResults"] = sampleData
+				result["dryRun"] = true
+				result["message"] = fmt.Sprintf("Dry run: found %d assets for '%s'", len(searchResults), params.Query)
+				result["success"] = true
+				return result, nil
 			}
-		}
 
-		if !albumFound {
-			if !params.CreateAlbum {
-				return nil, fmt.Errorf("album '%s' not found and createAlbum is false", params.AlbumName)
+			result["albumCreated"] = moveResult.AlbumCreated
+			result["albumID"] = moveResult.AlbumID
+			result["movedCount"] = moveResult.Moved
+			result["failedCount"] = moveResult.Failed
+			if len(moveResult.Errors) > 0 {
+				result["errors"] = moveResult.Errors
 			}
+			result["success"] = true
+			result["message"] = fmt.Sprintf("Added %d assets from search '%s' to album '%s'",
+				moveResult.Moved, params.Query, params.AlbumName)
 
-			newAlbum, err := immichClient.CreateAlbum(ctx, immich.CreateAlbumParams{
-				Name:        params.AlbumName,
-				Description: fmt.Sprintf("Photos from search: %s", params.Query),
-			})
-			if err != nil {
-				return nil, fmt.Errorf("failed to create album: %w", err)
-			}
-			albumID = newAlbum.ID
-			result["albumCreated"] = true
-		} else {
-			result["albumCreated"] = false
+			return result, nil
 		}
 
-		// Add assets to album
-		assetIDs := make([]string, len(searchResults))
-		for i, asset := range searchResults {
-			assetIDs[i] = asset.ID
+		if params.Async {
+			return startBackgroundJob("movePhotosBySearch", run)
 		}
-
-		bulkResult, err := immichClient.AddAssetsToAlbum(ctx, albumID, assetIDs)
+		result, err := run(ctx, func(string) {})
 		if err != nil {
-			return nil, fmt.Errorf("failed to add assets to album: %w", err)
+			return nil, err
 		}
-
-		result["albumID"] = albumID
-		result["movedCount"] = len(bulkResult.Success)
-		result["failedCount"] = len(bulkResult.Error)
-		result["success"] = true
-		result["message"] = fmt.Sprintf("Added %d assets from search '%s' to album '%s'",
-			len(bulkResult.Success), params.Query, params.AlbumName)
-
 		return makeMCPResult(result)
 	}
 
@@ -1772,6 +2828,11 @@ func registerSmartSearchAdvanced(s *server.MCPServer, immichClient *immich.Clien
 					"items":       map[string]interface{}{"type": "string"},
 					"description": "Filter by specific tag IDs",
 				},
+				"tagPaths": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Filter by tag paths (e.g. 'animals/dogs'); descendant tags are included automatically",
+				},
 				"city": map[string]interface{}{
 					"type":        "string",
 					"description": "Filter by city name",
@@ -1903,6 +2964,17 @@ func registerSmartSearchAdvanced(s *server.MCPServer, immichClient *immich.Clien
 					"type":        "string",
 					"description": "Language for search query processing",
 				},
+				"withPeople": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include detected people names per sampled asset (requires per-asset detail lookups)",
+					"default":     false,
+				},
+				"includeLocked": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include assets in the locked/private space",
+					"default":     false,
+				},
+				"aggregateOnly": aggregateOnlySchema(),
 			},
 		},
 	}
@@ -1913,6 +2985,7 @@ func registerSmartSearchAdvanced(s *server.MCPServer, immichClient *immich.Clien
 			AlbumIds      []string `json:"albumIds"`
 			PersonIds     []string `json:"personIds"`
 			TagIds        []string `json:"tagIds"`
+			TagPaths      []string `json:"tagPaths"`
 			City          string   `json:"city"`
 			Country       string   `json:"country"`
 			State         string   `json:"state"`
@@ -1942,17 +3015,16 @@ func registerSmartSearchAdvanced(s *server.MCPServer, immichClient *immich.Clien
 			Rating        *int     `json:"rating"`
 			Size          int      `json:"size"`
 			Language      string   `json:"language"`
+			WithPeople    bool     `json:"withPeople"`
+			IncludeLocked bool     `json:"includeLocked"`
+			AggregateOnly bool     `json:"aggregateOnly"`
 		}
 
 		// Set default size
 		params.Size = 100
 
-		argBytes, ok := request.Params.Arguments.([]byte)
-		if !ok {
-			argBytes, _ = json.Marshal(request.Params.Arguments)
-		}
-		if err := json.Unmarshal(argBytes, &params); err != nil {
-			return nil, fmt.Errorf("invalid parameters: %w", err)
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
 		}
 
 		// Convert to immich.SmartSearchParams
@@ -1961,6 +3033,7 @@ func registerSmartSearchAdvanced(s *server.MCPServer, immichClient *immich.Clien
 			AlbumIds:      params.AlbumIds,
 			PersonIds:     params.PersonIds,
 			TagIds:        params.TagIds,
+			TagPaths:      params.TagPaths,
 			City:          params.City,
 			Country:       params.Country,
 			State:         params.State,
@@ -1998,6 +3071,9 @@ func registerSmartSearchAdvanced(s *server.MCPServer, immichClient *immich.Clien
 			return nil, fmt.Errorf("smart search failed: %w", err)
 		}
 
+		// Locked assets stay private unless explicitly requested
+		searchResults = excludeLockedAssets(searchResults, params.IncludeLocked || params.Visibility == "locked")
+
 		// Build active filters list for clarity
 		var activeFilters []string
 		if params.Query != "" {
@@ -2012,6 +3088,9 @@ func registerSmartSearchAdvanced(s *server.MCPServer, immichClient *immich.Clien
 		if params.IsNotInAlbum != nil && *params.IsNotInAlbum {
 			activeFilters = append(activeFilters, "not in albums")
 		}
+		if len(params.TagPaths) > 0 {
+			activeFilters = append(activeFilters, fmt.Sprintf("tagPaths=%s", strings.Join(params.TagPaths, ",")))
+		}
 		if params.City != "" {
 			activeFilters = append(activeFilters, fmt.Sprintf("city=%s", params.City))
 		}
@@ -2028,6 +3107,13 @@ func registerSmartSearchAdvanced(s *server.MCPServer, immichClient *immich.Clien
 			"requestedSize": params.Size,
 		}
 
+		// Numbers only: skip samples and the asset ID list entirely
+		if params.AggregateOnly {
+			result["aggregateOnly"] = true
+			result["aggregate"] = aggregateAssets(searchResults)
+			return makeMCPResult(result)
+		}
+
 		// Include sample results
 		sampleSize := 10
 		if len(searchResults) < sampleSize {
@@ -2044,6 +3130,13 @@ func registerSmartSearchAdvanced(s *server.MCPServer, immichClient *immich.Clien
 				"date":     asset.FileCreatedAt,
 			}
 
+			// Add detected people names when requested
+			if params.WithPeople {
+				if names := hydratePeopleNames(ctx, immichClient, asset); len(names) > 0 {
+					assetInfo["people"] = names
+				}
+			}
+
 			// Add location info if available
 			if asset.ExifInfo != nil {
 				if asset.ExifInfo.City != "" || asset.ExifInfo.Country != "" {
@@ -2095,6 +3188,27 @@ func registerSmartSearchAdvanced(s *server.MCPServer, immichClient *immich.Clien
 	s.AddTool(tool, handler)
 }
 
+// hydratePeopleNames returns the named people detected on an asset, fetching
+// asset details when the search result did not include them.
+func hydratePeopleNames(ctx context.Context, immichClient *immich.Client, asset immich.Asset) []string {
+	people := asset.People
+	if len(people) == 0 {
+		detailed, err := immichClient.GetAssetMetadata(ctx, asset.ID)
+		if err != nil {
+			return nil
+		}
+		people = detailed.People
+	}
+
+	var names []string
+	for _, person := range people {
+		if person.Name != "" && !person.IsHidden {
+			names = append(names, person.Name)
+		}
+	}
+	return names
+}
+
 // Helper function to parse duration string (format: "H:MM:SS.mmmmm" or "MM:SS.mmmmm")
 func parseDuration(duration string) int {
 	// Remove milliseconds if present
@@ -2124,10 +3238,19 @@ func parseDuration(duration string) int {
 
 // Helper function to create MCP result
 func makeMCPResult(data interface{}) (*mcp.CallToolResult, error) {
+	return makeMCPResultVerbose(data, defaultResultVerbosity)
+}
+
+// makeMCPResultVerbose is makeMCPResult with an explicit verbosity profile,
+// for tools that accept a per-request override.
+func makeMCPResultVerbose(data interface{}, level string) (*mcp.CallToolResult, error) {
+	if result, ok := data.(map[string]interface{}); ok {
+		applyVerbosityToResult(result, level)
+	}
 	content, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
 	}
 
 	return mcp.NewToolResultText(string(content)), nil
-}
\ No newline at end of file
+}
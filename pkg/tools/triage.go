@@ -0,0 +1,202 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/yourusername/mcp-immich/pkg/classify"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// registerTriageNonPhotos registers the tool that routes classified
+// non-photo content (documents, text screenshots, memes) out of the timeline.
+func registerTriageNonPhotos(s *server.MCPServer, immichClient *immich.Client, classifier *classify.Classifier) {
+	tool := mcp.Tool{
+		Name:        "triageNonPhotos",
+		Description: "Route classifier-detected documents, text screenshots, and memes into dedicated review albums or archive them, with per-category thresholds",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"categories": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Classifier labels to triage",
+					"default":     []string{"document", "screenshot", "meme"},
+				},
+				"thresholds": map[string]interface{}{
+					"type":        "object",
+					"description": "Per-category minimum confidence (e.g. {\"document\": 0.8}); defaults to 0.7",
+				},
+				"action": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"album", "archive"},
+					"description": "Move matches into per-category review albums, or archive them",
+					"default":     "album",
+				},
+				"albumPrefix": map[string]interface{}{
+					"type":        "string",
+					"description": "Prefix for review album names",
+					"default":     "Review: ",
+				},
+				"dryRun": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Report matches per category without moving or archiving",
+					"default":     false,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			Categories  []string           `json:"categories"`
+			Thresholds  map[string]float64 `json:"thresholds"`
+			Action      string             `json:"action"`
+			AlbumPrefix string             `json:"albumPrefix"`
+			DryRun      bool               `json:"dryRun"`
+		}
+
+		// Set defaults
+		params.Categories = []string{"document", "screenshot", "meme"}
+		params.Action = "album"
+		params.AlbumPrefix = "Review: "
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if params.Action != "album" && params.Action != "archive" {
+			return nil, fmt.Errorf("invalid action: %s", params.Action)
+		}
+
+		threshold := func(category string) float64 {
+			if t, ok := params.Thresholds[category]; ok {
+				return t
+			}
+			return 0.7
+		}
+
+		// Album lookup (only needed for the album action)
+		albumsByName := map[string]string{}
+		if params.Action == "album" && !params.DryRun {
+			albums, err := immichClient.ListAlbums(ctx, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list albums: %w", err)
+			}
+			for _, album := range albums {
+				albumsByName[album.AlbumName] = album.ID
+			}
+		}
+
+		type categoryReport struct {
+			Category  string  `json:"category"`
+			Threshold float64 `json:"threshold"`
+			Matched   int     `json:"matched"`
+			Routed    int     `json:"routed,omitempty"`
+			Failed    int     `json:"failed,omitempty"`
+			AlbumName string  `json:"albumName,omitempty"`
+			Error     string  `json:"error,omitempty"`
+		}
+
+		reports := []categoryReport{}
+		totalRouted := 0
+		seen := map[string]bool{} // an asset goes to its first matching category only
+
+		for _, category := range params.Categories {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+
+			report := categoryReport{Category: category, Threshold: threshold(category)}
+
+			matches := classifier.Search(category, report.Threshold)
+			var assetIDs []string
+			for _, match := range matches {
+				if !seen[match.AssetID] {
+					seen[match.AssetID] = true
+					assetIDs = append(assetIDs, match.AssetID)
+				}
+			}
+			report.Matched = len(assetIDs)
+
+			if params.DryRun || len(assetIDs) == 0 {
+				reports = append(reports, report)
+				continue
+			}
+
+			switch params.Action {
+			case "album":
+				albumName := params.AlbumPrefix + category
+				report.AlbumName = albumName
+
+				albumID, found := albumsByName[albumName]
+				if !found {
+					newAlbum, err := immichClient.CreateAlbum(ctx, immich.CreateAlbumParams{
+						Name:        albumName,
+						Description: fmt.Sprintf("Assets classified as '%s' pending review", category),
+					})
+					if err != nil {
+						report.Error = fmt.Sprintf("album creation failed: %v", err)
+						reports = append(reports, report)
+						continue
+					}
+					albumID = newAlbum.ID
+					albumsByName[albumName] = albumID
+				}
+
+				if err := checkAlbumLock(albumID, albumName); err != nil {
+					report.Error = err.Error()
+					reports = append(reports, report)
+					continue
+				}
+
+				bulkResult, err := immichClient.AddAssetsToAlbum(ctx, albumID, assetIDs)
+				if err != nil {
+					report.Error = fmt.Sprintf("adding assets failed: %v", err)
+					reports = append(reports, report)
+					continue
+				}
+				report.Routed = len(bulkResult.Success)
+				report.Failed = len(bulkResult.Error)
+
+			case "archive":
+				for _, id := range assetIDs {
+					err := immichClient.UpdateAssetMetadata(ctx, id, map[string]interface{}{
+						"isArchived": true,
+					})
+					if err != nil {
+						report.Failed++
+						continue
+					}
+					report.Routed++
+				}
+			}
+
+			totalRouted += report.Routed
+			reports = append(reports, report)
+		}
+
+		result := map[string]interface{}{
+			"success":    true,
+			"action":     params.Action,
+			"categories": reports,
+		}
+
+		if params.DryRun {
+			result["dryRun"] = true
+			result["message"] = "Dry run: reported matches per category without routing"
+		} else {
+			result["totalRouted"] = totalRouted
+			result["message"] = fmt.Sprintf("Routed %d assets across %d categories (%s)", totalRouted, len(reports), params.Action)
+		}
+
+		return makeMCPResult(result)
+	}
+
+	s.AddTool(tool, handler)
+}
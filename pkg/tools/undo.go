@@ -0,0 +1,208 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog/log"
+	"github.com/yourusername/mcp-immich/pkg/immich"
+	"github.com/yourusername/mcp-immich/pkg/undo"
+)
+
+// undoJournal records reversible mutations for rollback; set at startup
+var undoJournal *undo.Journal
+
+// SetUndoJournal wires the undo journal that mutating tools record into.
+func SetUndoJournal(j *undo.Journal) {
+	undoJournal = j
+}
+
+// recordUndo records a reversible mutation best-effort; a journal that
+// cannot be written never fails the operation it describes
+func recordUndo(op undo.Operation) {
+	if undoJournal == nil {
+		return
+	}
+	if _, err := undoJournal.Record(op); err != nil {
+		log.Warn().Err(err).Str("tool", op.Tool).Msg("Failed to record undo operation")
+	}
+}
+
+// captureMetadataPrevious fetches the current values of the fields about to
+// be overwritten, keyed by asset ID, so a metadata update can be undone.
+// Capture is best-effort: assets whose fetch fails are skipped.
+func captureMetadataPrevious(ctx context.Context, immichClient *immich.Client, assetIDs []string, updates map[string]interface{}) map[string]map[string]interface{} {
+	if undoJournal == nil {
+		return nil
+	}
+
+	previous := map[string]map[string]interface{}{}
+	for _, assetID := range assetIDs {
+		asset, err := immichClient.GetAssetMetadata(ctx, assetID)
+		if err != nil {
+			log.Warn().Err(err).Str("assetId", assetID).Msg("Failed to capture previous metadata for undo")
+			continue
+		}
+		fields := map[string]interface{}{}
+		for name := range updates {
+			switch name {
+			case "isFavorite":
+				fields[name] = asset.IsFavorite
+			case "isArchived":
+				fields[name] = asset.IsArchived
+			case "description":
+				if asset.ExifInfo != nil {
+					fields[name] = asset.ExifInfo.Description
+				}
+			case "rating":
+				if asset.ExifInfo != nil {
+					fields[name] = asset.ExifInfo.Rating
+				}
+			case "dateTimeOriginal":
+				if asset.ExifInfo != nil {
+					fields[name] = asset.ExifInfo.DateTimeOriginal
+				}
+			case "latitude":
+				if asset.ExifInfo != nil && asset.ExifInfo.Latitude != nil {
+					fields[name] = *asset.ExifInfo.Latitude
+				}
+			case "longitude":
+				if asset.ExifInfo != nil && asset.ExifInfo.Longitude != nil {
+					fields[name] = *asset.ExifInfo.Longitude
+				}
+			}
+		}
+		if len(fields) > 0 {
+			previous[assetID] = fields
+		}
+	}
+	return previous
+}
+
+// RegisterUndoTools registers the tool that lists and replays the inverse
+// of recorded reversible mutations
+func RegisterUndoTools(s *server.MCPServer, immichClient *immich.Client) {
+	tool := mcp.Tool{
+		Name:        "undoOperation",
+		Description: "Roll back a recorded reversible mutation (album add/remove, metadata change, trashing) by replaying its inverse; call without operationId to list what can be undone",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"operationId": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the recorded operation to undo; omit to list recent operations",
+				},
+				"limit": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of operations to list, newest first",
+					"default":     20,
+				},
+			},
+		},
+	}
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params struct {
+			OperationID string `json:"operationId"`
+			Limit       int    `json:"limit"`
+		}
+
+		// Set defaults
+		params.Limit = 20
+
+		if err := decodeArgs(request, &params); err != nil {
+			return nil, err
+		}
+
+		if undoJournal == nil {
+			return nil, fmt.Errorf("the undo journal is not enabled")
+		}
+
+		if params.OperationID == "" {
+			ops := undoJournal.List(params.Limit)
+			return makeMCPResult(map[string]interface{}{
+				"success":    true,
+				"count":      len(ops),
+				"operations": ops,
+				"message":    fmt.Sprintf("%d recorded operations; pass operationId to undo one", len(ops)),
+			})
+		}
+
+		op, found := undoJournal.Get(params.OperationID)
+		if !found {
+			return nil, fmt.Errorf("undo operation '%s' not found", params.OperationID)
+		}
+		if op.Undone {
+			return nil, fmt.Errorf("operation '%s' has already been undone", op.ID)
+		}
+
+		inverse, err := replayInverse(ctx, immichClient, op)
+		if err != nil {
+			return nil, err
+		}
+		if err := undoJournal.MarkUndone(op.ID); err != nil {
+			return nil, err
+		}
+
+		return makeMCPResult(map[string]interface{}{
+			"success":     true,
+			"operationId": op.ID,
+			"kind":        op.Kind,
+			"tool":        op.Tool,
+			"inverse":     inverse,
+			"assets":      len(op.AssetIDs),
+			"message":     fmt.Sprintf("Undid %s from %s (%s) across %d assets", op.Kind, op.Tool, inverse, len(op.AssetIDs)),
+		})
+	}
+
+	s.AddTool(tool, handler)
+}
+
+// replayInverse applies the inverse of a recorded operation and returns a
+// short description of what it did
+func replayInverse(ctx context.Context, immichClient *immich.Client, op undo.Operation) (string, error) {
+	switch op.Kind {
+	case undo.KindAlbumAdd:
+		if err := checkAlbumLock(op.AlbumID, ""); err != nil {
+			return "", err
+		}
+		if _, err := immichClient.RemoveAssetsFromAlbum(ctx, op.AlbumID, op.AssetIDs); err != nil {
+			return "", fmt.Errorf("failed to remove assets from album: %w", err)
+		}
+		return "removed assets from album", nil
+
+	case undo.KindAlbumRemove:
+		if err := checkAlbumLock(op.AlbumID, ""); err != nil {
+			return "", err
+		}
+		if _, err := immichClient.AddAssetsToAlbum(ctx, op.AlbumID, op.AssetIDs); err != nil {
+			return "", fmt.Errorf("failed to add assets back to album: %w", err)
+		}
+		return "added assets back to album", nil
+
+	case undo.KindMetadata:
+		for assetID, previous := range op.Previous {
+			// Check for context cancellation
+			select {
+			case <-ctx.Done():
+				return "", fmt.Errorf("operation cancelled: %w", ctx.Err())
+			default:
+			}
+			if err := immichClient.UpdateAssetsBulk(ctx, []string{assetID}, previous); err != nil {
+				return "", fmt.Errorf("failed to restore metadata for asset %s: %w", assetID, err)
+			}
+		}
+		return "restored previous metadata values", nil
+
+	case undo.KindTrash:
+		if err := immichClient.RestoreAssets(ctx, op.AssetIDs); err != nil {
+			return "", fmt.Errorf("failed to restore assets from trash: %w", err)
+		}
+		return "restored assets from trash", nil
+
+	default:
+		return "", fmt.Errorf("operation kind '%s' cannot be undone", op.Kind)
+	}
+}
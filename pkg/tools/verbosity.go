@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"strings"
+
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// Result verbosity profiles. The server-wide default comes from the
+// result_verbosity config key; individual tools can override it per request
+// via a "verbosity" parameter.
+const (
+	// VerbosityMinimal drops sample arrays, caps ID lists and strips EXIF,
+	// smart info and people from asset payloads — for small-context models.
+	VerbosityMinimal = "minimal"
+	// VerbosityStandard is the historical default output.
+	VerbosityStandard = "standard"
+	// VerbosityFull includes all available detail.
+	VerbosityFull = "full"
+)
+
+// minimalIDListCap is how many entries of an ID list survive under the
+// minimal profile.
+const minimalIDListCap = 20
+
+var defaultResultVerbosity = VerbosityStandard
+
+// SetDefaultVerbosity sets the server-wide result verbosity profile.
+// Unknown values are ignored and the standard profile is kept.
+func SetDefaultVerbosity(level string) {
+	switch level {
+	case VerbosityMinimal, VerbosityStandard, VerbosityFull:
+		defaultResultVerbosity = level
+	}
+}
+
+// resolveVerbosity picks the effective profile for a request: the per-request
+// value when valid, otherwise the configured default.
+func resolveVerbosity(requested string) string {
+	switch requested {
+	case VerbosityMinimal, VerbosityStandard, VerbosityFull:
+		return requested
+	}
+	return defaultResultVerbosity
+}
+
+// verbositySchema is the reusable schema fragment for the per-request
+// verbosity override parameter.
+func verbositySchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"enum":        []string{VerbosityMinimal, VerbosityStandard, VerbosityFull},
+		"description": "Override the configured result verbosity profile for this request",
+	}
+}
+
+// trimAssetsForVerbosity reduces per-asset detail for the minimal profile.
+// Standard and full profiles return the slice unchanged.
+func trimAssetsForVerbosity(assets []immich.Asset, level string) []immich.Asset {
+	if level != VerbosityMinimal {
+		return assets
+	}
+	trimmed := make([]immich.Asset, len(assets))
+	for i, asset := range assets {
+		asset.ExifInfo = nil
+		asset.SmartInfo = nil
+		asset.People = nil
+		trimmed[i] = asset
+	}
+	return trimmed
+}
+
+// applyVerbosityToResult prunes a result map in place according to the
+// profile: under minimal, sample arrays are dropped and string ID lists are
+// capped, with a truncation marker so callers know entries were omitted.
+func applyVerbosityToResult(result map[string]interface{}, level string) {
+	if level != VerbosityMinimal {
+		return
+	}
+	for key, value := range result {
+		if strings.HasPrefix(key, "sample") {
+			delete(result, key)
+			continue
+		}
+		if ids, ok := value.([]string); ok && len(ids) > minimalIDListCap {
+			result[key] = ids[:minimalIDListCap]
+			result[key+"Truncated"] = len(ids) - minimalIDListCap
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package tools
+
+import (
+	"time"
+
+	"github.com/yourusername/mcp-immich/pkg/immich"
+)
+
+// Analytics results carry a query watermark: the newest updatedAt the scan
+// observed plus when it ran. Drill-down calls pass watermark.updatedAt back
+// as asOf, which pins their scan to assets updated at or before that point,
+// keeping multi-call analyses internally consistent while uploads continue.
+
+// watermarkTracker folds the newest updatedAt out of a scan
+type watermarkTracker struct {
+	newest time.Time
+}
+
+// observe folds one asset into the watermark
+func (w *watermarkTracker) observe(asset immich.Asset) {
+	if asset.UpdatedAt.After(w.newest) {
+		w.newest = asset.UpdatedAt
+	}
+}
+
+// attach stamps the watermark into an analytics result
+func (w *watermarkTracker) attach(result map[string]interface{}) {
+	watermark := map[string]interface{}{
+		"computedAt": time.Now().Format(time.RFC3339),
+	}
+	if !w.newest.IsZero() {
+		watermark["updatedAt"] = w.newest.Format(time.RFC3339)
+	}
+	result["watermark"] = watermark
+}
+
+// asOfSchema is the shared schema fragment for the asOf parameter
+func asOfSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"format":      "date-time",
+		"description": "Pin the scan to assets updated at or before this timestamp (use watermark.updatedAt from a previous result) so drill-down calls see the same library state",
+	}
+}
@@ -0,0 +1,163 @@
+// Package undo persists a bounded journal of reversible mutations — album
+// adds and removals, metadata changes, trashing — with enough context to
+// replay the inverse, so an accidental bulk change can be rolled back.
+package undo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// defaultMaxOperations bounds the journal when no limit is configured.
+const defaultMaxOperations = 200
+
+// Kind classifies a reversible operation by the inverse that undoes it.
+type Kind string
+
+const (
+	// KindAlbumAdd records assets added to an album; the inverse removes them
+	KindAlbumAdd Kind = "albumAdd"
+	// KindAlbumRemove records assets removed from an album; the inverse adds
+	// them back
+	KindAlbumRemove Kind = "albumRemove"
+	// KindMetadata records changed metadata fields; the inverse restores the
+	// captured previous values per asset
+	KindMetadata Kind = "metadata"
+	// KindTrash records trashed assets; the inverse restores them from trash
+	KindTrash Kind = "trash"
+)
+
+// Operation is one recorded reversible mutation.
+type Operation struct {
+	ID       string   `json:"id"`
+	Tool     string   `json:"tool"`
+	Kind     Kind     `json:"kind"`
+	AlbumID  string   `json:"albumId,omitempty"`
+	AssetIDs []string `json:"assetIds"`
+	// Previous holds the prior metadata field values per asset for
+	// KindMetadata operations
+	Previous map[string]map[string]interface{} `json:"previous,omitempty"`
+	At       time.Time                         `json:"at"`
+	Undone   bool                              `json:"undone,omitempty"`
+}
+
+// Journal is a JSON-file-backed ring of reversible operations.
+type Journal struct {
+	path string
+	max  int
+	mu   sync.Mutex
+	ops  []*Operation
+}
+
+// New opens (or creates) an undo journal at path, keeping at most max
+// operations (0 for the default).
+func New(path string, max int) (*Journal, error) {
+	if max <= 0 {
+		max = defaultMaxOperations
+	}
+	j := &Journal{path: path, max: max}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return j, nil
+		}
+		return nil, fmt.Errorf("failed to read undo journal: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &j.ops); err != nil {
+			return nil, fmt.Errorf("failed to parse undo journal: %w", err)
+		}
+	}
+	if len(j.ops) > j.max {
+		j.ops = j.ops[len(j.ops)-j.max:]
+	}
+
+	return j, nil
+}
+
+// Record appends an operation, assigning its ID and evicting the oldest
+// when over the limit.
+func (j *Journal) Record(op Operation) (Operation, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	op.ID = fmt.Sprintf("undo-%d", time.Now().UnixNano())
+	op.At = time.Now()
+	j.ops = append(j.ops, &op)
+	if len(j.ops) > j.max {
+		j.ops = j.ops[len(j.ops)-j.max:]
+	}
+	if err := j.save(); err != nil {
+		return Operation{}, err
+	}
+	return op, nil
+}
+
+// Get returns a copy of the operation with the given ID.
+func (j *Journal) Get(id string) (Operation, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, op := range j.ops {
+		if op.ID == id {
+			return *op, true
+		}
+	}
+	return Operation{}, false
+}
+
+// List returns up to limit recorded operations, newest first.
+func (j *Journal) List(limit int) []Operation {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	ops := make([]Operation, 0, len(j.ops))
+	for i := len(j.ops) - 1; i >= 0; i-- {
+		ops = append(ops, *j.ops[i])
+		if limit > 0 && len(ops) >= limit {
+			break
+		}
+	}
+	return ops
+}
+
+// MarkUndone flags an operation as already replayed, so it cannot be undone
+// twice.
+func (j *Journal) MarkUndone(id string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, op := range j.ops {
+		if op.ID == id {
+			op.Undone = true
+			return j.save()
+		}
+	}
+	return fmt.Errorf("undo operation '%s' not found", id)
+}
+
+// save writes the journal atomically via a temp file rename. Caller must
+// hold the mutex.
+func (j *Journal) save() error {
+	data, err := json.MarshalIndent(j.ops, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal undo journal: %w", err)
+	}
+
+	tmp := j.path + ".tmp"
+	if err := os.MkdirAll(filepath.Dir(j.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create undo journal directory: %w", err)
+	}
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write undo journal: %w", err)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return fmt.Errorf("failed to replace undo journal file: %w", err)
+	}
+	return nil
+}
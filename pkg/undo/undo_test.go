@@ -0,0 +1,138 @@
+package undo
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestJournalRecordAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "undo_journal.json")
+
+	j, err := New(path, 0)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	first, err := j.Record(Operation{
+		Tool:     "moveAssetsToAlbum",
+		Kind:     KindAlbumAdd,
+		AlbumID:  "album-1",
+		AssetIDs: []string{"a", "b"},
+	})
+	if err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if first.ID == "" || first.At.IsZero() {
+		t.Fatalf("Record did not assign ID and timestamp: %+v", first)
+	}
+
+	second, err := j.Record(Operation{
+		Tool:     "updateAssetMetadata",
+		Kind:     KindMetadata,
+		AssetIDs: []string{"c"},
+		Previous: map[string]map[string]interface{}{
+			"c": {"isFavorite": true, "rating": float64(4)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	// A fresh Journal over the same file must see both operations
+	reloaded, err := New(path, 0)
+	if err != nil {
+		t.Fatalf("New over existing journal failed: %v", err)
+	}
+
+	ops := reloaded.List(0)
+	if len(ops) != 2 {
+		t.Fatalf("List returned %d operations, want 2", len(ops))
+	}
+	// Newest first
+	if ops[0].ID != second.ID || ops[1].ID != first.ID {
+		t.Errorf("List order wrong: got %s, %s", ops[0].ID, ops[1].ID)
+	}
+
+	got, ok := reloaded.Get(second.ID)
+	if !ok {
+		t.Fatalf("Get(%s) found nothing after reload", second.ID)
+	}
+	if got.Kind != KindMetadata || got.Previous["c"]["isFavorite"] != true {
+		t.Errorf("metadata operation lost detail across reload: %+v", got)
+	}
+}
+
+func TestJournalRingTruncation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "undo_journal.json")
+
+	j, err := New(path, 3)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := j.Record(Operation{
+			Tool:     "moveAssetsToAlbum",
+			Kind:     KindAlbumAdd,
+			AlbumID:  fmt.Sprintf("album-%d", i),
+			AssetIDs: []string{"a"},
+		}); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	ops := j.List(0)
+	if len(ops) != 3 {
+		t.Fatalf("List returned %d operations, want 3", len(ops))
+	}
+	// The two oldest were evicted; newest first leaves album-4..album-2
+	for i, want := range []string{"album-4", "album-3", "album-2"} {
+		if ops[i].AlbumID != want {
+			t.Errorf("ops[%d].AlbumID = %s, want %s", i, ops[i].AlbumID, want)
+		}
+	}
+
+	// Reopening with a smaller limit truncates the persisted ring too
+	reloaded, err := New(path, 2)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if got := len(reloaded.List(0)); got != 2 {
+		t.Errorf("reloaded journal holds %d operations, want 2", got)
+	}
+}
+
+func TestJournalMarkUndone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "undo_journal.json")
+
+	j, err := New(path, 0)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	op, err := j.Record(Operation{Tool: "deleteAlbumContents", Kind: KindTrash, AssetIDs: []string{"a"}})
+	if err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	if err := j.MarkUndone(op.ID); err != nil {
+		t.Fatalf("MarkUndone failed: %v", err)
+	}
+	if err := j.MarkUndone("undo-nope"); err == nil {
+		t.Error("MarkUndone of unknown ID succeeded, want error")
+	}
+
+	// The flag must survive a reload
+	reloaded, err := New(path, 0)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	got, ok := reloaded.Get(op.ID)
+	if !ok {
+		t.Fatalf("Get(%s) found nothing after reload", op.ID)
+	}
+	if !got.Undone {
+		t.Error("Undone flag lost across reload")
+	}
+}